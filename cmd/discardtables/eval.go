@@ -0,0 +1,205 @@
+package main
+
+import (
+	"github.com/joshprzybyszewski/cribbage/logic/scorer"
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// pairClassSizes is how many ways there are to pick each two card discard from a deck
+var pairClassSizes = countPairClasses()
+
+func countPairClasses() [strategy.NumPairClasses]float64 {
+	var sizes [strategy.NumPairClasses]float64
+	model.FullDeck.Combinations(2, func(pair model.CardSet) {
+		a, rest := pair.Next()
+		b, _ := rest.Next()
+		sizes[strategy.PairClass(a, b)]++
+	})
+	return sizes
+}
+
+// pairWeight is how likely the opponent is to discard the two cards, going by how
+// often they discard each two card discard. Without choices, every two cards are
+// as likely.
+func pairWeight(pair model.CardSet, choices []float64) float64 {
+	if choices == nil {
+		return 1
+	}
+	a, rest := pair.Next()
+	b, _ := rest.Next()
+	class := strategy.PairClass(a, b)
+	return choices[class] / pairClassSizes[class]
+}
+
+// singleWeight is pairWeight for a one card discard
+func singleWeight(c model.Card, choices []float64) float64 {
+	if choices == nil {
+		return 1
+	}
+	// there's one card of each suit
+	return choices[strategy.SingleClass(c)] / 4
+}
+
+// pairItems returns an item for each two card discard in a two player game, where
+// the opponent discards as often as the choices say
+func pairItems(choices []float64) []item {
+	items := make([]item, strategy.NumPairClasses)
+	for i := range items {
+		class := i
+		items[i] = item{
+			class: class,
+			eval: func() float64 {
+				return evalPair(class, choices)
+			},
+		}
+	}
+	return items
+}
+
+func evalPair(class int, choices []float64) float64 {
+	a, b := strategy.PairClassCards(class)
+	discard := model.NewCardSet(a, b)
+	rest := model.FullDeck.Without(discard)
+
+	var total, weights float64
+	rest.Combinations(2, func(opp model.CardSet) {
+		w := pairWeight(opp, choices)
+		if w == 0 {
+			return
+		}
+
+		crib := discard | opp
+		pts := 0
+		for cuts := rest.Without(opp); cuts != model.EmptyCardSet; {
+			var cut model.Card
+			cut, cuts = cuts.Next()
+			pts += scorer.CribPointsForSet(cut, crib)
+		}
+
+		total += w * float64(pts)
+		weights += w * float64(rest.Len()-opp.Len())
+	})
+
+	return total / weights
+}
+
+// singleItems returns an item for each one card discard in a three player game,
+// where the two opponents discard as often as their choices say, and the last card
+// in the crib comes off the deck
+func singleItems(first, second []float64) []item {
+	items := make([]item, strategy.NumSingleClasses)
+	for i := range items {
+		class := i
+		items[i] = item{
+			class: class,
+			eval: func() float64 {
+				return evalSingle(class, first, second)
+			},
+		}
+	}
+	return items
+}
+
+func evalSingle(class int, first, second []float64) float64 {
+	discard := model.NewCardSet(strategy.SingleClassCard(class))
+	rest := model.FullDeck.Without(discard)
+
+	var total, weights float64
+	for xs := rest; xs != model.EmptyCardSet; {
+		var x model.Card
+		x, xs = xs.Next()
+		wx := singleWeight(x, first)
+
+		for ys := rest.Remove(x); ys != model.EmptyCardSet; {
+			var y model.Card
+			y, ys = ys.Next()
+			w := wx * singleWeight(y, second)
+			if w == 0 {
+				continue
+			}
+
+			given := discard.Add(x).Add(y)
+			pts, num := 0, 0
+			rest.Without(given).Combinations(2, func(drawn model.CardSet) {
+				// either of the drawn cards could be the cut, with the other off the deck
+				pts += scorer.CribPointsForEachCut(given|drawn, drawn)
+				num += drawn.Len()
+			})
+
+			total += w * float64(pts)
+			weights += w * float64(num)
+		}
+	}
+
+	return total / weights
+}
+
+// pairChoices goes through every hand of six cards, and returns how often the dealer
+// and the pone discard each two cards. The dealer discards the most points to their
+// crib, and the pone the fewest, going by the expected points.
+func pairChoices(expected []float64) ([]float64, []float64) {
+	dealer := make([]float64, strategy.NumPairClasses)
+	pone := make([]float64, strategy.NumPairClasses)
+
+	var cards [6]model.Card
+	model.FullDeck.Combinations(6, func(hand model.CardSet) {
+		for i := range cards {
+			cards[i], hand = hand.Next()
+		}
+
+		most, least := -1, -1
+		for i := range cards {
+			for j := i + 1; j < len(cards); j++ {
+				class := strategy.PairClass(cards[i], cards[j])
+				if most < 0 || expected[class] > expected[most] {
+					most = class
+				}
+				if least < 0 || expected[class] < expected[least] {
+					least = class
+				}
+			}
+		}
+		dealer[most]++
+		pone[least]++
+	})
+
+	return normalize(dealer), normalize(pone)
+}
+
+// singleChoices is pairChoices for the five card hands of a three player game
+func singleChoices(expected []float64) ([]float64, []float64) {
+	dealer := make([]float64, strategy.NumSingleClasses)
+	pone := make([]float64, strategy.NumSingleClasses)
+
+	model.FullDeck.Combinations(5, func(hand model.CardSet) {
+		most, least := -1, -1
+		for hand != model.EmptyCardSet {
+			var c model.Card
+			c, hand = hand.Next()
+
+			class := strategy.SingleClass(c)
+			if most < 0 || expected[class] > expected[most] {
+				most = class
+			}
+			if least < 0 || expected[class] < expected[least] {
+				least = class
+			}
+		}
+		dealer[most]++
+		pone[least]++
+	})
+
+	return normalize(dealer), normalize(pone)
+}
+
+func normalize(counts []float64) []float64 {
+	total := 0.0
+	for _, c := range counts {
+		total += c
+	}
+	for i := range counts {
+		counts[i] /= total
+	}
+	return counts
+}
@@ -0,0 +1,63 @@
+// discardtables generates the tables of how many points the crib is expected to
+// score with each discard in it, which the strategies look up instead of working
+// them out on every turn.
+//
+// The expected points are worked out in two passes. The first assumes the other
+// players give the crib any of their cards. The second assumes that the other
+// players discard what the first pass says is best for them: the dealer gives the
+// most to their own crib, and the pone gives the least. Neither pass knows what
+// the player kept in their hand, so the tables only depend on the discard.
+//
+// It takes a while, so each expected value is saved to the progress file as soon
+// as it's worked out. Running it again picks up where it left off.
+package main
+
+import (
+	"flag"
+	"log"
+	"runtime"
+)
+
+var (
+	out      = flag.String(`out`, `logic/strategy/discard_tables_gen.go`, `The go file to write the encoded tables to`)
+	progress = flag.String(`progress`, `discard_tables.progress`, `The file that keeps track of what's been worked out so far`) //nolint:lll
+	workers  = flag.Int(`workers`, runtime.NumCPU(), `How many expected values to work out at once`)
+)
+
+func main() {
+	flag.Parse()
+
+	err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate() error {
+	p, err := openProgress(*progress)
+	if err != nil {
+		return err
+	}
+	defer p.close()
+
+	uniformPairs, err := p.run(`pairs/uniform`, pairItems(nil), *workers)
+	if err != nil {
+		return err
+	}
+	uniformSingles, err := p.run(`singles/uniform`, singleItems(nil, nil), *workers)
+	if err != nil {
+		return err
+	}
+
+	log.Println(`working out what the dealer and pone discard`)
+	dealerPairChoices, ponePairChoices := pairChoices(uniformPairs)
+	dealerSingleChoices, poneSingleChoices := singleChoices(uniformSingles)
+
+	dt, err := p.tables(dealerPairChoices, ponePairChoices, dealerSingleChoices, poneSingleChoices)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("writing the tables to %s\n", *out)
+	return writeTables(*out, dt)
+}
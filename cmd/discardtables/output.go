@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
+)
+
+const bytesPerLine = 24
+
+// writeTables writes a go file with the encoded tables in it, so that they're built
+// into anything that uses the strategies
+func writeTables(fn string, dt strategy.DiscardTables) error {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/discardtables; DO NOT EDIT.\n\n")
+	buf.WriteString("package strategy\n\n")
+	buf.WriteString("// discardTablesData is the encoded DiscardTables\n")
+	buf.WriteString("const discardTablesData = \"\"")
+
+	data := dt.Encode()
+	for len(data) > 0 {
+		n := bytesPerLine
+		if n > len(data) {
+			n = len(data)
+		}
+		buf.WriteString(" +\n\t\"")
+		for _, b := range data[:n] {
+			fmt.Fprintf(&buf, `\x%02x`, b)
+		}
+		buf.WriteString(`"`)
+		data = data[n:]
+	}
+	buf.WriteString("\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fn, src, 0644)
+}
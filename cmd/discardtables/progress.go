@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
+)
+
+// item is one expected value to work out
+type item struct {
+	class int
+	eval  func() float64
+}
+
+// progressFile remembers every expected value that's been worked out, one per line
+type progressFile struct {
+	lock sync.Mutex
+	f    *os.File
+	done map[string]float64
+}
+
+func openProgress(fn string) (*progressFile, error) {
+	f, err := os.OpenFile(fn, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &progressFile{
+		f:    f,
+		done: map[string]float64{},
+	}
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// the last line may have been cut off part way through writing it, so it's
+	// dropped to be worked out again
+	complete := bytes.LastIndexByte(b, '\n') + 1
+	if complete < len(b) {
+		err = f.Truncate(int64(complete))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	for _, line := range strings.Split(string(b[:complete]), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		p.done[fields[0]] = v
+	}
+
+	if len(p.done) > 0 {
+		log.Printf("picking up from %d expected values in %s\n", len(p.done), fn)
+	}
+	return p, nil
+}
+
+func (p *progressFile) close() {
+	p.f.Close()
+}
+
+func (p *progressFile) save(key string, v float64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.done[key] = v
+	_, err := fmt.Fprintf(p.f, "%s %s\n", key, strconv.FormatFloat(v, 'g', -1, 64))
+	if err != nil {
+		return err
+	}
+	return p.f.Sync()
+}
+
+func (p *progressFile) get(key string) (float64, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v, ok := p.done[key]
+	return v, ok
+}
+
+// run works out every item that isn't already done, spread across the workers,
+// and returns all of the expected values in order
+func (p *progressFile) run(name string, items []item, workers int) ([]float64, error) {
+	todo := make(chan item)
+	errs := make(chan error, len(items))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range todo {
+				errs <- p.save(itemKey(name, it.class), it.eval())
+			}
+		}()
+	}
+
+	numTodo := 0
+	for _, it := range items {
+		if _, ok := p.get(itemKey(name, it.class)); ok {
+			continue
+		}
+		numTodo++
+		todo <- it
+	}
+	close(todo)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("%s: worked out %d of %d expected values\n", name, numTodo, len(items))
+
+	vals := make([]float64, len(items))
+	for i, it := range items {
+		vals[i], _ = p.get(itemKey(name, it.class))
+	}
+	return vals, nil
+}
+
+func itemKey(name string, class int) string {
+	return name + `/` + strconv.Itoa(class)
+}
+
+// tables works out the expected values for the dealer and the pone, given what the
+// other players are likely to discard
+func (p *progressFile) tables(
+	dealerPairChoices, ponePairChoices, dealerSingleChoices, poneSingleChoices []float64,
+) (strategy.DiscardTables, error) {
+
+	var dt strategy.DiscardTables
+
+	// the dealer's opponent is the pone, and the other way around
+	vals, err := p.run(`pairs/dealer`, pairItems(ponePairChoices), *workers)
+	if err != nil {
+		return dt, err
+	}
+	copy(dt.DealerPairs[:], vals)
+
+	vals, err = p.run(`pairs/pone`, pairItems(dealerPairChoices), *workers)
+	if err != nil {
+		return dt, err
+	}
+	copy(dt.PonePairs[:], vals)
+
+	// with three players, the dealer has two pone opponents, and each pone has
+	// the dealer and another pone as opponents
+	vals, err = p.run(`singles/dealer`, singleItems(poneSingleChoices, poneSingleChoices), *workers)
+	if err != nil {
+		return dt, err
+	}
+	copy(dt.DealerSingles[:], vals)
+
+	vals, err = p.run(`singles/pone`, singleItems(dealerSingleChoices, poneSingleChoices), *workers)
+	if err != nil {
+		return dt, err
+	}
+	copy(dt.PoneSingles[:], vals)
+
+	return dt, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"github.com/joshprzybyszewski/cribbage/logic/scorer"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// maxPoints is the most that any hand can score
+const maxPoints = 29
+
+// distribution is how many hands score each number of points, out of every hand
+// of four cards with every cut
+type distribution struct {
+	isCrib bool
+	counts [maxPoints + 1]int64
+}
+
+func (d distribution) total() int64 {
+	var total int64
+	for _, n := range d.counts {
+		total += n
+	}
+	return total
+}
+
+func (d distribution) mean() float64 {
+	var pts int64
+	for p, n := range d.counts {
+		pts += int64(p) * n
+	}
+	return float64(pts) / float64(d.total())
+}
+
+func (d distribution) kind() string {
+	if d.isCrib {
+		return `crib`
+	}
+	return `hand`
+}
+
+// computeDistribution scores every hand, spread across the workers. Each worker
+// takes the hands whose lowest numbered card is the next one up.
+func computeDistribution(isCrib bool, workers int) distribution {
+	lowest := make(chan int)
+	results := make(chan distribution)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			d := distribution{
+				isCrib: isCrib,
+			}
+			for n := range lowest {
+				d.countHands(n)
+			}
+			results <- d
+		}()
+	}
+
+	for n := 0; n < model.NumCardsPerDeck; n++ {
+		lowest <- n
+	}
+	close(lowest)
+
+	d := distribution{
+		isCrib: isCrib,
+	}
+	for i := 0; i < workers; i++ {
+		wd := <-results
+		for p, n := range wd.counts {
+			d.counts[p] += n
+		}
+	}
+	return d
+}
+
+// countHands scores the hands whose lowest numbered card is the nth one, with each
+// of the cuts
+func (d *distribution) countHands(n int) {
+	first := model.NewCardFromNumber(n)
+	higher := model.FullDeck &^ (model.CardSet(1)<<uint(n+1) - 1)
+
+	higher.Combinations(3, func(rest model.CardSet) {
+		hand := rest.Add(first)
+		for cuts := model.FullDeck.Without(hand); cuts != model.EmptyCardSet; {
+			var cut model.Card
+			cut, cuts = cuts.Next()
+			if d.isCrib {
+				d.counts[scorer.CribPointsForSet(cut, hand)]++
+			} else {
+				d.counts[scorer.HandPointsForSet(cut, hand)]++
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDistribution(t *testing.T) {
+	if testing.Short() {
+		t.Skip(`scoring every hand takes a few seconds`)
+	}
+
+	for _, isCrib := range []bool{false, true} {
+		d := computeDistribution(isCrib, 2)
+		require.NoError(t, verify(d), `crib: %v`, isCrib)
+	}
+
+	d := computeDistribution(false, 3)
+	assert.Equal(t, publishedHandCounts, d.counts)
+	assert.InDelta(t, 4.769, d.mean(), 0.001)
+}
+
+func TestVerify(t *testing.T) {
+	d := distribution{
+		counts: publishedHandCounts,
+	}
+	assert.NoError(t, verify(d))
+
+	d.counts[12]--
+	d.counts[13]++
+	assert.EqualError(t, verify(d), `317339 hands scored 12 points, expected 317340`)
+
+	// a crib doesn't check the hands that could have been flushes
+	d.isCrib = true
+	assert.NoError(t, verify(d))
+
+	d.counts[29]--
+	d.counts[28]++
+	assert.EqualError(t, verify(d), `3 cribs scored 29 points, expected 4`)
+
+	d.counts[0]++
+	assert.EqualError(t, verify(d), `scored 12994801 hands, expected 12994800`)
+}
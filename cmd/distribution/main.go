@@ -0,0 +1,43 @@
+// distribution scores every hand of four cards with every cut, and prints how many
+// hands score each number of points. It checks what it scored against the well
+// known totals, so it doubles as a check on the scorer.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"runtime"
+)
+
+var (
+	crib        = flag.Bool(`crib`, false, `Score the hands as cribs, which can't have a four card flush`)
+	format      = flag.String(`format`, `text`, `How to print the distribution: text, csv, or json`)
+	workers     = flag.Int(`workers`, runtime.NumCPU(), `How many goroutines to score the hands with`)
+	shouldCheck = flag.Bool(`verify`, true, `Check the distribution against the published totals`)
+)
+
+func main() {
+	flag.Parse()
+
+	if !isFormat(*format) {
+		log.Fatalf("unknown format %q", *format)
+	}
+	if *workers < 1 {
+		log.Fatal(`there needs to be at least one worker`)
+	}
+
+	d := computeDistribution(*crib, *workers)
+
+	err := write(os.Stdout, *format, d)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *shouldCheck {
+		err = verify(d)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
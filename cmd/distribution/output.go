@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+var errUnknownFormat = errors.New(`unknown format`)
+
+type bucket struct {
+	Points int   `json:"points"`
+	Hands  int64 `json:"hands"`
+}
+
+type summary struct {
+	Kind         string   `json:"kind"`
+	Hands        int64    `json:"hands"`
+	Mean         float64  `json:"mean"`
+	TwentyNines  int64    `json:"twentyNines"`
+	TwentyEights int64    `json:"twentyEights"`
+	TwentyFours  int64    `json:"twentyFours"`
+	Histogram    []bucket `json:"histogram"`
+}
+
+func newSummary(d distribution) summary {
+	s := summary{
+		Kind:         d.kind(),
+		Hands:        d.total(),
+		Mean:         d.mean(),
+		TwentyNines:  d.counts[29],
+		TwentyEights: d.counts[28],
+		TwentyFours:  d.counts[24],
+		Histogram:    make([]bucket, 0, len(d.counts)),
+	}
+	for p, n := range d.counts {
+		s.Histogram = append(s.Histogram, bucket{
+			Points: p,
+			Hands:  n,
+		})
+	}
+	return s
+}
+
+func isFormat(format string) bool {
+	switch format {
+	case `text`, `csv`, `json`:
+		return true
+	}
+	return false
+}
+
+func write(w io.Writer, format string, d distribution) error {
+	s := newSummary(d)
+	switch format {
+	case `text`:
+		return writeText(w, s)
+	case `csv`:
+		return writeCSV(w, s)
+	case `json`:
+		e := json.NewEncoder(w)
+		e.SetIndent(``, `  `)
+		return e.Encode(s)
+	}
+	return errUnknownFormat
+}
+
+func writeText(w io.Writer, s summary) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "points\t%s hands\tpercent\t\n", s.Kind)
+	for _, b := range s.Histogram {
+		fmt.Fprintf(tw, "%d\t%d\t%.4f%%\t\n", b.Points, b.Hands, 100*float64(b.Hands)/float64(s.Hands))
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\nhands: %d\nmean: %.4f\n29 points: %d\n28 points: %d\n24 points: %d\n",
+		s.Hands, s.Mean, s.TwentyNines, s.TwentyEights, s.TwentyFours)
+	return err
+}
+
+// writeCSV writes the histogram, followed by the rows for the total and the mean.
+// The 29, 28, and 24 point hands are in the histogram.
+func writeCSV(w io.Writer, s summary) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{`points`, `hands`})
+	if err != nil {
+		return err
+	}
+	for _, b := range s.Histogram {
+		err = cw.Write([]string{strconv.Itoa(b.Points), strconv.FormatInt(b.Hands, 10)})
+		if err != nil {
+			return err
+		}
+	}
+	err = cw.WriteAll([][]string{
+		{`total`, strconv.FormatInt(s.Hands, 10)},
+		{`mean`, strconv.FormatFloat(s.Mean, 'f', -1, 64)},
+	})
+	if err != nil {
+		return err
+	}
+	return cw.Error()
+}
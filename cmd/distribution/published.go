@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+)
+
+// publishedHandCounts is the well known number of hands that score each number of
+// points, out of every hand of four cards with every cut
+var publishedHandCounts = [maxPoints + 1]int64{
+	1009008, 99792, 2813796, 505008, 2855676,
+	697508, 1800268, 751324, 1137236, 361224,
+	388740, 51680, 317340, 19656, 90100,
+	9168, 58248, 11196, 2708, 0,
+	8068, 2496, 444, 356, 3680,
+	0, 0, 0, 76, 4,
+}
+
+// numHands is how many ways there are to pick four cards and then the cut:
+// (52 choose 4) * 48
+const numHands = 270725 * 48
+
+// verify checks the distribution against the published totals. A crib can't score
+// a four card flush, so only the total and the hands that can't be flushes are
+// checked for it.
+func verify(d distribution) error {
+	if t := d.total(); t != numHands {
+		return fmt.Errorf(`scored %d hands, expected %d`, t, numHands)
+	}
+
+	checked := []int{29, 28, 24}
+	if !d.isCrib {
+		checked = checked[:0]
+		for p := range publishedHandCounts {
+			checked = append(checked, p)
+		}
+	}
+
+	for _, p := range checked {
+		if d.counts[p] != publishedHandCounts[p] {
+			return fmt.Errorf(`%d %ss scored %d points, expected %d`,
+				d.counts[p], d.kind(), p, publishedHandCounts[p])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// UnmarshalGameEvent takes json-marshaled bytes and returns the model.GameEvent
+// The advantage is that we can unmarshal the Event which is typed based on the Type
+func UnmarshalGameEvent(b []byte) (model.GameEvent, error) {
+	var raw json.RawMessage
+	ge := model.GameEvent{
+		Event: &raw,
+	}
+	err := json.Unmarshal(b, &ge)
+	if err != nil {
+		return model.GameEvent{}, err
+	}
+
+	err = unmarshalEventIntoGameEvent(&ge, raw)
+	if err != nil {
+		return model.GameEvent{}, err
+	}
+
+	return ge, nil
+}
+
+func unmarshalEventIntoGameEvent(
+	ge *model.GameEvent,
+	raw json.RawMessage,
+) error {
+
+	typedEvents := map[model.GameEventType]func() interface{}{
+		model.Scored:        func() interface{} { return &model.ScoreEvent{} },
+		model.CardDealt:     func() interface{} { return &model.CardDealtEvent{} },
+		model.CutRevealed:   func() interface{} { return &model.CutRevealedEvent{} },
+		model.CardPegged:    func() interface{} { return &model.CardPeggedEvent{} },
+		model.GoSaid:        func() interface{} { return &model.GoSaidEvent{} },
+		model.PhaseChanged:  func() interface{} { return &model.PhaseChangedEvent{} },
+		model.MugginsCalled: func() interface{} { return &model.MugginsCalledEvent{} },
+	}
+
+	subEventFn, ok := typedEvents[ge.Type]
+	if !ok {
+		return errors.New(`unknown event type`)
+	}
+	subEvent := subEventFn()
+
+	if err := json.Unmarshal(raw, subEvent); err != nil {
+		return err
+	}
+
+	switch t := subEvent.(type) {
+	case *model.ScoreEvent:
+		ge.Event = *t
+	case *model.CardDealtEvent:
+		ge.Event = *t
+	case *model.CutRevealedEvent:
+		ge.Event = *t
+	case *model.CardPeggedEvent:
+		ge.Event = *t
+	case *model.GoSaidEvent:
+		ge.Event = *t
+	case *model.PhaseChangedEvent:
+		ge.Event = *t
+	case *model.MugginsCalledEvent:
+		ge.Event = *t
+	}
+
+	return nil
+}
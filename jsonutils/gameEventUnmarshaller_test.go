@@ -0,0 +1,81 @@
+package jsonutils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestUnmarshalGameEvent(t *testing.T) {
+	cut := model.NewCardFromString(`5h`)
+
+	testCases := []struct {
+		msg string
+		e   interface{}
+	}{{
+		msg: `scored`,
+		e: model.ScoreEvent{
+			PlayerID: model.PlayerID(`alice`),
+			Reason:   model.HandScore,
+			Points:   29,
+			Score:    42,
+			Cards: []model.Card{
+				model.NewCardFromString(`jh`),
+				model.NewCardFromString(`5d`),
+				model.NewCardFromString(`5c`),
+				model.NewCardFromString(`5s`),
+			},
+			CutCard: &cut,
+		},
+	}, {
+		msg: `card dealt`,
+		e: model.CardDealtEvent{
+			PlayerID: model.PlayerID(`bob`),
+			Cards: []model.Card{
+				model.NewCardFromString(`ah`),
+			},
+		},
+	}, {
+		msg: `cut revealed`,
+		e: model.CutRevealedEvent{
+			Card: cut,
+		},
+	}, {
+		msg: `card pegged`,
+		e: model.CardPeggedEvent{
+			PlayerID: model.PlayerID(`charlie`),
+			Card:     model.NewCardFromString(`kc`),
+			Peg:      25,
+		},
+	}, {
+		msg: `go said`,
+		e: model.GoSaidEvent{
+			PlayerID: model.PlayerID(`diane`),
+		},
+	}, {
+		msg: `phase changed`,
+		e: model.PhaseChangedEvent{
+			Phase: model.Pegging,
+		},
+	}, {
+		msg: `muggins called`,
+		e: model.MugginsCalledEvent{
+			PlayerID: model.PlayerID(`edward`),
+			Points:   4,
+			Correct:  true,
+		},
+	}}
+
+	for _, tc := range testCases {
+		ge := model.NewGameEvent(3, tc.e)
+		b, err := json.Marshal(ge)
+		require.NoError(t, err, tc.msg)
+		actGE, err := UnmarshalGameEvent(b)
+		require.NoError(t, err, tc.msg)
+		assert.Equal(t, ge, actGE, tc.msg)
+	}
+}
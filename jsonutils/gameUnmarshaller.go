@@ -8,7 +8,7 @@ import (
 
 // UnmarshalGame takes in json marshaled bytes of a model.Game
 // The main advantage is that the list of actions can be deserialized
-// into the interface{} type.
+// (and events) into the interface{} type.
 func UnmarshalGame(b []byte) (model.Game, error) {
 	game := model.Game{}
 
@@ -31,5 +31,18 @@ func UnmarshalGame(b []byte) (model.Game, error) {
 		game.Actions[i] = pa
 	}
 
+	for i := range game.Events {
+		b, err := json.Marshal(game.Events[i])
+		if err != nil {
+			return model.Game{}, err
+		}
+
+		ge, err := UnmarshalGameEvent(b)
+		if err != nil {
+			return model.Game{}, err
+		}
+		game.Events[i] = ge
+	}
+
 	return game, nil
 }
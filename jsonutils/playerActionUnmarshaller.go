@@ -35,12 +35,16 @@ func unmarshalActionIntoPlayerAction(
 ) error {
 
 	blockerActions := map[model.Blocker]func() interface{}{
-		model.DealCards: func() interface{} { return &model.DealAction{} },
-		model.CribCard:  func() interface{} { return &model.BuildCribAction{} },
-		model.CutCard:   func() interface{} { return &model.CutDeckAction{} },
-		model.PegCard:   func() interface{} { return &model.PegAction{} },
-		model.CountHand: func() interface{} { return &model.CountHandAction{} },
-		model.CountCrib: func() interface{} { return &model.CountCribAction{} },
+		model.DealCards:   func() interface{} { return &model.DealAction{} },
+		model.CribCard:    func() interface{} { return &model.BuildCribAction{} },
+		model.CutCard:     func() interface{} { return &model.CutDeckAction{} },
+		model.PegCard:     func() interface{} { return &model.PegAction{} },
+		model.CountHand:   func() interface{} { return &model.CountHandAction{} },
+		model.CountCrib:   func() interface{} { return &model.CountCribAction{} },
+		model.CallMuggins: func() interface{} { return &model.CallMugginsAction{} },
+		model.Undo:        func() interface{} { return &model.UndoAction{} },
+		model.Forfeit:     func() interface{} { return &model.ForfeitAction{} },
+		model.Resign:      func() interface{} { return &model.ResignAction{} },
 	}
 
 	subActionFn, ok := blockerActions[action.Overcomes]
@@ -66,6 +70,14 @@ func unmarshalActionIntoPlayerAction(
 		action.Action = *t
 	case *model.CountCribAction:
 		action.Action = *t
+	case *model.CallMugginsAction:
+		action.Action = *t
+	case *model.UndoAction:
+		action.Action = *t
+	case *model.ForfeitAction:
+		action.Action = *t
+	case *model.ResignAction:
+		action.Action = *t
 	}
 
 	return nil
@@ -87,6 +87,44 @@ func TestUnmarshalPlayerAction(t *testing.T) {
 				Pts: 12,
 			},
 		},
+	}, {
+		msg: `call muggins`,
+		pa: model.PlayerAction{
+			GameID:    model.GameID(7),
+			ID:        model.PlayerID(`harriet`),
+			Overcomes: model.CallMuggins,
+			Action: model.CallMugginsAction{
+				Pts: 4,
+			},
+		},
+	}, {
+		msg: `undo`,
+		pa: model.PlayerAction{
+			GameID:    model.GameID(7),
+			ID:        model.PlayerID(`harriet`),
+			Overcomes: model.Undo,
+			Action: model.UndoAction{
+				Approve: true,
+			},
+		},
+	}, {
+		msg: `forfeit`,
+		pa: model.PlayerAction{
+			GameID:    model.GameID(8),
+			ID:        model.PlayerID(`ignatius`),
+			Overcomes: model.Forfeit,
+			Action: model.ForfeitAction{
+				TimedOut: true,
+			},
+		},
+	}, {
+		msg: `resign`,
+		pa: model.PlayerAction{
+			GameID:    model.GameID(9),
+			ID:        model.PlayerID(`alice`),
+			Overcomes: model.Resign,
+			Action:    model.ResignAction{},
+		},
 	}}
 
 	for _, tc := range testCases {
@@ -98,7 +98,7 @@ func (tc *terminalClient) getDealAction() model.DealAction {
 
 func (tc *terminalClient) getBuildCribAction(g model.Game) model.BuildCribAction {
 	hand := g.Hands[tc.me.ID]
-	desired := len(hand) - 4
+	desired := len(hand) - g.Options.Rules.KeptHandSize()
 	cardChoices := make([]string, 0, len(hand))
 	for _, c := range hand {
 		cardChoices = append(cardChoices, c.String())
@@ -34,6 +34,8 @@ var (
 
 type terminalClient struct {
 	server *http.Client
+	// token is the session the server gave us when we signed in
+	token string
 
 	reqChan chan terminalRequest
 
@@ -66,7 +68,10 @@ func StartTerminalInteraction() error {
 		reqChan: make(chan terminalRequest, 5),
 	}
 	if tc.shouldSignIn() {
-		tc.me.ID = tc.getPlayerID(`What is your username?`)
+		err := tc.signIn()
+		if err != nil {
+			return err
+		}
 	} else {
 		err := tc.createPlayer()
 		if err != nil {
@@ -138,8 +143,7 @@ func (tc *terminalClient) startClientServer(wg *sync.WaitGroup, port int) {
 		router.Use(gin.LoggerWithWriter(playerServerFile), gin.Recovery())
 
 		router.POST("/blocking/:gameID", handleBlocking(tc.reqChan))
-		router.POST("/message/:gameID", handleMessage(tc.reqChan))
-		router.POST("/score/:gameID", handleScoreUpdate(tc.reqChan))
+		router.POST("/event/:gameID", handleEvent(tc.reqChan))
 
 		err = router.Run(fmt.Sprintf("0.0.0.0:%d", port)) // listen and serve on the addr
 		fmt.Printf("router.Run error: %+v\n", err)
@@ -215,35 +219,29 @@ func handleBlocking(reqChan chan terminalRequest) func(*gin.Context) {
 	}
 }
 
-func handleMessage(reqChan chan terminalRequest) func(*gin.Context) {
+func handleEvent(reqChan chan terminalRequest) func(*gin.Context) {
 	return func(c *gin.Context) {
-		gID, msg, err := getGameIDAndBody(c, `Received a message`)
+		gID, body, err := getGameIDAndBody(c, ``)
 		if err != nil {
 			c.String(http.StatusBadRequest, err.Error())
 			return
 		}
 
-		reqChan <- terminalRequest{
-			gameID: gID,
-			msg:    msg,
-			req:    message,
-		}
-		c.String(http.StatusOK, `received`)
-	}
-}
-
-func handleScoreUpdate(reqChan chan terminalRequest) func(*gin.Context) {
-	return func(c *gin.Context) {
-		gID, msg, err := getGameIDAndBody(c, `There was a score update`)
+		var e network.GameEvent
+		err = json.Unmarshal([]byte(body), &e)
 		if err != nil {
 			c.String(http.StatusBadRequest, err.Error())
 			return
 		}
 
+		req := message
+		if e.Type == model.Scored.String() {
+			req = scoreUpdate
+		}
 		reqChan <- terminalRequest{
 			gameID: gID,
-			msg:    msg,
-			req:    scoreUpdate,
+			msg:    e.Description,
+			req:    req,
 		}
 		c.String(http.StatusOK, `received`)
 	}
@@ -288,6 +286,9 @@ func (tc *terminalClient) makeRequest(method, apiURL string, data io.Reader, hea
 	if header != nil {
 		req.Header = header
 	}
+	if tc.token != `` {
+		req.Header.Set(`Authorization`, `Bearer `+tc.token)
+	}
 
 	response, err := tc.server.Do(req)
 	if err != nil {
@@ -316,25 +317,69 @@ func (tc *terminalClient) makeRequest(method, apiURL string, data io.Reader, hea
 
 func (tc *terminalClient) createPlayer() error {
 	username, name := tc.getName()
-	reqData := model.Player{
-		ID:   model.PlayerID(username),
-		Name: name,
+	reqData := network.CreatePlayerRequest{
+		Player: network.Player{
+			ID:   model.PlayerID(username),
+			Name: name,
+		},
+		Password: tc.getPassword(),
 	}
 	respBytes, err := tc.makeJSONBodiedRequest(`POST`, `/create/player`, reqData)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(respBytes, &tc.me)
+	var cpr network.CreatePlayerResponse
+	err = json.Unmarshal(respBytes, &cpr)
 	if err != nil {
 		return err
 	}
+	tc.me.ID = cpr.Player.ID
+	tc.me.Name = cpr.Player.Name
+	if cpr.Session != nil {
+		tc.token = cpr.Session.Token
+	}
 
 	fmt.Printf("Your player ID is: %v\n", tc.me.ID)
 
 	return nil
 }
 
+func (tc *terminalClient) signIn() error {
+	reqData := network.LoginRequest{
+		Username: tc.getPlayerID(`What is your username?`),
+		Password: tc.getPassword(),
+	}
+	respBytes, err := tc.makeJSONBodiedRequest(`POST`, `/auth/login`, reqData)
+	if err != nil {
+		return err
+	}
+
+	var lr network.LoginResponse
+	err = json.Unmarshal(respBytes, &lr)
+	if err != nil {
+		return err
+	}
+	tc.me.ID = lr.Player.ID
+	tc.me.Name = lr.Player.Name
+	tc.token = lr.Session.Token
+
+	return nil
+}
+
+func (tc *terminalClient) getPassword() string {
+	password := ``
+	prompt := &survey.Password{
+		Message: `What is your password?`,
+	}
+
+	err := survey.AskOne(prompt, &password, survey.WithValidator(survey.Required))
+	if err != nil {
+		fmt.Printf("survey.AskOne error: %+v\n", err)
+	}
+	return password
+}
+
 func (tc *terminalClient) shouldSignIn() bool {
 	should := true
 
@@ -14,10 +14,62 @@ var (
 
 // PointsForCard returns how many points are received for the given card, provided the previously pegged cards
 func PointsForCard(prevCards []model.PeggedCard, c model.Card) (int, error) {
-	if err := validatePrevCards(prevCards, c); err != nil {
+	items, err := BreakdownForCard(prevCards, c)
+	if err != nil {
 		return 0, err
 	}
 
+	return model.TotalPoints(items), nil
+}
+
+// BreakdownForCard returns each of the ways the given card scores, provided the previously
+// pegged cards. The points of the items add up to what PointsForCard returns.
+func BreakdownForCard(prevCards []model.PeggedCard, c model.Card) ([]model.ScoreItem, error) {
+	if err := validatePrevCards(prevCards, c); err != nil {
+		return nil, err
+	}
+
+	totalPegged, cardsToAnalyze := currentStack(prevCards, c)
+
+	var items []model.ScoreItem
+
+	switch totalPegged + c.PegValue() {
+	case 15:
+		items = append(items, model.ScoreItem{
+			Type:   model.FifteenItem,
+			Cards:  withCard(cardsToAnalyze, c),
+			Points: 2,
+		})
+	case 31:
+		items = append(items, model.ScoreItem{
+			Type:   model.ThirtyOneItem,
+			Cards:  withCard(cardsToAnalyze, c),
+			Points: 2,
+		})
+	}
+
+	if pts, pair := scorePairs(cardsToAnalyze, c); pts > 0 {
+		items = append(items, model.ScoreItem{
+			Type:   model.PairItem,
+			Cards:  pair,
+			Points: pts,
+		})
+	}
+
+	if pts, run := scoreRun(cardsToAnalyze, c); pts > 0 {
+		items = append(items, model.ScoreItem{
+			Type:   model.RunItem,
+			Cards:  run,
+			Points: pts,
+		})
+	}
+
+	return items, nil
+}
+
+// currentStack returns the total pegged so far and the cards that c could
+// score with: the ones pegged since the count last went back to zero
+func currentStack(prevCards []model.PeggedCard, c model.Card) (int, []model.Card) {
 	totalPegged := 0
 	indexOfCardsToUse := 0
 	for i, pc := range prevCards {
@@ -36,33 +88,31 @@ func PointsForCard(prevCards []model.PeggedCard, c model.Card) (int, error) {
 		cardsToAnalyze = cardsToAnalyze[:0]
 	}
 
-	points := 0
-	runPoints := scoreRun(cardsToAnalyze, c)
-	points += runPoints
-
-	pairPoints := scorePairs(cardsToAnalyze, c)
-	points += pairPoints
-
-	switch totalPegged + c.PegValue() {
-	case 15, 31:
-		points += 2
-	}
+	return totalPegged, cardsToAnalyze
+}
 
-	return points, nil
+// withCard returns a new slice of the cards with c on the end
+func withCard(cards []model.Card, c model.Card) []model.Card {
+	res := make([]model.Card, len(cards), len(cards)+1)
+	copy(res, cards)
+	return append(res, c)
 }
 
-func scoreRun(cardsToAnalyze []model.Card, c model.Card) int {
-	runLen := 0
+// scoreRun returns the points for the longest run that c completes, along
+// with the cards in that run in the order they were pegged
+func scoreRun(cardsToAnalyze []model.Card, c model.Card) (int, []model.Card) {
+	var run []model.Card
 	for i := len(cardsToAnalyze) - 2; i >= 0; i-- {
-		if !isRun(append(cardsToAnalyze[i:], c)) {
+		candidate := withCard(cardsToAnalyze[i:], c)
+		if !isRun(candidate) {
 			continue
 		}
-		runLen = (len(cardsToAnalyze) - i) + 1
+		run = candidate
 	}
-	if runLen >= 3 {
-		return runLen
+	if len(run) >= 3 {
+		return len(run), run
 	}
-	return 0
+	return 0, nil
 }
 
 func isRun(c []model.Card) bool {
@@ -81,8 +131,11 @@ func isRun(c []model.Card) bool {
 	return true
 }
 
-func scorePairs(prevCards []model.Card, c model.Card) int {
+// scorePairs returns the points for the pair (or pair royal, or double pair
+// royal) that c completes, along with the cards that make it
+func scorePairs(prevCards []model.Card, c model.Card) (int, []model.Card) {
 	points := 0
+	start := len(prevCards)
 	for i := len(prevCards) - 1; i >= 0; i-- {
 		if prevCards[i].Value != c.Value {
 			break
@@ -90,8 +143,12 @@ func scorePairs(prevCards []model.Card, c model.Card) int {
 		// this will add the correct number of points for pairs
 		// the first time 2, then 4, then 6
 		points += 2 * (len(prevCards) - i)
+		start = i
+	}
+	if points == 0 {
+		return 0, nil
 	}
-	return points
+	return points, withCard(prevCards[start:], c)
 }
 
 func validatePrevCards(prevCards []model.PeggedCard, c model.Card) error {
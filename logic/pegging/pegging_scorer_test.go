@@ -187,3 +187,90 @@ func TestPointsErrorCase(t *testing.T) {
 		assert.Equal(t, tc.expVal, actVal, `unexpected value for test "%s"`, tc.msg)
 	}
 }
+
+func TestBreakdownForCard(t *testing.T) {
+	cs := func(strs ...string) []model.Card {
+		cards := make([]model.Card, len(strs))
+		for i, s := range strs {
+			cards[i] = model.NewCardFromString(s)
+		}
+		return cards
+	}
+
+	testCases := []struct {
+		msg        string
+		inputCards []string
+		inputCard  string
+		expItems   []model.ScoreItem
+		expDesc    string
+	}{{
+		msg:        `no points`,
+		inputCards: []string{`10C`},
+		inputCard:  `4C`,
+		expDesc:    `nineteen`,
+	}, {
+		msg:        `fifteen and a pair royal`,
+		inputCards: []string{`5C`, `5D`},
+		inputCard:  `5H`,
+		expItems: []model.ScoreItem{{
+			Type:   model.FifteenItem,
+			Cards:  cs(`5C`, `5D`, `5H`),
+			Points: 2,
+		}, {
+			Type:   model.PairItem,
+			Cards:  cs(`5C`, `5D`, `5H`),
+			Points: 6,
+		}},
+		expDesc: `fifteen 2, and a pair royal is 8`,
+	}, {
+		msg:        `double pair royal`,
+		inputCards: []string{`KC`, `2C`, `2D`, `2S`},
+		inputCard:  `2H`,
+		expItems: []model.ScoreItem{{
+			Type:   model.PairItem,
+			Cards:  cs(`2C`, `2D`, `2S`, `2H`),
+			Points: 12,
+		}},
+		expDesc: `a double pair royal is 12`,
+	}, {
+		msg:        `thirty-one with a run of four`,
+		inputCards: []string{`KC`, `7D`, `3H`, `2S`, `5H`},
+		inputCard:  `4H`,
+		expItems: []model.ScoreItem{{
+			Type:   model.ThirtyOneItem,
+			Cards:  cs(`KC`, `7D`, `3H`, `2S`, `5H`, `4H`),
+			Points: 2,
+		}, {
+			Type:   model.RunItem,
+			Cards:  cs(`3H`, `2S`, `5H`, `4H`),
+			Points: 4,
+		}},
+		expDesc: `thirty-one 2, and a run of 4 is 6`,
+	}, {
+		msg:        `only scores since the last thirty-one`,
+		inputCards: []string{`KC`, `QD`, `AH`, `KH`, `KD`},
+		inputCard:  `KS`,
+		expItems: []model.ScoreItem{{
+			Type:   model.PairItem,
+			Cards:  cs(`KD`, `KS`),
+			Points: 2,
+		}},
+		expDesc: `a pair is 2`,
+	}}
+
+	for _, tc := range testCases {
+		c := make([]model.PeggedCard, len(tc.inputCards))
+		for i, ic := range tc.inputCards {
+			c[i] = model.NewPeggedCard(model.InvalidPlayerID, model.NewCardFromString(ic), 0)
+		}
+		next := model.NewCardFromString(tc.inputCard)
+		items, err := BreakdownForCard(c, next)
+		assert.NoError(t, err, tc.msg)
+		assert.Equal(t, tc.expItems, items, tc.msg)
+		assert.Equal(t, tc.expDesc, model.DescribeScoreItems(items), tc.msg)
+
+		pts, err := PointsForCard(c, next)
+		assert.NoError(t, err, tc.msg)
+		assert.Equal(t, pts, model.TotalPoints(items), tc.msg)
+	}
+}
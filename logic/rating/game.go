@@ -0,0 +1,84 @@
+package rating
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+var (
+	ErrGameNotRated  = errors.New(`game was not finished by anyone winning it`)
+	ErrMissingRating = errors.New(`missing the rating of a player in the game`)
+)
+
+// ForGame returns the new ratings of everyone in the finished game, given what
+// they were going into it. Each player is rated against every opponent in the
+// game. The game is the only one in its rating period, and any full periods that
+// a player sat out before it make their rating less certain.
+func ForGame(
+	g model.Game,
+	ratings map[model.PlayerID]model.Rating,
+	now time.Time,
+	period time.Duration,
+) (map[model.PlayerID]model.Rating, error) {
+
+	if !g.IsOver() || g.Result == nil {
+		return nil, ErrGameNotRated
+	}
+
+	before := make(map[model.PlayerID]model.Rating, len(g.Players))
+	for _, p := range g.Players {
+		r, ok := ratings[p.ID]
+		if !ok {
+			return nil, ErrMissingRating
+		}
+		before[p.ID] = Decay(r, idlePeriods(r, now, period)-1)
+	}
+
+	after := make(map[model.PlayerID]model.Rating, len(g.Players))
+	for _, p := range g.Players {
+		r := Update(before[p.ID], results(g, p.ID, before))
+		r.Games++
+		r.Updated = now
+		after[p.ID] = r
+	}
+
+	return after, nil
+}
+
+func results(g model.Game, pID model.PlayerID, ratings map[model.PlayerID]model.Rating) []Result {
+	color := g.PlayerColors[pID]
+	res := make([]Result, 0, len(g.Players)-1)
+	for _, opp := range g.Players {
+		oppColor := g.PlayerColors[opp.ID]
+		if oppColor == color {
+			// teammates don't play against each other
+			continue
+		}
+		res = append(res, Result{
+			Opponent: ratings[opp.ID],
+			Score:    score(g, color, oppColor),
+		})
+	}
+	return res
+}
+
+func score(g model.Game, color, oppColor model.PlayerColor) float64 {
+	switch g.Result.Winner {
+	case color:
+		return 1
+	case oppColor:
+		return 0
+	}
+
+	// neither won, so whoever got further along did better
+	mine, theirs := g.CurrentScores[color], g.CurrentScores[oppColor]
+	switch {
+	case mine > theirs:
+		return 1
+	case mine < theirs:
+		return 0
+	}
+	return 0.5
+}
@@ -0,0 +1,139 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func threePlayerGame() model.Game {
+	return model.Game{
+		ID: model.NewGameID(),
+		Players: []model.Player{
+			{ID: `alice`},
+			{ID: `bob`},
+			{ID: `charlie`},
+		},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`alice`:   model.Blue,
+			`bob`:     model.Red,
+			`charlie`: model.Green,
+		},
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue:  121,
+			model.Red:   100,
+			model.Green: 80,
+		},
+		Status: model.StatusCompleted,
+		Result: &model.GameResult{
+			Winner: model.Blue,
+		},
+	}
+}
+
+func newRatings(g model.Game) map[model.PlayerID]model.Rating {
+	pool, _ := model.NewRatingPool(g)
+	ratings := make(map[model.PlayerID]model.Rating, len(g.Players))
+	for _, p := range g.Players {
+		ratings[p.ID] = New(p.ID, pool)
+	}
+	return ratings
+}
+
+func TestForGame(t *testing.T) {
+	g := threePlayerGame()
+	now := time.Now()
+
+	after, err := ForGame(g, newRatings(g), now, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, after, 3)
+
+	for _, r := range after {
+		assert.Equal(t, 1, r.Games)
+		assert.Equal(t, now, r.Updated)
+		assert.Equal(t, model.RatingPool{NumPlayers: 3, Variant: model.StandardVariant}, r.Pool)
+		assert.Less(t, r.Deviation, DefaultDeviation)
+	}
+
+	// the winner beat both, and the one that came second beat the last
+	assert.Greater(t, after[`alice`].Rating, after[`bob`].Rating)
+	assert.Greater(t, after[`bob`].Rating, after[`charlie`].Rating)
+	assert.InDelta(t, DefaultRating, after[`bob`].Rating, 0.001)
+}
+
+func TestForGameTeams(t *testing.T) {
+	g := model.Game{
+		Players: []model.Player{
+			{ID: `alice`},
+			{ID: `bob`},
+			{ID: `charlie`},
+			{ID: `diane`},
+		},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`alice`:   model.Blue,
+			`bob`:     model.Red,
+			`charlie`: model.Blue,
+			`diane`:   model.Red,
+		},
+		Status: model.StatusResigned,
+		Result: &model.GameResult{
+			Winner: model.Red,
+		},
+	}
+
+	after, err := ForGame(g, newRatings(g), time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, after[`bob`].Rating, after[`diane`].Rating)
+	assert.Equal(t, after[`alice`].Rating, after[`charlie`].Rating)
+	assert.Greater(t, after[`bob`].Rating, DefaultRating)
+	assert.Less(t, after[`alice`].Rating, DefaultRating)
+}
+
+func TestForGameSporadicPlayers(t *testing.T) {
+	g := threePlayerGame()
+	now := time.Now()
+
+	regular := newRatings(g)
+	sporadic := newRatings(g)
+	for pID, r := range regular {
+		r.Deviation = 60
+		r.Updated = now.Add(-time.Hour)
+		regular[pID] = r
+
+		r.Updated = now.Add(-100 * time.Hour)
+		sporadic[pID] = r
+	}
+
+	afterRegular, err := ForGame(g, regular, now, time.Hour)
+	require.NoError(t, err)
+	afterSporadic, err := ForGame(g, sporadic, now, time.Hour)
+	require.NoError(t, err)
+
+	// the less certain rating of the player that hasn't been around moves further
+	assert.Greater(t, afterSporadic[`alice`].Deviation, afterRegular[`alice`].Deviation)
+	assert.Greater(t, afterSporadic[`alice`].Rating, afterRegular[`alice`].Rating)
+	assert.Less(t, afterSporadic[`charlie`].Rating, afterRegular[`charlie`].Rating)
+}
+
+func TestForGameNotRated(t *testing.T) {
+	g := threePlayerGame()
+
+	_, err := ForGame(g, map[model.PlayerID]model.Rating{}, time.Now(), time.Hour)
+	assert.Equal(t, ErrMissingRating, err)
+
+	g.Result = nil
+	g.Status = model.StatusAbandoned
+	_, err = ForGame(g, newRatings(g), time.Now(), time.Hour)
+	assert.Equal(t, ErrGameNotRated, err)
+
+	g = threePlayerGame()
+	g.Status = model.StatusActive
+	g.CurrentScores[model.Blue] = 120
+	_, err = ForGame(g, newRatings(g), time.Now(), time.Hour)
+	assert.Equal(t, ErrGameNotRated, err)
+}
@@ -0,0 +1,143 @@
+package rating
+
+import (
+	"math"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// These are the values recommended by Glickman's "Example of the Glicko-2 system"
+const (
+	// DefaultRating, DefaultDeviation, and DefaultVolatility are where every
+	// player starts out
+	DefaultRating     float64 = 1500
+	DefaultDeviation  float64 = 350
+	DefaultVolatility float64 = 0.06
+
+	// tau limits how much the volatility can change in one period
+	tau float64 = 0.5
+	// glickoScale converts between the Glicko scale and the Glicko-2 scale
+	glickoScale float64 = 173.7178
+	// convergence is how precisely the volatility is found
+	convergence float64 = 0.000001
+)
+
+// Result is the outcome of one game against one opponent
+type Result struct {
+	// The opponent's rating going into the game
+	Opponent model.Rating
+	// The score against the opponent: 1 for a win, 0.5 for a draw, and 0 for a loss
+	Score float64
+}
+
+// New returns the rating for a player who has never played in the pool
+func New(pID model.PlayerID, pool model.RatingPool) model.Rating {
+	return model.Rating{
+		PlayerID:   pID,
+		Pool:       pool,
+		Rating:     DefaultRating,
+		Deviation:  DefaultDeviation,
+		Volatility: DefaultVolatility,
+	}
+}
+
+// Decay returns the rating after the player sat out for the number of rating
+// periods. Their rating stays the same, but it becomes less certain.
+func Decay(r model.Rating, periods float64) model.Rating {
+	if periods <= 0 {
+		return r
+	}
+	phi := r.Deviation / glickoScale
+	phi = math.Sqrt(phi*phi + periods*r.Volatility*r.Volatility)
+	r.Deviation = math.Min(phi*glickoScale, DefaultDeviation)
+	return r
+}
+
+// Update returns the rating after a rating period with the results
+func Update(r model.Rating, results []Result) model.Rating {
+	if len(results) == 0 {
+		return Decay(r, 1)
+	}
+
+	mu, phi := toGlicko2(r)
+	var vInv, improvement float64
+	for _, res := range results {
+		muJ, phiJ := toGlicko2(res.Opponent)
+		g := reduceImpact(phiJ)
+		e := expectedScore(mu, muJ, g)
+		vInv += g * g * e * (1 - e)
+		improvement += g * (res.Score - e)
+	}
+	v := 1 / vInv
+	delta := v * improvement
+
+	sigma := newVolatility(phi, r.Volatility, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*improvement
+
+	r.Rating = newMu*glickoScale + DefaultRating
+	r.Deviation = math.Min(newPhi*glickoScale, DefaultDeviation)
+	r.Volatility = sigma
+	return r
+}
+
+func toGlicko2(r model.Rating) (float64, float64) {
+	return (r.Rating - DefaultRating) / glickoScale, r.Deviation / glickoScale
+}
+
+// reduceImpact is Glickman's g function. It lessens how much a game against an
+// opponent with an uncertain rating counts.
+func reduceImpact(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expectedScore(mu, muJ, g float64) float64 {
+	return 1 / (1 + math.Exp(-g*(mu-muJ)))
+}
+
+// newVolatility finds the new volatility using the Illinois algorithm, as step 5
+// of Glickman's paper describes
+func newVolatility(phi, sigma, v, delta float64) float64 {
+	alpha := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-alpha)/(tau*tau)
+	}
+
+	lo := alpha
+	var hi float64
+	if delta*delta > phi*phi+v {
+		hi = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(alpha-k*tau) < 0 {
+			k++
+		}
+		hi = alpha - k*tau
+	}
+
+	fLo, fHi := f(lo), f(hi)
+	for math.Abs(hi-lo) > convergence {
+		mid := lo + (lo-hi)*fLo/(fHi-fLo)
+		fMid := f(mid)
+		if fMid*fHi <= 0 {
+			lo, fLo = hi, fHi
+		} else {
+			fLo /= 2
+		}
+		hi, fHi = mid, fMid
+	}
+
+	return math.Exp(lo / 2)
+}
+
+// idlePeriods is how many rating periods have passed since the rating last changed
+func idlePeriods(r model.Rating, now time.Time, period time.Duration) float64 {
+	if r.Updated.IsZero() || period <= 0 || !now.After(r.Updated) {
+		return 0
+	}
+	return float64(now.Sub(r.Updated)) / float64(period)
+}
@@ -0,0 +1,74 @@
+package rating
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestUpdate(t *testing.T) {
+	// the worked example from Glickman's "Example of the Glicko-2 system"
+	r := model.Rating{
+		Rating:     1500,
+		Deviation:  200,
+		Volatility: 0.06,
+	}
+	act := Update(r, []Result{{
+		Opponent: model.Rating{Rating: 1400, Deviation: 30},
+		Score:    1,
+	}, {
+		Opponent: model.Rating{Rating: 1550, Deviation: 100},
+		Score:    0,
+	}, {
+		Opponent: model.Rating{Rating: 1700, Deviation: 300},
+		Score:    0,
+	}})
+
+	assert.InDelta(t, 1464.06, act.Rating, 0.01)
+	assert.InDelta(t, 151.52, act.Deviation, 0.01)
+	assert.InDelta(t, 0.05999, act.Volatility, 0.00001)
+}
+
+func TestUpdateWithoutResults(t *testing.T) {
+	r := model.Rating{
+		Rating:     1500,
+		Deviation:  200,
+		Volatility: 0.06,
+	}
+	act := Update(r, nil)
+	assert.Equal(t, 1500.0, act.Rating)
+	assert.InDelta(t, 200.27, act.Deviation, 0.01)
+	assert.Equal(t, 0.06, act.Volatility)
+}
+
+func TestDecay(t *testing.T) {
+	r := model.Rating{
+		Rating:     1700,
+		Deviation:  50,
+		Volatility: 0.06,
+	}
+
+	assert.Equal(t, r, Decay(r, 0))
+	assert.Equal(t, r, Decay(r, -1))
+
+	decayed := Decay(r, 10)
+	assert.Equal(t, 1700.0, decayed.Rating)
+	assert.Greater(t, decayed.Deviation, r.Deviation)
+	assert.Greater(t, Decay(r, 20).Deviation, decayed.Deviation)
+
+	// ratings are never less certain than a new player's
+	assert.Equal(t, DefaultDeviation, Decay(r, 1000000).Deviation)
+}
+
+func TestIdlePeriods(t *testing.T) {
+	now := time.Now()
+	week := 7 * 24 * time.Hour
+
+	assert.Zero(t, idlePeriods(model.Rating{}, now, week))
+	assert.Zero(t, idlePeriods(model.Rating{Updated: now}, now, week))
+	assert.Zero(t, idlePeriods(model.Rating{Updated: now.Add(-week)}, now, 0))
+	assert.Equal(t, 2.5, idlePeriods(model.Rating{Updated: now.Add(-5 * week / 2)}, now, week))
+}
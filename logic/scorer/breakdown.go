@@ -0,0 +1,153 @@
+package scorer
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// Breakdown itemizes every combination that scores in the hand (or crib)
+// with the lead card: each fifteen, pair, run, flush, and nobs along with
+// the cards that made it. The points of the items add up to what HandPoints
+// or CribPoints would return.
+func Breakdown(lead model.Card, hand []model.Card, isCrib bool) []model.ScoreItem {
+	if !CanScore(len(hand), isCrib) {
+		return nil
+	}
+
+	// the lead card goes last so that it's described after the hand
+	all := make([]model.Card, 0, len(hand)+1)
+	all = append(all, hand...)
+	all = append(all, lead)
+
+	var items []model.ScoreItem
+	items = append(items, breakdownFifteens(all)...)
+	items = append(items, breakdownPairs(all)...)
+	items = append(items, breakdownRuns(all)...)
+	items = append(items, breakdownFlushesAndNobs(lead, hand, isCrib)...)
+
+	return items
+}
+
+// CanScore returns true if a hand (or crib) with numCards cards can be scored
+func CanScore(numCards int, isCrib bool) bool {
+	return numCards == 4 || (!isCrib && isVariantHandSize(numCards))
+}
+
+func breakdownFifteens(cards []model.Card) []model.ScoreItem {
+	var items []model.ScoreItem
+	// every subset of the cards is a bit mask, where each bit is one card
+	for mask := 1; mask < 1<<uint(len(cards)); mask++ {
+		sum := 0
+		var used []model.Card
+		for i, c := range cards {
+			if mask&(1<<uint(i)) != 0 {
+				sum += c.PegValue()
+				used = append(used, c)
+			}
+		}
+		if sum == 15 {
+			items = append(items, model.ScoreItem{
+				Type:   model.FifteenItem,
+				Cards:  used,
+				Points: 2,
+			})
+		}
+	}
+	return items
+}
+
+func breakdownPairs(cards []model.Card) []model.ScoreItem {
+	var items []model.ScoreItem
+	for i, c := range cards {
+		for _, o := range cards[i+1:] {
+			if c.Value == o.Value {
+				items = append(items, model.ScoreItem{
+					Type:   model.PairItem,
+					Cards:  []model.Card{c, o},
+					Points: 2,
+				})
+			}
+		}
+	}
+	return items
+}
+
+func breakdownRuns(cards []model.Card) []model.ScoreItem {
+	// byValue has a trailing empty slot so that every run has an end
+	var byValue [maxCardValue + 2][]model.Card
+	for _, c := range cards {
+		byValue[c.Value] = append(byValue[c.Value], c)
+	}
+
+	var items []model.ScoreItem
+	start := 1
+	for v := 1; v < len(byValue); v++ {
+		if len(byValue[v]) > 0 {
+			continue
+		}
+		if v-start >= 3 {
+			for _, run := range everyRun(byValue[start:v]) {
+				items = append(items, model.ScoreItem{
+					Type:   model.RunItem,
+					Cards:  run,
+					Points: len(run),
+				})
+			}
+		}
+		start = v + 1
+	}
+	return items
+}
+
+// everyRun returns each way to pick one card of every value
+func everyRun(values [][]model.Card) [][]model.Card {
+	runs := [][]model.Card{nil}
+	for _, cs := range values {
+		next := make([][]model.Card, 0, len(runs)*len(cs))
+		for _, r := range runs {
+			for _, c := range cs {
+				run := make([]model.Card, len(r), len(r)+1)
+				copy(run, r)
+				next = append(next, append(run, c))
+			}
+		}
+		runs = next
+	}
+	return runs
+}
+
+func breakdownFlushesAndNobs(lead model.Card, hand []model.Card, isCrib bool) []model.ScoreItem {
+	var items []model.ScoreItem
+
+	isHandFlush := true
+	for _, c := range hand {
+		if c.Suit != hand[0].Suit {
+			isHandFlush = false
+		}
+	}
+	if isHandFlush {
+		flush := make([]model.Card, len(hand), len(hand)+1)
+		copy(flush, hand)
+		if lead.Suit == hand[0].Suit {
+			flush = append(flush, lead)
+		}
+		if !isCrib || len(flush) > len(hand) {
+			items = append(items, model.ScoreItem{
+				Type:   model.FlushItem,
+				Cards:  flush,
+				Points: len(flush),
+			})
+		}
+	}
+
+	for _, c := range hand {
+		if c.Value == model.JackValue && c.Suit == lead.Suit {
+			items = append(items, model.ScoreItem{
+				Type:   model.NobsItem,
+				Cards:  []model.Card{c},
+				Points: 1,
+			})
+		}
+	}
+
+	return items
+}
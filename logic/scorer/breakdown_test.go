@@ -0,0 +1,133 @@
+package scorer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestBreakdown(t *testing.T) {
+	cs := func(strs ...string) []model.Card {
+		cards := make([]model.Card, len(strs))
+		for i, s := range strs {
+			cards[i] = model.NewCardFromString(s)
+		}
+		return cards
+	}
+
+	testCases := []struct {
+		desc     string
+		lead     string
+		hand     []model.Card
+		isCrib   bool
+		expItems []model.ScoreItem
+		expDesc  string
+	}{{
+		desc: `two fifteens and two pairs`,
+		lead: `KH`,
+		hand: cs(`5S`, `5C`, `2D`, `2H`),
+		expItems: []model.ScoreItem{{
+			Type:   model.FifteenItem,
+			Cards:  cs(`5S`, `KH`),
+			Points: 2,
+		}, {
+			Type:   model.FifteenItem,
+			Cards:  cs(`5C`, `KH`),
+			Points: 2,
+		}, {
+			Type:   model.PairItem,
+			Cards:  cs(`5S`, `5C`),
+			Points: 2,
+		}, {
+			Type:   model.PairItem,
+			Cards:  cs(`2D`, `2H`),
+			Points: 2,
+		}},
+		expDesc: `fifteen 2, fifteen 4, a pair 6, and a pair is 8`,
+	}, {
+		desc: `double run of three`,
+		lead: `2H`,
+		hand: cs(`8S`, `8C`, `9D`, `10H`),
+		expItems: []model.ScoreItem{{
+			Type:   model.PairItem,
+			Cards:  cs(`8S`, `8C`),
+			Points: 2,
+		}, {
+			Type:   model.RunItem,
+			Cards:  cs(`8S`, `9D`, `10H`),
+			Points: 3,
+		}, {
+			Type:   model.RunItem,
+			Cards:  cs(`8C`, `9D`, `10H`),
+			Points: 3,
+		}},
+		expDesc: `a pair 2, a run of 3 5, and a run of 3 is 8`,
+	}, {
+		desc: `flush and nobs`,
+		lead: `2S`,
+		hand: cs(`4H`, `6H`, `8H`, `JS`),
+		expItems: []model.ScoreItem{{
+			Type:   model.NobsItem,
+			Cards:  cs(`JS`),
+			Points: 1,
+		}},
+		expDesc: `nobs is 1`,
+	}, {
+		desc: `four card flush in the hand`,
+		lead: `2S`,
+		hand: cs(`4H`, `6H`, `8H`, `QH`),
+		expItems: []model.ScoreItem{{
+			Type:   model.FlushItem,
+			Cards:  cs(`4H`, `6H`, `8H`, `QH`),
+			Points: 4,
+		}},
+		expDesc: `a flush of 4 is 4`,
+	}, {
+		desc:    `four card flush doesn't count in the crib`,
+		lead:    `2S`,
+		hand:    cs(`4H`, `6H`, `8H`, `QH`),
+		isCrib:  true,
+		expDesc: `nineteen`,
+	}, {
+		desc:   `five card flush counts in the crib`,
+		lead:   `2H`,
+		hand:   cs(`4H`, `6H`, `8H`, `QH`),
+		isCrib: true,
+		expItems: []model.ScoreItem{{
+			Type:   model.FlushItem,
+			Cards:  cs(`4H`, `6H`, `8H`, `QH`, `2H`),
+			Points: 5,
+		}},
+		expDesc: `a flush of 5 is 5`,
+	}}
+
+	for _, tc := range testCases {
+		items := Breakdown(model.NewCardFromString(tc.lead), tc.hand, tc.isCrib)
+		assert.Equal(t, tc.expItems, items, tc.desc)
+		assert.Equal(t, tc.expDesc, model.DescribeScoreItems(items), tc.desc)
+	}
+}
+
+func TestBreakdownPoorlySizedHands(t *testing.T) {
+	assert.Empty(t, Breakdown(model.Card{}, make([]model.Card, 2), false))
+	assert.Empty(t, Breakdown(model.Card{}, make([]model.Card, 5), true))
+	assert.Empty(t, Breakdown(model.Card{}, make([]model.Card, 6), false))
+}
+
+func TestBreakdownMatchesPoints(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		d := model.NewDeck()
+		d.Shuffle()
+		lead := d.Deal()
+		hand := []model.Card{d.Deal(), d.Deal(), d.Deal(), d.Deal()}
+		if i%2 == 0 {
+			// also check the variant hand sizes
+			hand = append(hand, d.Deal())
+		}
+
+		assert.Equal(t, HandPoints(lead, hand), model.TotalPoints(Breakdown(lead, hand, false)), `%v %v`, lead, hand)
+		assert.Equal(t, CribPoints(lead, hand), model.TotalPoints(Breakdown(lead, hand, true)), `%v %v`, lead, hand)
+	}
+}
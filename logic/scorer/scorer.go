@@ -17,6 +17,9 @@ func CribPoints(lead model.Card, crib []model.Card) int {
 
 func points(lead model.Card, hand []model.Card, isCrib bool) int {
 	if len(hand) != 4 {
+		if !isCrib && isVariantHandSize(len(hand)) {
+			return variantPoints(lead, hand, isCrib)
+		}
 		if LOG {
 			fmt.Printf("Expected hand size 4, got %d\n", len(hand))
 		}
@@ -210,6 +210,74 @@ func TestScoringPoorlySizedHands(t *testing.T) {
 	// Asserting zero also checks that the func doesn't panic
 	assert.Zero(t, CribPoints(model.Card{}, make([]model.Card, 5)))
 	assert.Zero(t, CribPoints(model.Card{}, make([]model.Card, 6)))
-	assert.Zero(t, HandPoints(model.Card{}, make([]model.Card, 5)))
+	assert.Zero(t, HandPoints(model.Card{}, make([]model.Card, 2)))
 	assert.Zero(t, HandPoints(model.Card{}, make([]model.Card, 6)))
 }
+
+func TestPointsForVariantHandSizes(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		leadCard  string
+		hand      string
+		expPoints int
+	}{{
+		desc:      `five-card hand with a triplet and nobs`,
+		leadCard:  `5H`,
+		hand:      `5S,5C,JH`,
+		expPoints: 15,
+	}, {
+		desc:      `five-card hand flush`,
+		leadCard:  `KH`,
+		hand:      `2S,4S,6S`,
+		expPoints: 3,
+	}, {
+		desc:      `five-card hand flush with the lead`,
+		leadCard:  `KS`,
+		hand:      `2S,4S,6S`,
+		expPoints: 4,
+	}, {
+		desc:      `five-card hand run of four`,
+		leadCard:  `KH`,
+		hand:      `JS,QC,10D`,
+		expPoints: 4,
+	}, {
+		desc:      `seven-card hand double run of five and a flush`,
+		leadCard:  `7D`,
+		hand:      `6S,7S,8S,9S,10S`,
+		expPoints: 23,
+	}, {
+		desc:      `seven-card hand run of six`,
+		leadCard:  `AH`,
+		hand:      `2S,3C,4D,5H,6S`,
+		expPoints: 14,
+	}, {
+		desc:      `seven-card hand with nothing`,
+		leadCard:  `KH`,
+		hand:      `2S,4C,6D,8H,QS`,
+		expPoints: 0,
+	}}
+
+	for _, tc := range testCases {
+		lead := model.NewCardFromString(tc.leadCard)
+		cardStrs := strings.Split(tc.hand, `,`)
+		hand := make([]model.Card, len(cardStrs))
+		for i, c := range cardStrs {
+			hand[i] = model.NewCardFromString(c)
+		}
+
+		actPoints := HandPoints(lead, hand)
+		assert.Equal(t, tc.expPoints, actPoints, tc.desc)
+	}
+}
+
+func TestVariantPointsMatchesPoints(t *testing.T) {
+	for i := 0; i < 2000; i++ {
+		d := model.NewDeck()
+		d.Shuffle()
+		lead := d.Deal()
+		hand := []model.Card{d.Deal(), d.Deal(), d.Deal(), d.Deal()}
+
+		assert.Equal(t, HandPoints(lead, hand), variantPoints(lead, hand, false), `%v %v`, lead, hand)
+		assert.Equal(t, CribPoints(lead, hand), variantPoints(lead, hand, true), `%v %v`, lead, hand)
+	}
+}
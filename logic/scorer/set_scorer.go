@@ -0,0 +1,113 @@
+package scorer
+
+import (
+	"math/bits"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// HandPointsForSet is HandPoints for a hand packed into a card set. A hand of four
+// cards is scored without allocating, which makes it the one to use when scoring
+// every hand that could come up.
+func HandPointsForSet(lead model.Card, hand model.CardSet) int {
+	return setPoints(lead, hand, false)
+}
+
+// CribPointsForSet is CribPoints for a crib packed into a card set
+func CribPointsForSet(lead model.Card, crib model.CardSet) int {
+	return setPoints(lead, crib, true)
+}
+
+// CribPointsForEachCut adds up what the crib scores for each of the cuts, with the
+// rest of the five cards in the crib. The fifteens, pairs, and runs don't depend on
+// which card was cut, so they're only counted once.
+func CribPointsForEachCut(cards, cuts model.CardSet) int {
+	if cards.Len() != 5 {
+		total := 0
+		for s := cuts; s != model.EmptyCardSet; {
+			var cut model.Card
+			cut, s = s.Next()
+			total += CribPointsForSet(cut, cards.Remove(cut))
+		}
+		return total
+	}
+
+	shared := fiveCardPoints(cards)
+	total := 0
+	for s := cuts; s != model.EmptyCardSet; {
+		var cut model.Card
+		cut, s = s.Next()
+		total += shared + setFlushesAndNobs(cut, cards.Remove(cut), true)
+	}
+	return total
+}
+
+func setPoints(lead model.Card, hand model.CardSet, isCrib bool) int {
+	if hand.Len() != 4 || hand.Contains(lead) {
+		return points(lead, hand.Cards(), isCrib)
+	}
+
+	return fiveCardPoints(hand.Add(lead)) + setFlushesAndNobs(lead, hand, isCrib)
+}
+
+// fiveCardPoints returns the points for the fifteens, pairs, and runs in the cards
+func fiveCardPoints(cards model.CardSet) int {
+	var values, ptValues [5]int
+	i := 0
+	for s := cards; s != model.EmptyCardSet; i++ {
+		var c model.Card
+		c, s = s.Next()
+		values[i] = c.Value
+		ptValues[i] = c.PegValue()
+	}
+
+	sortFive(&values)
+	_, runsAndPairs := scoreRunsAndPairs(values[:])
+
+	return setFifteens(&ptValues) + runsAndPairs
+}
+
+// setFifteens adds up the cards in each of the 31 ways to pick some of them, where
+// each way is the sum of a smaller way plus one more card
+func setFifteens(ptValues *[5]int) int {
+	var sums [1 << 5]int
+	num := 0
+	for picked := 1; picked < len(sums); picked++ {
+		last := picked & -picked
+		sums[picked] = sums[picked^last] + ptValues[bits.TrailingZeros(uint(last))]
+		if sums[picked] == 15 {
+			num++
+		}
+	}
+	return 2 * num
+}
+
+// sortFive is an insertion sort, which is quicker than sort.Ints for so few
+// values, and doesn't allocate
+func sortFive(vals *[5]int) {
+	for i := 1; i < len(vals); i++ {
+		for j := i; j > 0 && vals[j] < vals[j-1]; j-- {
+			vals[j], vals[j-1] = vals[j-1], vals[j]
+		}
+	}
+}
+
+func setFlushesAndNobs(lead model.Card, hand model.CardSet, isCrib bool) int {
+	pts := 0
+	if hand.Contains(model.NewCard(lead.Suit, model.JackValue)) {
+		pts++
+	}
+
+	for s := model.Spades; s <= model.Hearts; s++ {
+		if hand.Suit(s) != hand {
+			continue
+		}
+		if lead.Suit == s {
+			pts += 5
+		} else if !isCrib {
+			pts += 4
+		}
+	}
+
+	return pts
+}
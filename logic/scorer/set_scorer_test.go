@@ -0,0 +1,101 @@
+package scorer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func randomHand(r *rand.Rand, n int) (model.Card, []model.Card) {
+	perm := r.Perm(model.NumCardsPerDeck)
+	hand := make([]model.Card, n)
+	for i := range hand {
+		hand[i] = model.NewCardFromNumber(perm[i])
+	}
+	return model.NewCardFromNumber(perm[n]), hand
+}
+
+func TestPointsForSetMatchesPoints(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		lead, hand := randomHand(r, 4)
+		set := model.NewCardSet(hand...)
+		assert.Equal(t, HandPoints(lead, hand), HandPointsForSet(lead, set), `%v %v`, lead, hand)
+		assert.Equal(t, CribPoints(lead, hand), CribPointsForSet(lead, set), `%v %v`, lead, hand)
+	}
+
+	for _, n := range []int{3, 5} {
+		lead, hand := randomHand(r, n)
+		assert.Equal(t, HandPoints(lead, hand), HandPointsForSet(lead, model.NewCardSet(hand...)))
+	}
+}
+
+func TestPointsForSet(t *testing.T) {
+	hand := model.NewCardSet(
+		model.NewCardFromString(`5s`),
+		model.NewCardFromString(`5c`),
+		model.NewCardFromString(`5d`),
+		model.NewCardFromString(`jh`),
+	)
+	assert.Equal(t, 29, HandPointsForSet(model.NewCardFromString(`5h`), hand))
+	assert.Equal(t, 29, CribPointsForSet(model.NewCardFromString(`5h`), hand))
+
+	flush := model.NewCardSet(
+		model.NewCardFromString(`2h`),
+		model.NewCardFromString(`4h`),
+		model.NewCardFromString(`6h`),
+		model.NewCardFromString(`8h`),
+	)
+	assert.Equal(t, 4, HandPointsForSet(model.NewCardFromString(`qs`), flush))
+	assert.Equal(t, 0, CribPointsForSet(model.NewCardFromString(`qs`), flush))
+	assert.Equal(t, 5, CribPointsForSet(model.NewCardFromString(`qh`), flush))
+}
+
+func TestCribPointsForEachCut(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		lead, hand := randomHand(r, 4)
+		cards := model.NewCardSet(hand...).Add(lead)
+
+		exp := 0
+		for _, c := range cards.Cards() {
+			exp += CribPoints(c, cards.Remove(c).Cards())
+		}
+		assert.Equal(t, exp, CribPointsForEachCut(cards, cards))
+		assert.Equal(t, CribPoints(lead, hand), CribPointsForEachCut(cards, model.NewCardSet(lead)))
+	}
+
+	// sets that aren't five cards are scored one cut at a time
+	_, hand := randomHand(r, 4)
+	cards := model.NewCardSet(hand...)
+	assert.Zero(t, CribPointsForEachCut(cards, cards))
+}
+
+func BenchmarkCribPoints(b *testing.B) {
+	lead := model.NewCardFromString(`5h`)
+	crib := []model.Card{
+		model.NewCardFromString(`4s`),
+		model.NewCardFromString(`5c`),
+		model.NewCardFromString(`6d`),
+		model.NewCardFromString(`jh`),
+	}
+	for i := 0; i < b.N; i++ {
+		_ = CribPoints(lead, crib)
+	}
+}
+
+func BenchmarkCribPointsForSet(b *testing.B) {
+	lead := model.NewCardFromString(`5h`)
+	crib := model.NewCardSet(
+		model.NewCardFromString(`4s`),
+		model.NewCardFromString(`5c`),
+		model.NewCardFromString(`6d`),
+		model.NewCardFromString(`jh`),
+	)
+	for i := 0; i < b.N; i++ {
+		_ = CribPointsForSet(lead, crib)
+	}
+}
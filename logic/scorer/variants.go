@@ -0,0 +1,94 @@
+package scorer
+
+import (
+	"sort"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+const (
+	// five-card cribbage keeps three cards, and seven-card cribbage keeps five
+	minVariantHandSize = 3
+	maxVariantHandSize = 5
+
+	// Ace is 1, King is 13
+	maxCardValue = 13
+)
+
+func isVariantHandSize(n int) bool {
+	return n >= minVariantHandSize && n <= maxVariantHandSize
+}
+
+// variantPoints scores a hand of any size. It's not as quick as points, so
+// it's only used for the hand sizes from the five- and seven-card variants.
+func variantPoints(lead model.Card, hand []model.Card, isCrib bool) int {
+	ptValues := make([]int, 0, len(hand)+1)
+	// numPerValue has a trailing zero so that every run has an end
+	var numPerValue [maxCardValue + 2]int
+	for _, c := range append([]model.Card{lead}, hand...) {
+		ptValues = append(ptValues, c.PegValue())
+		numPerValue[c.Value]++
+	}
+	sort.Ints(ptValues)
+
+	return variantFifteens(ptValues) +
+		variantPairsAndRuns(numPerValue[:]) +
+		variantFlushesAndNobs(lead, hand, isCrib)
+}
+
+// Assumes input is sorted
+func variantFifteens(ptValues []int) int {
+	pts := 0
+	for i := 0; i < len(ptValues) && ptValues[i] < 8; i++ {
+		pts += 2 * int(howManyAddUpTo(15-ptValues[i], ptValues[i+1:]))
+	}
+	return pts
+}
+
+func variantPairsAndRuns(numPerValue []int) int {
+	pts := 0
+
+	// every pair of same-valued cards is worth two
+	for _, n := range numPerValue {
+		pts += n * (n - 1)
+	}
+
+	// a run is worth its length for every way it can be made
+	runLen, runMult := 0, 1
+	for _, n := range numPerValue[1:] {
+		if n > 0 {
+			runLen++
+			runMult *= n
+			continue
+		}
+		if runLen >= 3 {
+			pts += runLen * runMult
+		}
+		runLen, runMult = 0, 1
+	}
+
+	return pts
+}
+
+func variantFlushesAndNobs(lead model.Card, hand []model.Card, isCrib bool) int {
+	pts := 0
+	isHandFlush := true
+	for _, c := range hand {
+		if c.Suit != hand[0].Suit {
+			isHandFlush = false
+		}
+		if c.Value == model.JackValue && c.Suit == lead.Suit {
+			pts++
+		}
+	}
+
+	if isHandFlush {
+		if lead.Suit == hand[0].Suit {
+			pts += len(hand) + 1
+		} else if !isCrib {
+			pts += len(hand)
+		}
+	}
+
+	return pts
+}
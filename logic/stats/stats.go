@@ -0,0 +1,217 @@
+package stats
+
+import (
+	"errors"
+
+	"github.com/joshprzybyszewski/cribbage/logic/scorer"
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+const perfectHand = 29
+
+var (
+	ErrPlayerNotInGame = errors.New(`player is not in the game`)
+)
+
+// deal is what one player was dealt, and what they did with it
+type deal struct {
+	// the number of actions the game had when the cards were dealt
+	start int
+
+	dealt    []model.Card
+	discards []model.Card
+	cut      *model.Card
+
+	counted   bool
+	handPts   int
+	cribbed   bool
+	cribPts   int
+	pegPoints int
+}
+
+// ForGame tallies how the player did in the finished game
+func ForGame(g model.Game, pID model.PlayerID) (model.PlayerStats, error) {
+	if !g.IsOver() {
+		return model.PlayerStats{}, model.ErrGameNotOver
+	}
+	color, ok := g.PlayerColors[pID]
+	if !ok {
+		return model.PlayerStats{}, ErrPlayerNotInGame
+	}
+
+	ps := model.PlayerStats{
+		PlayerID: pID,
+	}
+	if g.Result == nil {
+		// nobody won an abandoned game, and nobody played it
+		return ps, nil
+	}
+
+	if color == g.Result.Winner {
+		ps.Wins = 1
+		if g.Result.Skunk != model.NotSkunked {
+			ps.SkunksGiven = 1
+		}
+	} else {
+		ps.Losses = 1
+		if g.Result.Skunk != model.NotSkunked {
+			ps.SkunksTaken = 1
+		}
+	}
+
+	for _, d := range getDeals(g, pID) {
+		addDeal(&ps, g.ID, d)
+	}
+
+	return ps, nil
+}
+
+// ForGames tallies how the player did over all of the finished games
+func ForGames(games []model.Game, pID model.PlayerID) (model.PlayerStats, error) {
+	ps := model.PlayerStats{
+		PlayerID: pID,
+	}
+	for _, g := range games {
+		gs, err := ForGame(g, pID)
+		if err != nil {
+			return model.PlayerStats{}, err
+		}
+		ps.Add(gs)
+	}
+	return ps, nil
+}
+
+// getDeals pieces together each of the player's deals from the game's events, which
+// know the cards and the points scored, and its actions, which know what the player
+// did with them
+func getDeals(g model.Game, pID model.PlayerID) []deal {
+	var deals []deal
+	var cuts []model.Card
+	for _, ge := range g.Events {
+		switch e := ge.Event.(type) {
+		case model.CardDealtEvent:
+			if e.PlayerID == pID {
+				deals = append(deals, deal{
+					start: ge.Action,
+					dealt: e.Cards,
+				})
+			}
+		case model.CutRevealedEvent:
+			cuts = append(cuts, e.Card)
+		case model.ScoreEvent:
+			if e.PlayerID == pID && len(deals) > 0 {
+				deals[len(deals)-1].addPoints(e)
+			}
+		}
+	}
+
+	// every deal is cut before the next one is dealt
+	for i := range deals {
+		if i < len(cuts) {
+			deals[i].cut = &cuts[i]
+		}
+	}
+
+	for i, pa := range g.Actions {
+		if pa.ID != pID {
+			continue
+		}
+		d := dealAt(deals, i)
+		if d == nil {
+			continue
+		}
+		switch a := pa.Action.(type) {
+		case model.BuildCribAction:
+			d.discards = append(d.discards, a.Cards...)
+		case model.CountHandAction:
+			d.counted = true
+		case model.CountCribAction:
+			d.cribbed = true
+		}
+	}
+
+	return deals
+}
+
+// addPoints tallies the points the player scored in the deal. These come from the
+// score events, since what a player claims for a hand isn't always what they're given.
+func (d *deal) addPoints(se model.ScoreEvent) {
+	switch {
+	case isPegging(se.Reason):
+		d.pegPoints += se.Points
+	case se.Reason == model.HandScore:
+		d.handPts += se.Points
+	case se.Reason == model.CribScore:
+		d.cribPts += se.Points
+	}
+}
+
+// dealAt returns the deal that the action with the given index was taken in
+func dealAt(deals []deal, action int) *deal {
+	for i := len(deals) - 1; i >= 0; i-- {
+		if deals[i].start <= action {
+			return &deals[i]
+		}
+	}
+	return nil
+}
+
+func isPegging(r model.ScoreReason) bool {
+	switch r {
+	case model.PegScore, model.GoScore, model.LastCardScore:
+		return true
+	}
+	return false
+}
+
+func addDeal(ps *model.PlayerStats, gID model.GameID, d deal) {
+	ps.Deals++
+	ps.PegPoints += d.pegPoints
+	if d.cribbed {
+		ps.Cribs++
+		ps.CribPoints += d.cribPts
+	}
+	if !d.counted {
+		return
+	}
+	ps.Hands++
+	ps.HandPoints += d.handPts
+
+	kept := without(d.dealt, d.discards)
+	if d.cut != nil {
+		pts := scorer.HandPoints(*d.cut, kept)
+		if pts > ps.BestHand {
+			ps.BestHand = pts
+			ps.BestHandGame = gID
+		}
+		if pts == perfectHand {
+			ps.TwentyNines++
+		}
+	}
+
+	if len(d.discards) == 0 {
+		return
+	}
+	left, err := strategy.PointsLeftOnTable(d.dealt, kept)
+	if err != nil {
+		// some variants deal too many cards to judge the discards
+		return
+	}
+	ps.Discards++
+	ps.PointsLeft += left
+}
+
+func without(cards, removed []model.Card) []model.Card {
+	rem := make(map[model.Card]struct{}, len(removed))
+	for _, c := range removed {
+		rem[c] = struct{}{}
+	}
+	kept := make([]model.Card, 0, len(cards))
+	for _, c := range cards {
+		if _, ok := rem[c]; !ok {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
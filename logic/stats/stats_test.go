@@ -0,0 +1,176 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func cards(strs ...string) []model.Card {
+	cs := make([]model.Card, len(strs))
+	for i, s := range strs {
+		cs[i] = model.NewCardFromString(s)
+	}
+	return cs
+}
+
+func finishedGame() model.Game {
+	return finishedGameWithCrib(4, 4)
+}
+
+// finishedGameWithCrib is a finished game where bob claimed and scored the given
+// points for his crib
+func finishedGameWithCrib(claimed, scored int) model.Game {
+	g := model.Game{
+		ID: model.NewGameID(),
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`alice`: model.Blue,
+			`bob`:   model.Red,
+		},
+		Status: model.StatusCompleted,
+		Result: &model.GameResult{
+			Winner: model.Blue,
+			Skunk:  model.Skunked,
+		},
+	}
+
+	for _, a := range []model.PlayerAction{{
+		ID:     `bob`,
+		Action: model.DealAction{NumShuffles: 3},
+	}, {
+		ID:     `alice`,
+		Action: model.BuildCribAction{Cards: cards(`2c`, `3c`)},
+	}, {
+		ID:     `bob`,
+		Action: model.BuildCribAction{Cards: cards(`8c`, `9c`)},
+	}, {
+		ID:     `alice`,
+		Action: model.CutDeckAction{Percentage: 0.5},
+	}, {
+		ID:     `alice`,
+		Action: model.CountHandAction{Pts: 29},
+	}, {
+		ID:     `bob`,
+		Action: model.CountHandAction{Pts: 10},
+	}, {
+		ID:     `bob`,
+		Action: model.CountCribAction{Pts: claimed},
+	}, {
+		ID:     `alice`,
+		Action: model.DealAction{NumShuffles: 3},
+	}} {
+		g.AddAction(a)
+		switch len(g.Actions) {
+		case 1:
+			g.AddEvent(model.CardDealtEvent{PlayerID: `alice`, Cards: cards(`5c`, `5d`, `5s`, `jh`, `2c`, `3c`)})
+			g.AddEvent(model.CardDealtEvent{PlayerID: `bob`, Cards: cards(`4h`, `4s`, `6d`, `7c`, `8c`, `9c`)})
+		case 4:
+			g.AddEvent(model.CutRevealedEvent{Card: model.NewCardFromString(`5h`)})
+			g.AddEvent(model.ScoreEvent{PlayerID: `alice`, Reason: model.PegScore, Points: 2})
+			g.AddEvent(model.ScoreEvent{PlayerID: `bob`, Reason: model.GoScore, Points: 1})
+			g.AddEvent(model.ScoreEvent{PlayerID: `bob`, Reason: model.NibsScore, Points: 2})
+		case 5:
+			g.AddEvent(model.ScoreEvent{PlayerID: `alice`, Reason: model.HandScore, Points: 29})
+		case 6:
+			g.AddEvent(model.ScoreEvent{PlayerID: `bob`, Reason: model.HandScore, Points: 10})
+		case 7:
+			if scored > 0 {
+				g.AddEvent(model.ScoreEvent{PlayerID: `bob`, Reason: model.CribScore, Points: scored})
+			}
+		case 8:
+			// the game ended before this hand was played
+			g.AddEvent(model.CardDealtEvent{PlayerID: `alice`, Cards: cards(`ac`, `2d`, `3s`, `4h`, `6c`, `7c`)})
+		}
+	}
+
+	return g
+}
+
+func TestForGame(t *testing.T) {
+	g := finishedGame()
+
+	alice, err := ForGame(g, `alice`)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, alice.PointsLeft, 0.0001)
+	alice.PointsLeft = 0
+	assert.Equal(t, model.PlayerStats{
+		PlayerID:     `alice`,
+		Wins:         1,
+		SkunksGiven:  1,
+		Deals:        2,
+		PegPoints:    2,
+		Hands:        1,
+		HandPoints:   29,
+		BestHand:     29,
+		BestHandGame: g.ID,
+		TwentyNines:  1,
+		Discards:     1,
+	}, alice)
+
+	bob, err := ForGame(g, `bob`)
+	require.NoError(t, err)
+	assert.Greater(t, bob.PointsLeft, 0.0)
+	bob.PointsLeft = 0
+	assert.Equal(t, model.PlayerStats{
+		PlayerID:    `bob`,
+		Losses:      1,
+		SkunksTaken: 1,
+		Deals:       1,
+		PegPoints:   1,
+		Hands:       1,
+		HandPoints:  10,
+		Cribs:       1,
+		CribPoints:  4,
+		// bob didn't count all of his hand
+		BestHand:     16,
+		BestHandGame: g.ID,
+		Discards:     1,
+	}, bob)
+
+	_, err = ForGame(g, `charlie`)
+	assert.Equal(t, ErrPlayerNotInGame, err)
+
+	g.Status = model.StatusActive
+	_, err = ForGame(g, `alice`)
+	assert.Equal(t, model.ErrGameNotOver, err)
+}
+
+func TestForGameNineteen(t *testing.T) {
+	// 19 can't be scored, so it's how a player says their crib is worth nothing
+	g := finishedGameWithCrib(19, 0)
+
+	bob, err := ForGame(g, `bob`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bob.Cribs)
+	assert.Zero(t, bob.CribPoints)
+	assert.Equal(t, 10, bob.HandPoints)
+}
+
+func TestForGames(t *testing.T) {
+	g1, g2 := finishedGame(), finishedGame()
+	g2.Result.Winner = model.Red
+	g2.Result.Skunk = model.NotSkunked
+
+	ps, err := ForGames([]model.Game{g1, g2}, `alice`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ps.Games())
+	assert.Equal(t, 1, ps.Wins)
+	assert.Equal(t, 1, ps.Losses)
+	assert.Equal(t, 1, ps.SkunksGiven)
+	assert.Zero(t, ps.SkunksTaken)
+	assert.Equal(t, 4, ps.Deals)
+	assert.Equal(t, 2, ps.TwentyNines)
+	assert.Equal(t, g1.ID, ps.BestHandGame)
+	assert.Equal(t, 29.0, ps.AverageHandPoints())
+	assert.Equal(t, 1.0, ps.AveragePegPoints())
+
+	// nobody plays in an abandoned game
+	g2.Status = model.StatusAbandoned
+	g2.Result = nil
+	ps, err = ForGames([]model.Game{g2}, `alice`)
+	require.NoError(t, err)
+	assert.Equal(t, model.PlayerStats{PlayerID: `alice`}, ps)
+}
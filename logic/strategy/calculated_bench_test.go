@@ -0,0 +1,40 @@
+package strategy
+
+import (
+	"testing"
+)
+
+func BenchmarkGiveCribLowestPotential(b *testing.B) {
+	hand := strToCards([]string{`5s`, `5c`, `6d`, `9h`, `2h`, `1s`})
+	for i := 0; i < b.N; i++ {
+		_, _ = GiveCribLowestPotential(2, hand)
+	}
+}
+
+func BenchmarkGiveCribLowestPotentialThreePlayers(b *testing.B) {
+	hand := strToCards([]string{`5s`, `5c`, `6d`, `9h`, `2h`})
+	for i := 0; i < b.N; i++ {
+		_, _ = GiveCribLowestPotential(1, hand)
+	}
+}
+
+func BenchmarkKeepHandHighestPotential(b *testing.B) {
+	hand := strToCards([]string{`5s`, `5c`, `6d`, `9h`, `2h`, `1s`})
+	for i := 0; i < b.N; i++ {
+		_, _ = KeepHandHighestPotential(2, hand)
+	}
+}
+
+func BenchmarkRankDiscards(b *testing.B) {
+	hand := strToCards([]string{`5s`, `5c`, `6d`, `9h`, `2h`, `1s`})
+	for i := 0; i < b.N; i++ {
+		_, _ = RankDiscards(2, hand, true, DefaultPegWeight)
+	}
+}
+
+func BenchmarkRankDiscardsFromTable(b *testing.B) {
+	hand := strToCards([]string{`5s`, `5c`, `6d`, `9h`, `2h`, `1s`})
+	for i := 0; i < b.N; i++ {
+		_, _ = RankDiscardsFromTable(2, hand, 2, true, DefaultPegWeight)
+	}
+}
@@ -8,23 +8,23 @@ import (
 )
 
 // GiveCribHighestPotential gives the crib the highest potential pointed crib
-func GiveCribHighestPotential(_ int, hand []model.Card) ([]model.Card, error) {
+func GiveCribHighestPotential(desired int, hand []model.Card) ([]model.Card, error) {
 	isBetter := func(old, new float64) bool { return new > old }
-	return getBestPotentialCrib(hand, isBetter)
+	return getBestPotentialCrib(desired, hand, isBetter)
 }
 
 // GiveCribLowestPotential gives the crib the lowest potential pointed hand
-func GiveCribLowestPotential(_ int, hand []model.Card) ([]model.Card, error) {
+func GiveCribLowestPotential(desired int, hand []model.Card) ([]model.Card, error) {
 	isBetter := func(old, new float64) bool { return new < old }
-	return getBestPotentialCrib(hand, isBetter)
+	return getBestPotentialCrib(desired, hand, isBetter)
 }
 
-func getBestPotentialCrib(hand []model.Card, isBetter func(old, new float64) bool) ([]model.Card, error) {
-	if len(hand) > 6 || len(hand) <= 4 {
-		return nil, errors.New(`hand size must be between 4 and 6`)
+func getBestPotentialCrib(desired int, hand []model.Card, isBetter func(old, new float64) bool) ([]model.Card, error) {
+	if desired <= 0 || desired >= len(hand) || desired > 4 {
+		return nil, errors.New(`must keep at least one card and deposit between one and four cards`)
 	}
 
-	lenDeposit := len(hand) - 4
+	lenDeposit := desired
 	bestCrib := make([]model.Card, 0, lenDeposit)
 	bestPotential := 0.0
 
@@ -33,13 +33,9 @@ func getBestPotentialCrib(hand []model.Card, isBetter func(old, new float64) boo
 		return nil, err
 	}
 
-	seen := map[model.Card]struct{}{}
-	for _, c := range hand {
-		seen[c] = struct{}{}
-	}
-
+	seen := model.NewCardSet(hand...)
 	for i, dep := range allDeposits {
-		p := getPotentialForDeposit(seen, dep)
+		p := getPotentialForDeposit(seen, model.NewCardSet(dep...))
 		if i == 0 || isBetter(bestPotential, p) {
 			bestCrib = bestCrib[:0]
 			bestCrib = append(bestCrib, dep...)
@@ -50,35 +46,19 @@ func getBestPotentialCrib(hand []model.Card, isBetter func(old, new float64) boo
 	return bestCrib, nil
 }
 
-func getPotentialForDeposit(prevSeen map[model.Card]struct{}, cribDeposit []model.Card) float64 {
-	seen := map[model.Card]struct{}{}
-	for k := range prevSeen {
-		seen[k] = struct{}{}
-	}
-	for _, c := range cribDeposit {
-		seen[c] = struct{}{}
-	}
+// getPotentialForDeposit returns the average points the crib scores over every cut
+// and every set of cards the other players could give it
+func getPotentialForDeposit(seen, cribDeposit model.CardSet) float64 {
+	unseen := model.FullDeck.Without(seen | cribDeposit)
 
 	totalCribPoints := 0
 	totalHands := 0
 
-	for i := 0; i < 52; i++ {
-		lead := model.NewCardFromNumber(i)
-		if _, ok := seen[lead]; ok {
-			continue
-		}
-
-		seen[lead] = struct{}{}
-
-		options := otherOptions(4-len(cribDeposit), seen)
-
-		for _, o := range options {
-			totalCribPoints += scorer.CribPoints(lead, append(o, cribDeposit...))
-			totalHands++
-		}
-
-		delete(seen, lead)
-	}
+	unseen.Combinations(5-cribDeposit.Len(), func(drawn model.CardSet) {
+		// any one of the drawn cards could be the cut, with the rest given to the crib
+		totalCribPoints += scorer.CribPointsForEachCut(cribDeposit|drawn, drawn)
+		totalHands += drawn.Len()
+	})
 
 	return float64(totalCribPoints) / float64(totalHands)
 }
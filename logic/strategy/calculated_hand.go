@@ -8,37 +8,34 @@ import (
 )
 
 // KeepHandHighestPotential will keep the hand with the highest potential score
-func KeepHandHighestPotential(_ int, hand []model.Card) ([]model.Card, error) {
+func KeepHandHighestPotential(desired int, hand []model.Card) ([]model.Card, error) {
 	isBetter := func(old, new float64) bool { return new > old }
-	return getBestPotentialHand(hand, isBetter)
+	return getBestPotentialHand(desired, hand, isBetter)
 }
 
 // KeepHandLowestPotential will keep the hand with the lowest potential score
-func KeepHandLowestPotential(_ int, hand []model.Card) ([]model.Card, error) {
+func KeepHandLowestPotential(desired int, hand []model.Card) ([]model.Card, error) {
 	isBetter := func(old, new float64) bool { return new < old }
-	return getBestPotentialHand(hand, isBetter)
+	return getBestPotentialHand(desired, hand, isBetter)
 }
 
-func getBestPotentialHand(hand []model.Card, isBetter func(old, new float64) bool) ([]model.Card, error) {
-	if len(hand) > 6 || len(hand) <= 4 {
-		return nil, errors.New(`hand size must be between 4 and 6`)
+func getBestPotentialHand(desired int, hand []model.Card, isBetter func(old, new float64) bool) ([]model.Card, error) {
+	if desired <= 0 || desired >= len(hand) {
+		return nil, errors.New(`must keep at least one card and deposit at least one card`)
 	}
 
-	bestHand := make([]model.Card, 0, 4)
+	lenKept := len(hand) - desired
+	bestHand := make([]model.Card, 0, lenKept)
 	bestPotential := 0.0
 
-	allHands, err := chooseFrom(4, hand)
+	allHands, err := chooseFrom(lenKept, hand)
 	if err != nil {
 		return nil, err
 	}
 
-	seen := map[model.Card]struct{}{}
-	for _, c := range hand {
-		seen[c] = struct{}{}
-	}
-
+	seen := model.NewCardSet(hand...)
 	for i, h := range allHands {
-		p := getHandPotentialForCribDeposit(seen, h)
+		p := getHandPotentialForCribDeposit(seen, model.NewCardSet(h...))
 		if i == 0 || isBetter(bestPotential, p) {
 			bestHand = bestHand[:0]
 			bestHand = append(bestHand, h...)
@@ -49,31 +46,38 @@ func getBestPotentialHand(hand []model.Card, isBetter func(old, new float64) boo
 	return without(hand, bestHand), nil
 }
 
-func getHandPotentialForCribDeposit(prevSeen map[model.Card]struct{}, hand []model.Card) float64 {
-	seen := map[model.Card]struct{}{}
-	for k := range prevSeen {
-		seen[k] = struct{}{}
-	}
-	for _, c := range hand {
-		seen[c] = struct{}{}
-	}
-
+// getHandPotentialForCribDeposit returns the average points the hand scores over
+// every cut
+func getHandPotentialForCribDeposit(seen, hand model.CardSet) float64 {
 	totalHandPoints := 0
 	totalHands := 0
 
-	for i := 0; i < 52; i++ {
-		lead := model.NewCardFromNumber(i)
-		if _, ok := seen[lead]; ok {
-			continue
-		}
-
-		seen[lead] = struct{}{}
+	for leads := model.FullDeck.Without(seen | hand); leads != model.EmptyCardSet; {
+		var lead model.Card
+		lead, leads = leads.Next()
 
-		totalHandPoints += scorer.HandPoints(lead, hand)
+		totalHandPoints += scorer.HandPointsForSet(lead, hand)
 		totalHands++
-
-		delete(seen, lead)
 	}
 
 	return float64(totalHandPoints) / float64(totalHands)
 }
+
+// PointsLeftOnTable returns how many fewer points the kept hand is expected to score
+// than the best hand that could have been kept from the dealt cards
+func PointsLeftOnTable(dealt, kept []model.Card) (float64, error) {
+	allHands, err := chooseFrom(len(kept), dealt)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := model.NewCardSet(dealt...)
+	best := 0.0
+	for _, h := range allHands {
+		if p := getHandPotentialForCribDeposit(seen, model.NewCardSet(h...)); p > best {
+			best = p
+		}
+	}
+
+	return best - getHandPotentialForCribDeposit(seen, model.NewCardSet(kept...)), nil
+}
@@ -0,0 +1,25 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointsLeftOnTable(t *testing.T) {
+	dealt := strToCards([]string{`5s`, `5c`, `5d`, `jh`, `kc`, `2s`})
+
+	best, err := KeepHandHighestPotential(2, dealt)
+	require.NoError(t, err)
+	left, err := PointsLeftOnTable(dealt, without(dealt, best))
+	require.NoError(t, err)
+	assert.Zero(t, left)
+
+	left, err = PointsLeftOnTable(dealt, strToCards([]string{`5s`, `jh`, `kc`, `2s`}))
+	require.NoError(t, err)
+	assert.Greater(t, left, 5.0)
+
+	_, err = PointsLeftOnTable(strToCards([]string{`5s`, `5c`, `5d`, `jh`, `kc`, `2s`, `3s`}), dealt[:5])
+	assert.Error(t, err)
+}
@@ -6,38 +6,6 @@ import (
 	"github.com/joshprzybyszewski/cribbage/model"
 )
 
-func otherOptions(desired int, avoid map[model.Card]struct{}) [][]model.Card {
-	options := [][]model.Card{}
-
-	for o1 := 0; o1 < 52; o1++ {
-		oc1 := model.NewCardFromNumber(o1)
-		if _, ok := avoid[oc1]; ok {
-			continue
-		}
-		for o2 := o1 + 1; o2 < 52; o2++ {
-			oc2 := model.NewCardFromNumber(o2)
-			if _, ok := avoid[oc2]; ok {
-				continue
-			}
-
-			if desired == 2 {
-				options = append(options, []model.Card{oc1, oc2})
-				continue
-			}
-
-			for o3 := o2 + 1; o3 < 52; o3++ {
-				oc3 := model.NewCardFromNumber(o3)
-				if _, ok := avoid[oc3]; ok {
-					continue
-				}
-				options = append(options, []model.Card{oc1, oc2, oc3})
-			}
-		}
-	}
-
-	return options
-}
-
 func chooseFrom(k int, hand []model.Card) ([][]model.Card, error) {
 	if k < 1 || k > len(hand) {
 		return nil, errors.New(`developer error: invalid k`)
@@ -0,0 +1,174 @@
+package strategy
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+const (
+	// NumPairClasses is how many different two card discards there are to the crib.
+	// Only the ranks and whether the cards are suited matter: 13 pairs, and 78
+	// unpaired ranks that can either be suited or not.
+	NumPairClasses = 13 + 78*2
+	// NumSingleClasses is how many different one card discards there are to the crib
+	NumSingleClasses = 13
+
+	discardTablesVersion byte = 1
+	// the expected points are stored in thousandths of a point
+	discardTablesScale = 1000
+)
+
+var (
+	errBadDiscardTables = errors.New(`discard tables are malformed`)
+
+	pairClasses, pairClassCards = buildPairClasses()
+
+	loadDiscardTablesOnce sync.Once
+	loadedDiscardTables   DiscardTables
+	loadDiscardTablesErr  error
+)
+
+// DiscardTables are the points the crib is expected to score with each discard
+// in it, for the dealer and for the pone. The pairs are for two players who each
+// discard two cards, and the singles for three players who each discard one.
+type DiscardTables struct {
+	DealerPairs [NumPairClasses]float64
+	PonePairs   [NumPairClasses]float64
+
+	DealerSingles [NumSingleClasses]float64
+	PoneSingles   [NumSingleClasses]float64
+}
+
+func buildPairClasses() ([13][13][2]int, [NumPairClasses][2]model.Card) {
+	var classes [13][13][2]int
+	var cards [NumPairClasses][2]model.Card
+
+	i := 0
+	for lo := 0; lo < 13; lo++ {
+		for hi := lo; hi < 13; hi++ {
+			for suited := 0; suited < 2; suited++ {
+				if lo == hi && suited == 1 {
+					// a pair can't be suited
+					continue
+				}
+				classes[lo][hi][suited] = i
+				classes[hi][lo][suited] = i
+
+				other := model.Clubs
+				if suited == 1 {
+					other = model.Spades
+				}
+				cards[i] = [2]model.Card{
+					model.NewCard(model.Spades, lo+1),
+					model.NewCard(other, hi+1),
+				}
+				i++
+			}
+		}
+	}
+
+	return classes, cards
+}
+
+// PairClass returns which of the two card discards the cards are
+func PairClass(a, b model.Card) int {
+	suited := 0
+	if a.Suit == b.Suit {
+		suited = 1
+	}
+	return pairClasses[a.Value-1][b.Value-1][suited]
+}
+
+// PairClassCards returns two cards that make up the two card discard
+func PairClassCards(class int) (model.Card, model.Card) {
+	return pairClassCards[class][0], pairClassCards[class][1]
+}
+
+// SingleClass returns which of the one card discards the card is
+func SingleClass(c model.Card) int {
+	return c.Value - 1
+}
+
+// SingleClassCard returns a card that makes up the one card discard
+func SingleClassCard(class int) model.Card {
+	return model.NewCard(model.Spades, class+1)
+}
+
+// Encode packs the tables into the bytes that DecodeDiscardTables reads
+func (dt DiscardTables) Encode() []byte {
+	b := make([]byte, 1, 1+2*(2*NumPairClasses+2*NumSingleClasses))
+	b[0] = discardTablesVersion
+	for _, vals := range [][]float64{
+		dt.DealerPairs[:],
+		dt.PonePairs[:],
+		dt.DealerSingles[:],
+		dt.PoneSingles[:],
+	} {
+		for _, v := range vals {
+			var packed [2]byte
+			binary.LittleEndian.PutUint16(packed[:], uint16(math.Round(v*discardTablesScale)))
+			b = append(b, packed[:]...)
+		}
+	}
+	return b
+}
+
+// DecodeDiscardTables unpacks the tables that were encoded
+func DecodeDiscardTables(b []byte) (DiscardTables, error) {
+	var dt DiscardTables
+	if len(b) != 1+2*(2*NumPairClasses+2*NumSingleClasses) || b[0] != discardTablesVersion {
+		return DiscardTables{}, errBadDiscardTables
+	}
+
+	b = b[1:]
+	for _, vals := range [][]float64{
+		dt.DealerPairs[:],
+		dt.PonePairs[:],
+		dt.DealerSingles[:],
+		dt.PoneSingles[:],
+	} {
+		for i := range vals {
+			vals[i] = float64(binary.LittleEndian.Uint16(b)) / discardTablesScale
+			b = b[2:]
+		}
+	}
+	return dt, nil
+}
+
+func loadDiscardTables() (DiscardTables, error) {
+	loadDiscardTablesOnce.Do(func() {
+		loadedDiscardTables, loadDiscardTablesErr = DecodeDiscardTables([]byte(discardTablesData))
+	})
+	return loadedDiscardTables, loadDiscardTablesErr
+}
+
+// TableCribPoints looks up how many points the crib is expected to score with the
+// discarded cards in it, when each of the players was dealt handSize cards. It
+// returns false when there's no table for the discard: the pairs are only for two
+// players dealt six cards, and the singles for three players dealt five.
+func TableCribPoints(discard []model.Card, numPlayers, handSize int, isDealer bool) (float64, bool) {
+	dt, err := loadDiscardTables()
+	if err != nil {
+		return 0, false
+	}
+
+	switch {
+	case numPlayers == 3 && handSize == 5 && len(discard) == 1:
+		class := SingleClass(discard[0])
+		if isDealer {
+			return dt.DealerSingles[class], true
+		}
+		return dt.PoneSingles[class], true
+	case numPlayers == 2 && handSize == 6 && len(discard) == 2:
+		class := PairClass(discard[0], discard[1])
+		if isDealer {
+			return dt.DealerPairs[class], true
+		}
+		return dt.PonePairs[class], true
+	}
+	return 0, false
+}
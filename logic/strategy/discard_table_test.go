@@ -0,0 +1,168 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestPairClass(t *testing.T) {
+	sizes := map[int]int{}
+	model.FullDeck.Combinations(2, func(pair model.CardSet) {
+		a, rest := pair.Next()
+		b, _ := rest.Next()
+		class := PairClass(a, b)
+		require.True(t, class >= 0 && class < NumPairClasses)
+		assert.Equal(t, class, PairClass(b, a))
+		sizes[class]++
+	})
+	assert.Len(t, sizes, NumPairClasses)
+
+	for class := 0; class < NumPairClasses; class++ {
+		a, b := PairClassCards(class)
+		assert.Equal(t, class, PairClass(a, b))
+		switch {
+		case a.Value == b.Value:
+			assert.Equal(t, 6, sizes[class])
+		case a.Suit == b.Suit:
+			assert.Equal(t, 4, sizes[class])
+		default:
+			assert.Equal(t, 12, sizes[class])
+		}
+	}
+
+	assert.Equal(t, PairClass(model.NewCardFromString(`kh`), model.NewCardFromString(`10s`)),
+		PairClass(model.NewCardFromString(`10d`), model.NewCardFromString(`kc`)))
+	assert.NotEqual(t, PairClass(model.NewCardFromString(`kh`), model.NewCardFromString(`10h`)),
+		PairClass(model.NewCardFromString(`kh`), model.NewCardFromString(`10s`)))
+
+	for class := 0; class < NumSingleClasses; class++ {
+		assert.Equal(t, class, SingleClass(SingleClassCard(class)))
+	}
+}
+
+func TestEncodeDiscardTables(t *testing.T) {
+	var dt DiscardTables
+	dt.DealerPairs[3] = 4.2
+	dt.PonePairs[168] = 12.345
+	dt.DealerSingles[0] = 0.001
+	dt.PoneSingles[12] = 6
+
+	act, err := DecodeDiscardTables(dt.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, dt, act)
+
+	_, err = DecodeDiscardTables(dt.Encode()[1:])
+	assert.Equal(t, errBadDiscardTables, err)
+	_, err = DecodeDiscardTables(append([]byte{0}, dt.Encode()[1:]...))
+	assert.Equal(t, errBadDiscardTables, err)
+}
+
+func TestTableCribPoints(t *testing.T) {
+	_, err := loadDiscardTables()
+	require.NoError(t, err)
+
+	fives := strToCards([]string{`5h`, `5s`})
+	kingQueen := strToCards([]string{`kh`, `qs`})
+	for _, isDealer := range []bool{true, false} {
+		fivesPts, ok := TableCribPoints(fives, 2, 6, isDealer)
+		require.True(t, ok)
+		kingQueenPts, ok := TableCribPoints(kingQueen, 2, 6, isDealer)
+		require.True(t, ok)
+		assert.Greater(t, fivesPts, kingQueenPts)
+
+		five, ok := TableCribPoints(fives[:1], 3, 5, isDealer)
+		require.True(t, ok)
+		king, ok := TableCribPoints(kingQueen[:1], 3, 5, isDealer)
+		require.True(t, ok)
+		assert.Greater(t, five, king)
+	}
+
+	// the pone gives the dealer's crib the worst cards they can, but the dealer
+	// gives their own crib the best
+	dealer, _ := TableCribPoints(fives, 2, 6, true)
+	pone, _ := TableCribPoints(fives, 2, 6, false)
+	assert.Greater(t, pone, dealer)
+
+	// there are only tables for the games they were generated for
+	for _, tc := range []struct {
+		msg        string
+		discard    []model.Card
+		numPlayers int
+		handSize   int
+	}{{
+		msg:        `three cards`,
+		discard:    strToCards([]string{`5h`, `5s`, `5c`}),
+		numPlayers: 2,
+		handSize:   6,
+	}, {
+		msg:        `four players`,
+		discard:    fives[:1],
+		numPlayers: 4,
+		handSize:   5,
+	}, {
+		msg:        `six players`,
+		discard:    fives[:1],
+		numPlayers: 6,
+		handSize:   5,
+	}, {
+		msg:        `two players dealt five cards`,
+		discard:    fives,
+		numPlayers: 2,
+		handSize:   5,
+	}, {
+		msg:        `two players dealt seven cards`,
+		discard:    fives,
+		numPlayers: 2,
+		handSize:   7,
+	}, {
+		msg:        `unknown players`,
+		discard:    fives,
+		numPlayers: 0,
+		handSize:   6,
+	}} {
+		_, ok := TableCribPoints(tc.discard, tc.numPlayers, tc.handSize, true)
+		assert.False(t, ok, tc.msg)
+	}
+}
+
+func TestRankDiscardsFromTable(t *testing.T) {
+	hand := strToCards([]string{`5h`, `5s`, `jc`, `kd`, `9s`, `2c`})
+
+	opts, err := RankDiscardsFromTable(2, hand, 2, false, DefaultPegWeight)
+	require.NoError(t, err)
+	require.Len(t, opts, 15)
+	assert.Equal(t, strToCards([]string{`9s`, `2c`}), opts[0].Crib)
+	for _, o := range opts {
+		exp, ok := TableCribPoints(o.Crib, 2, len(hand), false)
+		require.True(t, ok)
+		assert.Equal(t, exp, o.CribPoints)
+	}
+
+	// there aren't tables for giving three cards, so it works them out instead
+	exp, err := RankDiscards(3, hand, true, DefaultPegWeight)
+	require.NoError(t, err)
+	opts, err = RankDiscardsFromTable(3, hand, 2, true, DefaultPegWeight)
+	require.NoError(t, err)
+	assert.Equal(t, exp, opts)
+
+	// nor for four players, where the crib is one card from each of them
+	fourPlayerHand := hand[:5]
+	exp, err = RankDiscards(1, fourPlayerHand, false, DefaultPegWeight)
+	require.NoError(t, err)
+	opts, err = RankDiscardsFromTable(1, fourPlayerHand, 4, false, DefaultPegWeight)
+	require.NoError(t, err)
+	assert.Equal(t, exp, opts)
+
+	// but there is for three players
+	opts, err = RankDiscardsFromTable(1, fourPlayerHand, 3, false, DefaultPegWeight)
+	require.NoError(t, err)
+	for _, o := range opts {
+		tablePts, ok := TableCribPoints(o.Crib, 3, len(fourPlayerHand), false)
+		require.True(t, ok)
+		assert.Equal(t, tablePts, o.CribPoints)
+	}
+}
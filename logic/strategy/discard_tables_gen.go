@@ -0,0 +1,37 @@
+// Code generated by cmd/discardtables; DO NOT EDIT.
+
+package strategy
+
+// discardTablesData is the encoded DiscardTables
+const discardTablesData = "" +
+	"\x01\x0b\x10\xf6\x09\x16\x0a\x9c\x0a\xbd\x0a\xb0\x12\xd1\x12\x42\x14\x63\x14\x5b\x0c\x79\x0c\x0c" +
+	"\x0c\x24\x0c\x18\x0c\x34\x0c\xac\x0a\xc1\x0a\x08\x0a\x1d\x0a\x35\x0a\x3f\x0a\x88\x09\xa1\x09\x21" +
+	"\x0a\x33\x0a\x4e\x10\x7c\x15\x9f\x15\xb9\x0b\xdc\x0b\x60\x13\x83\x13\x09\x0c\x29\x0c\x83\x0b\xa1" +
+	"\x0b\xf1\x0a\x0e\x0b\x8a\x0a\xa3\x0a\xc6\x09\xde\x09\xf2\x09\xfe\x09\x44\x09\x5f\x09\xd6\x09\xea" +
+	"\x09\xba\x11\x70\x0c\x94\x0c\x9e\x14\xc2\x14\x07\x0b\x26\x0b\xd9\x0a\xf7\x0a\x46\x0b\x65\x0b\x86" +
+	"\x0a\x9b\x0a\x10\x0a\x29\x0a\x3f\x0a\x4c\x0a\x92\x09\xae\x09\x26\x0a\x3b\x0a\xce\x11\x22\x16\x45" +
+	"\x16\xba\x0b\xdb\x0b\x07\x0b\x22\x0b\xa7\x0b\xc6\x0b\x34\x0b\x4f\x0b\xd9\x0a\xf2\x0a\x07\x0b\x14" +
+	"\x0b\x5b\x0a\x77\x0a\xf6\x0a\x0c\x0b\xc0\x22\x73\x18\x93\x18\xb6\x16\xd4\x16\xe5\x14\x05\x15\x17" +
+	"\x15\x33\x15\xf9\x19\x13\x1a\x66\x1a\x73\x1a\xba\x19\xd7\x19\x07\x1a\x1d\x1a\x5e\x14\x55\x11\x6f" +
+	"\x11\x58\x11\x74\x11\x02\x13\x1a\x13\x95\x0b\xaa\x0b\xa3\x0b\xac\x0b\xdc\x0a\xf4\x0a\x91\x0b\xa3" +
+	"\x0b\x16\x15\x99\x19\xb2\x19\x9a\x0f\xaf\x0f\x7e\x0b\x8f\x0b\xc0\x0b\xc6\x0b\x23\x0b\x36\x0b\xe1" +
+	"\x0b\xef\x0b\x64\x14\xcd\x13\xe3\x13\xc4\x0f\xd8\x0f\xab\x0b\xb2\x0b\x69\x0b\x7c\x0b\x1c\x0c\x29" +
+	"\x0c\x28\x13\x24\x10\x34\x10\x84\x0f\x8a\x0f\x98\x0a\xab\x0a\x84\x0b\x90\x0b\x57\x11\x66\x11\x6a" +
+	"\x11\x14\x0c\x25\x0c\x5d\x0a\x66\x0a\x39\x12\xda\x12\xe1\x12\xd4\x0e\xd5\x0e\x59\x10\x7c\x0c\x8a" +
+	"\x0c\x04\x11\xce\x1a\x7d\x15\xa7\x15\x90\x16\xb9\x16\xc8\x1a\xf5\x1a\xa1\x1b\xbd\x1b\xbc\x17\xe3" +
+	"\x17\xfb\x15\x24\x16\x97\x15\xc1\x15\xe2\x18\x0f\x19\xcd\x16\xfa\x16\xf7\x17\x0c\x18\x64\x16\x93" +
+	"\x16\xd1\x15\x01\x16\x64\x1a\x99\x1c\xcb\x1c\x00\x19\x25\x19\xcb\x1b\xe0\x1b\x98\x17\xb9\x17\xf0" +
+	"\x16\x13\x17\x9e\x17\xc3\x17\xa8\x15\xcf\x15\x78\x16\x9e\x16\xa0\x17\xb0\x17\x0d\x16\x37\x16\x78" +
+	"\x15\xa2\x15\xc7\x1c\x12\x1f\x37\x1f\x26\x1f\x39\x1f\xc9\x17\xe8\x17\x1d\x1a\x3f\x1a\x87\x15\xaa" +
+	"\x15\x19\x16\x40\x16\x6b\x17\x90\x17\x96\x18\xa4\x18\xff\x16\x27\x17\x67\x16\x90\x16\x8a\x21\xfc" +
+	"\x23\x16\x24\x1c\x22\x3f\x22\x91\x17\xb5\x17\xac\x17\xd1\x17\xb9\x18\xe2\x18\xeb\x18\x13\x19\x1c" +
+	"\x1a\x2d\x1a\x7d\x18\xa8\x18\xe5\x17\x11\x18\x51\x29\x99\x22\xb2\x22\x80\x20\x94\x20\xde\x1b\xf3" +
+	"\x1b\x36\x1c\x4f\x1c\x63\x22\x86\x22\x2e\x23\x42\x23\x1a\x22\x39\x22\x9a\x21\xb9\x21\xe9\x20\xdd" +
+	"\x1f\x00\x20\x9b\x18\xbd\x18\xca\x1c\xf9\x1c\x09\x18\x2d\x18\x3f\x19\x4d\x19\xa1\x17\xc8\x17\x05" +
+	"\x17\x2d\x17\x90\x1d\xf0\x1e\x2d\x1f\x7f\x17\xa6\x17\xea\x16\x10\x17\x30\x18\x3f\x18\x95\x16\xbe" +
+	"\x16\xfe\x15\x27\x16\xcd\x19\x0c\x18\x35\x18\x40\x17\x67\x17\xaf\x16\xc0\x16\x3e\x15\x68\x15\xa8" +
+	"\x14\xd3\x14\xb0\x1c\x67\x1b\x91\x1b\xc0\x19\xd3\x19\x14\x16\x41\x16\xce\x15\xfc\x15\xe3\x1e\xea" +
+	"\x1b\xfd\x1b\x45\x1a\x71\x1a\xa3\x16\xd0\x16\x6d\x20\x80\x1b\x95\x1b\x50\x19\x66\x19\x43\x1e\x6e" +
+	"\x19\x9e\x19\x45\x1d\x39\x0f\x2a\x0e\xa9\x0e\x75\x10\x74\x1a\xb4\x0d\x5d\x0d\xd7\x0d\xf8\x0d\x88" +
+	"\x0e\xcf\x12\x1f\x10\x5a\x10\x53\x13\x1e\x13\x32\x13\x50\x14\x24\x1c\xaa\x12\x47\x11\xdd\x10\x56" +
+	"\x11\xe4\x12\x7a\x14\xc6\x13\xa6\x13"
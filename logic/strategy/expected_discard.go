@@ -0,0 +1,160 @@
+package strategy
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/joshprzybyszewski/cribbage/logic/pegging"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// DefaultPegWeight is how much the expected pegging points count towards a discard's
+// value. Answering a single lead is only a small part of the pegging, so it's
+// counted for less than the points in the hand and crib.
+const DefaultPegWeight float64 = 0.5
+
+// DiscardOption is one way to build the crib from a dealt hand, and the points it's
+// expected to be worth over every cut and every set of cards the others could give
+type DiscardOption struct {
+	Kept []model.Card
+	Crib []model.Card
+
+	// HandPoints is the expected points counted in the kept hand
+	HandPoints float64
+	// CribPoints is the expected points counted in the crib
+	CribPoints float64
+	// PegPoints is the expected points pegged when answering the first card led
+	PegPoints float64
+
+	// Value adds up the points for whoever dealt the hand. The crib counts against
+	// the pone, and the pegging is counted by its weight.
+	Value float64
+}
+
+// RankDiscards returns every way to give the desired number of cards to the crib,
+// with the best one first
+func RankDiscards(
+	desired int,
+	hand []model.Card,
+	isDealer bool,
+	pegWeight float64,
+) ([]DiscardOption, error) {
+
+	return rankDiscards(desired, hand, 0, isDealer, pegWeight)
+}
+
+// RankDiscardsFromTable is RankDiscards, but it looks up the crib points in the
+// discard tables when there's one for a game with numPlayers, instead of working
+// them out. The tables don't know which cards were kept, but they do know what the
+// other players tend to discard.
+func RankDiscardsFromTable(
+	desired int,
+	hand []model.Card,
+	numPlayers int,
+	isDealer bool,
+	pegWeight float64,
+) ([]DiscardOption, error) {
+
+	return rankDiscards(desired, hand, numPlayers, isDealer, pegWeight)
+}
+
+// rankDiscards never finds a table for zero players, so it works out all of the
+// crib points
+func rankDiscards(
+	desired int,
+	hand []model.Card,
+	numPlayers int,
+	isDealer bool,
+	pegWeight float64,
+) ([]DiscardOption, error) {
+
+	if desired <= 0 || desired >= len(hand) || desired > 4 {
+		return nil, errors.New(`must keep at least one card and deposit between one and four cards`)
+	}
+
+	allDeposits, err := chooseFrom(desired, hand)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := model.NewCardSet(hand...)
+
+	opts := make([]DiscardOption, 0, len(allDeposits))
+	for _, dep := range allDeposits {
+		kept := without(hand, dep)
+		o := DiscardOption{
+			Kept:       kept,
+			Crib:       dep,
+			HandPoints: getHandPotentialForCribDeposit(seen, model.NewCardSet(kept...)),
+			PegPoints:  getPegPotential(seen, kept),
+		}
+
+		var ok bool
+		o.CribPoints, ok = TableCribPoints(dep, numPlayers, len(hand), isDealer)
+		if !ok {
+			o.CribPoints = getPotentialForDeposit(seen, model.NewCardSet(dep...))
+		}
+
+		o.Value = o.HandPoints + pegWeight*o.PegPoints
+		if isDealer {
+			o.Value += o.CribPoints
+		} else {
+			o.Value -= o.CribPoints
+		}
+		opts = append(opts, o)
+	}
+
+	sort.SliceStable(opts, func(i, j int) bool {
+		return opts[i].Value > opts[j].Value
+	})
+
+	return opts, nil
+}
+
+// DealerHighestExpectedValue gives the crib the cards that the dealer expects to
+// score the most with, between their hand and their crib
+func DealerHighestExpectedValue(desired int, hand []model.Card, numPlayers int) ([]model.Card, error) {
+	return getHighestExpectedValue(desired, hand, numPlayers, true)
+}
+
+// PoneHighestExpectedValue gives the crib the cards that the pone expects to score
+// the most with, after taking away what the dealer's crib will be worth
+func PoneHighestExpectedValue(desired int, hand []model.Card, numPlayers int) ([]model.Card, error) {
+	return getHighestExpectedValue(desired, hand, numPlayers, false)
+}
+
+// getHighestExpectedValue looks up the crib points in the discard tables when it
+// can, so that the NPCs don't have to work them out on every turn
+func getHighestExpectedValue(desired int, hand []model.Card, numPlayers int, isDealer bool) ([]model.Card, error) {
+	opts, err := RankDiscardsFromTable(desired, hand, numPlayers, isDealer, DefaultPegWeight)
+	if err != nil {
+		return nil, err
+	}
+	return opts[0].Crib, nil
+}
+
+// getPegPotential returns the average points the hand can peg in answer to a lead
+// of any of the unseen cards
+func getPegPotential(seen model.CardSet, hand []model.Card) float64 {
+	totalPegPoints := 0
+	totalLeads := 0
+
+	for leads := model.FullDeck.Without(seen); leads != model.EmptyCardSet; {
+		var lead model.Card
+		lead, leads = leads.Next()
+
+		led := []model.PeggedCard{{Card: lead}}
+		best := 0
+		for _, c := range hand {
+			// the lead was never in the hand, so it can always be pegged on
+			if pts, _ := pegging.PointsForCard(led, c); pts > best {
+				best = pts
+			}
+		}
+
+		totalPegPoints += best
+		totalLeads++
+	}
+
+	return float64(totalPegPoints) / float64(totalLeads)
+}
@@ -0,0 +1,52 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankDiscards(t *testing.T) {
+	hand := strToCards([]string{`5h`, `5s`, `5c`, `5d`, `2h`, `1s`})
+
+	opts, err := RankDiscards(2, hand, false, DefaultPegWeight)
+	require.NoError(t, err)
+	require.Len(t, opts, 15)
+
+	assert.Equal(t, strToCards([]string{`5h`, `5s`, `5c`, `5d`}), opts[0].Kept)
+	assert.Equal(t, strToCards([]string{`2h`, `1s`}), opts[0].Crib)
+	for i, o := range opts {
+		assert.Len(t, o.Kept, 4)
+		assert.ElementsMatch(t, hand, append(append(o.Kept[:0:0], o.Kept...), o.Crib...))
+		assert.InDelta(t, o.HandPoints-o.CribPoints+DefaultPegWeight*o.PegPoints, o.Value, 0.0001)
+		if i > 0 {
+			assert.GreaterOrEqual(t, opts[i-1].Value, o.Value)
+		}
+	}
+
+	opts, err = RankDiscards(1, hand[:5], true, 0)
+	require.NoError(t, err)
+	require.Len(t, opts, 5)
+	assert.Equal(t, strToCards([]string{`2h`}), opts[0].Crib)
+	assert.InDelta(t, opts[0].HandPoints+opts[0].CribPoints, opts[0].Value, 0.0001)
+
+	_, err = RankDiscards(0, hand, true, DefaultPegWeight)
+	assert.Error(t, err)
+	_, err = RankDiscards(6, hand, true, DefaultPegWeight)
+	assert.Error(t, err)
+}
+
+func TestHighestExpectedValue(t *testing.T) {
+	hand := strToCards([]string{`2h`, `2s`, `5c`, `qd`, `kd`, `9c`})
+
+	// the dealer keeps the pair of twos for their own crib
+	crib, err := DealerHighestExpectedValue(2, hand, 2)
+	require.NoError(t, err)
+	assert.Equal(t, strToCards([]string{`2h`, `2s`}), crib)
+
+	// but the pone would rather not give it to them
+	crib, err = PoneHighestExpectedValue(2, hand, 2)
+	require.NoError(t, err)
+	assert.Equal(t, strToCards([]string{`kd`, `9c`}), crib)
+}
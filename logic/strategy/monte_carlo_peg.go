@@ -0,0 +1,178 @@
+package strategy
+
+import (
+	"math"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/utils/rand"
+)
+
+const (
+	// explorationConstant weighs trying out moves against the moves that have
+	// done well so far. The rewards are in points, so it's larger than the usual
+	// square root of two.
+	explorationConstant float64 = 3
+	// winningBonus is how many points pegging out to win the game is worth
+	winningBonus float64 = 30
+	// checkTimeEvery is how many iterations to run between looking at the clock
+	checkTimeEvery int = 64
+)
+
+// SearchBudget limits how long the monte carlo search thinks about each card. It
+// stops at whichever limit it reaches first, and a zero value isn't a limit.
+type SearchBudget struct {
+	Iterations int
+	Duration   time.Duration
+}
+
+// DefaultSearchBudget is used when a budget doesn't have any limits
+var DefaultSearchBudget = SearchBudget{
+	Iterations: 5000,
+	Duration:   500 * time.Millisecond,
+}
+
+func (b SearchBudget) withDefaults() SearchBudget {
+	if b.Iterations <= 0 && b.Duration <= 0 {
+		return DefaultSearchBudget
+	}
+	return b
+}
+
+// PegMonteCarlo picks the card for the player to peg using information set monte
+// carlo tree search. It only uses what the player can see: their own cards, the
+// cut, the cards pegged so far, and the goes the other players have said. Each
+// iteration deals the unseen cards out to the other players in a way that agrees
+// with that, and plays out the rest of the pegging.
+func PegMonteCarlo(g model.Game, pID model.PlayerID, b SearchBudget) (model.Card, bool) {
+	v := newPegView(g, pID)
+	moves := v.root.legalMoves()
+	if len(moves) == 1 {
+		return moves[0].card, moves[0].sayGo
+	}
+
+	rng := mathrand.New(mathrand.NewSource(rand.Int64n(math.MaxInt64)))
+	best := search(v, rng, b.withDefaults())
+	return best.card, best.sayGo
+}
+
+// mctsNode is a move in the search tree. Because each iteration deals different
+// cards to the other players, a move isn't always available, so the node counts
+// how often it was.
+type mctsNode struct {
+	move   pegMove
+	seat   int
+	parent *mctsNode
+
+	children []*mctsNode
+
+	visits    int
+	available int
+	reward    float64
+}
+
+func search(v pegView, rng *mathrand.Rand, b SearchBudget) pegMove {
+	root := &mctsNode{
+		seat: -1,
+	}
+
+	var deadline time.Time
+	if b.Duration > 0 {
+		deadline = time.Now().Add(b.Duration)
+	}
+	for i := 0; b.Iterations <= 0 || i < b.Iterations; i++ {
+		if !deadline.IsZero() && i%checkTimeEvery == 0 && time.Now().After(deadline) {
+			break
+		}
+		iterate(root, v.determinize(rng), rng)
+	}
+
+	var best *mctsNode
+	for _, c := range root.children {
+		if best == nil || c.visits > best.visits {
+			best = c
+		}
+	}
+	return best.move
+}
+
+// iterate walks down the tree as far as it's been explored for these cards, adds
+// one new move to it, then plays the rest of the pegging out at random
+func iterate(root *mctsNode, s pegState, rng *mathrand.Rand) {
+	n := root
+	for !s.isOver() {
+		moves := s.legalMoves()
+		if untried := n.untried(moves); len(untried) > 0 {
+			m := untried[rng.Intn(len(untried))]
+			n = n.addChild(m, s.toPlay)
+			s.apply(m)
+			break
+		}
+		n = n.selectChild(moves)
+		s.apply(n.move)
+	}
+
+	for !s.isOver() {
+		moves := s.legalMoves()
+		s.apply(moves[rng.Intn(len(moves))])
+	}
+
+	rewards := s.rewards()
+	for ; n != nil; n = n.parent {
+		n.visits++
+		if n.seat >= 0 {
+			n.reward += rewards[n.seat]
+		}
+	}
+}
+
+// untried returns the moves that don't have a node yet. Every move that does is
+// counted as available.
+func (n *mctsNode) untried(moves []pegMove) []pegMove {
+	var untried []pegMove
+	for _, m := range moves {
+		if c := n.child(m); c != nil {
+			c.available++
+		} else {
+			untried = append(untried, m)
+		}
+	}
+	return untried
+}
+
+func (n *mctsNode) child(m pegMove) *mctsNode {
+	for _, c := range n.children {
+		if c.move == m {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *mctsNode) addChild(m pegMove, seat int) *mctsNode {
+	c := &mctsNode{
+		move:      m,
+		seat:      seat,
+		parent:    n,
+		available: 1,
+	}
+	n.children = append(n.children, c)
+	return c
+}
+
+// selectChild picks the available move with the best upper confidence bound, for
+// the player making it
+func (n *mctsNode) selectChild(moves []pegMove) *mctsNode {
+	var best *mctsNode
+	bestScore := math.Inf(-1)
+	for _, m := range moves {
+		c := n.child(m)
+		score := c.reward/float64(c.visits) +
+			explorationConstant*math.Sqrt(math.Log(float64(c.available))/float64(c.visits))
+		if score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,349 @@
+package strategy
+
+import (
+	mathrand "math/rand"
+	"sort"
+
+	"github.com/joshprzybyszewski/cribbage/logic/pegging"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// pegMove is either pegging a card, or saying go
+type pegMove struct {
+	card  model.Card
+	sayGo bool
+}
+
+// pegState is the pegging as the server plays it out, with every hand known.
+// Players take turns around the table, and a player without a card that fits
+// under 31 says go. The count starts over at 31, or once the goes have gone all
+// the way around to whoever pegged last, who takes a point for it.
+type pegState struct {
+	hands [][]model.Card
+
+	pegged []model.PeggedCard
+	// runTotal is the count the same way that model.Game.CurrentPeg adds it up,
+	// which only starts over when a card would go past 31
+	runTotal int
+	// wentAround is true once the goes have gone around since the last card
+	wentAround bool
+	lastPegger int
+
+	toPlay    int
+	cardsLeft int
+
+	seatTeam []int
+	points   []int
+	// needed is how many points each team needs to win the game
+	needed []int
+	winner int
+}
+
+func (s *pegState) clone() pegState {
+	c := *s
+	c.hands = make([][]model.Card, len(s.hands))
+	for i, h := range s.hands {
+		c.hands[i] = append([]model.Card(nil), h...)
+	}
+	c.pegged = append(make([]model.PeggedCard, 0, len(s.pegged)+s.cardsLeft), s.pegged...)
+	c.points = append([]int(nil), s.points...)
+	return c
+}
+
+// count is the count as model.Game.CurrentPeg returns it
+func (s *pegState) count() int {
+	if s.wentAround || s.runTotal == model.MaxPeggingValue {
+		return 0
+	}
+	return s.runTotal
+}
+
+func (s *pegState) isOver() bool {
+	return s.cardsLeft == 0 || s.winner >= 0
+}
+
+// legalMoves returns the moves the player whose turn it is can make
+func (s *pegState) legalMoves() []pegMove {
+	cur := s.count()
+	hand := s.hands[s.toPlay]
+	moves := make([]pegMove, 0, len(hand))
+	for _, c := range hand {
+		if cur+c.PegValue() <= model.MaxPeggingValue {
+			moves = append(moves, pegMove{card: c})
+		}
+	}
+	if len(moves) == 0 {
+		moves = append(moves, pegMove{sayGo: true})
+	}
+	return moves
+}
+
+func (s *pegState) apply(m pegMove) {
+	seat := s.toPlay
+	s.toPlay = (seat + 1) % len(s.hands)
+
+	if m.sayGo {
+		if seat == s.lastPegger {
+			s.wentAround = true
+			s.addPoints(seat, 1)
+		}
+		return
+	}
+
+	// the cards were dealt from one deck, so the only way this can fail is
+	// by pegging too many cards, which the simulation never does
+	pts, _ := pegging.PointsForCard(s.pegged, m.card)
+	s.pegged = append(s.pegged, model.PeggedCard{Card: m.card})
+	s.hands[seat] = removeCard(s.hands[seat], m.card)
+	s.runTotal += m.card.PegValue()
+	if s.runTotal > model.MaxPeggingValue {
+		s.runTotal = m.card.PegValue()
+	}
+	s.wentAround = false
+	s.lastPegger = seat
+	s.cardsLeft--
+
+	if s.cardsLeft == 0 {
+		// the last card takes a point
+		pts++
+	}
+	s.addPoints(seat, pts)
+}
+
+func (s *pegState) addPoints(seat, pts int) {
+	if s.winner >= 0 {
+		return
+	}
+	team := s.seatTeam[seat]
+	s.points[team] += pts
+	if s.points[team] >= s.needed[team] {
+		s.winner = team
+	}
+}
+
+// rewards returns how well the pegging went for each seat. That's how many more
+// points their team pegged than the other teams did, on average, plus a bonus
+// for pegging out to win the game.
+func (s *pegState) rewards() []float64 {
+	numTeams := len(s.points)
+	total := 0
+	for _, p := range s.points {
+		total += p
+	}
+
+	byTeam := make([]float64, numTeams)
+	for t, p := range s.points {
+		byTeam[t] = float64(p)
+		if numTeams > 1 {
+			byTeam[t] -= float64(total-p) / float64(numTeams-1)
+		}
+		if s.winner == t {
+			byTeam[t] += winningBonus
+		} else if s.winner >= 0 {
+			byTeam[t] -= winningBonus / float64(numTeams-1)
+		}
+	}
+
+	res := make([]float64, len(s.seatTeam))
+	for seat, t := range s.seatTeam {
+		res[seat] = byTeam[t]
+	}
+	return res
+}
+
+// pegView is what one player can see of the pegging: everything but the cards
+// that the other players have left
+type pegView struct {
+	me   int
+	root pegState
+
+	// the cards that the other players could be holding
+	unseen []model.Card
+	// how many cards each seat has left to peg
+	numCards []int
+	// the lowest peg value that each seat could be holding, going by the goes they've said
+	minValue []int
+}
+
+func newPegView(g model.Game, pID model.PlayerID) pegView {
+	v := pegView{
+		numCards: make([]int, len(g.Players)),
+		minValue: make([]int, len(g.Players)),
+		root: pegState{
+			hands:      make([][]model.Card, len(g.Players)),
+			pegged:     append([]model.PeggedCard(nil), g.PeggedCards...),
+			lastPegger: -1,
+			seatTeam:   make([]int, len(g.Players)),
+			winner:     -1,
+		},
+	}
+
+	seats := make(map[model.PlayerID]int, len(g.Players))
+	teams := map[model.PlayerColor]int{}
+	for i, p := range g.Players {
+		seats[p.ID] = i
+		color := g.PlayerColors[p.ID]
+		if _, ok := teams[color]; !ok {
+			teams[color] = len(teams)
+			v.root.needed = append(v.root.needed, g.Options.Rules.TargetScore()-g.CurrentScores[color])
+		}
+		v.root.seatTeam[i] = teams[color]
+		v.numCards[i] = g.Options.Rules.KeptHandSize()
+	}
+	v.root.points = make([]int, len(teams))
+	v.me = seats[pID]
+	v.root.toPlay = v.me
+
+	for _, pc := range g.PeggedCards {
+		v.numCards[seats[pc.PlayerID]]--
+		v.root.runTotal += pc.PegValue()
+		if v.root.runTotal > model.MaxPeggingValue {
+			v.root.runTotal = pc.PegValue()
+		}
+		v.root.lastPegger = seats[pc.PlayerID]
+	}
+	v.root.wentAround = len(g.PeggedCards) > 0 && g.CurrentPeg() != v.root.count()
+	for _, n := range v.numCards {
+		v.root.cardsLeft += n
+	}
+
+	v.root.hands[v.me] = unpegged(g.Hands[pID], g.PeggedCards)
+	v.unseen = unseenCards(g, pID)
+	v.addGoLimits(g, seats)
+
+	return v
+}
+
+func unpegged(hand []model.Card, pegged []model.PeggedCard) []model.Card {
+	left := append([]model.Card(nil), hand...)
+	for _, pc := range pegged {
+		left = removeCard(left, pc.Card)
+	}
+	return left
+}
+
+// removeCard returns a new slice of the cards without c
+func removeCard(cards []model.Card, c model.Card) []model.Card {
+	res := make([]model.Card, 0, len(cards))
+	for _, oc := range cards {
+		if oc != c {
+			res = append(res, oc)
+		}
+	}
+	return res
+}
+
+// unseenCards returns the cards that the player hasn't seen: every card that isn't
+// in their hand, in what they gave to the crib, the cut, or already pegged
+func unseenCards(g model.Game, pID model.PlayerID) []model.Card {
+	seen := make(map[model.Card]struct{}, model.NumCardsPerDeck)
+	for _, c := range g.Hands[pID] {
+		seen[c] = struct{}{}
+	}
+	for _, pc := range g.PeggedCards {
+		seen[pc.Card] = struct{}{}
+	}
+	seen[g.CutCard] = struct{}{}
+	for i := len(g.Actions) - 1; i >= 0; i-- {
+		if bca, ok := g.Actions[i].Action.(model.BuildCribAction); ok && g.Actions[i].ID == pID {
+			// the player's most recent discards were for this deal
+			for _, c := range bca.Cards {
+				seen[c] = struct{}{}
+			}
+			break
+		}
+	}
+
+	unseen := make([]model.Card, 0, model.NumCardsPerDeck-len(seen))
+	for i := 0; i < model.NumCardsPerDeck; i++ {
+		c := model.NewCardFromNumber(i)
+		if _, ok := seen[c]; !ok {
+			unseen = append(unseen, c)
+		}
+	}
+	return unseen
+}
+
+// addGoLimits looks at the goes said so far in this pegging. A player that said go
+// had no card that fit under 31, and so every card they still have is worth more.
+func (v *pegView) addGoLimits(g model.Game, seats map[model.PlayerID]int) {
+	start := len(g.Events)
+	for i := len(g.Events) - 1; i >= 0; i-- {
+		if pce, ok := g.Events[i].Event.(model.PhaseChangedEvent); ok && pce.Phase == model.Pegging {
+			start = i
+			break
+		}
+	}
+
+	count := 0
+	var last model.PlayerID
+	for _, ge := range g.Events[start:] {
+		switch e := ge.Event.(type) {
+		case model.CardPeggedEvent:
+			count, last = e.Peg, e.PlayerID
+			if count == model.MaxPeggingValue {
+				count = 0
+			}
+		case model.GoSaidEvent:
+			seat := seats[e.PlayerID]
+			if min := model.MaxPeggingValue - count + 1; min > v.minValue[seat] {
+				v.minValue[seat] = min
+			}
+			if e.PlayerID == last {
+				count = 0
+			}
+		}
+	}
+}
+
+// determinize deals out the unseen cards to the other players, in a way that
+// agrees with the goes they've said
+func (v *pegView) determinize(rng *mathrand.Rand) pegState {
+	s := v.root.clone()
+
+	pool := append([]model.Card(nil), v.unseen...)
+	rng.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	// the players with the strictest limits get their cards first
+	others := make([]int, 0, len(s.hands)-1)
+	for seat := range s.hands {
+		if seat != v.me {
+			others = append(others, seat)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return v.minValue[others[i]] > v.minValue[others[j]]
+	})
+
+	for _, seat := range others {
+		s.hands[seat], pool = dealFrom(pool, v.numCards[seat], v.minValue[seat])
+	}
+
+	// there are always enough unseen cards, but the pegging has to end either way
+	s.cardsLeft = 0
+	for _, h := range s.hands {
+		s.cardsLeft += len(h)
+	}
+	return s
+}
+
+// dealFrom takes n cards worth at least min from the pool. If there aren't enough,
+// it makes up the rest with any card.
+func dealFrom(pool []model.Card, n, min int) ([]model.Card, []model.Card) {
+	hand := make([]model.Card, 0, n)
+	for i := 0; i < len(pool) && len(hand) < n; {
+		if pool[i].PegValue() < min {
+			i++
+			continue
+		}
+		hand = append(hand, pool[i])
+		pool = append(pool[:i], pool[i+1:]...)
+	}
+	for len(hand) < n && len(pool) > 0 {
+		hand = append(hand, pool[0])
+		pool = pool[1:]
+	}
+	return hand, pool
+}
@@ -0,0 +1,216 @@
+package strategy
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// pegGame returns a heads up game between alice and bob where the cards have been
+// pegged in order, starting with bob
+func pegGame(aliceHand, bobHand, pegged []string) model.Game {
+	g := model.Game{
+		Players: []model.Player{
+			{ID: `alice`},
+			{ID: `bob`},
+		},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`alice`: model.Blue,
+			`bob`:   model.Red,
+		},
+		CurrentScores: map[model.PlayerColor]int{},
+		Hands: map[model.PlayerID][]model.Card{
+			`alice`: strToCards(aliceHand),
+			`bob`:   strToCards(bobHand),
+		},
+		CutCard: model.NewCardFromString(`ah`),
+		Phase:   model.Pegging,
+	}
+	g.AddAction(model.PlayerAction{
+		ID:     `alice`,
+		Action: model.BuildCribAction{Cards: strToCards([]string{`as`, `ad`})},
+	})
+	g.AddEvent(model.PhaseChangedEvent{Phase: model.Pegging})
+
+	pIDs := []model.PlayerID{`bob`, `alice`}
+	for i, c := range strToCards(pegged) {
+		pID := pIDs[i%2]
+		g.AddEvent(model.CardPeggedEvent{
+			PlayerID: pID,
+			Card:     c,
+			Peg:      g.CurrentPeg() + c.PegValue(),
+		})
+		g.PeggedCards = append(g.PeggedCards, model.PeggedCard{
+			Card:     c,
+			PlayerID: pID,
+		})
+	}
+	return g
+}
+
+func TestPegMonteCarlo(t *testing.T) {
+	budget := SearchBudget{
+		Iterations: 500,
+	}
+
+	tests := []struct {
+		desc     string
+		g        model.Game
+		needed   int
+		expCard  model.Card
+		expSayGo bool
+	}{{
+		desc: `takes 31`,
+		g: pegGame(
+			[]string{`qh`, `3c`, `2d`, `9c`},
+			[]string{`kh`, `8s`, `7d`, `7c`},
+			[]string{`kh`, `qh`, `8s`},
+		),
+		expCard: model.NewCardFromString(`3c`),
+	}, {
+		desc: `says go without a card that fits`,
+		g: pegGame(
+			[]string{`qh`, `9c`, `5d`, `kd`},
+			[]string{`kh`, `8s`, `7d`, `7c`},
+			[]string{`kh`, `qh`, `8s`},
+		),
+		expSayGo: true,
+	}, {
+		desc: `pegs out to win`,
+		g: pegGame(
+			[]string{`8c`, `4d`, `6s`, `jd`},
+			[]string{`7h`, `8s`, `7d`, `7c`},
+			[]string{`7h`},
+		),
+		needed:  2,
+		expCard: model.NewCardFromString(`8c`),
+	}}
+
+	for _, tc := range tests {
+		if tc.needed > 0 {
+			tc.g.CurrentScores[model.Blue] = tc.g.Options.Rules.TargetScore() - tc.needed
+		}
+		c, sayGo := PegMonteCarlo(tc.g, `alice`, budget)
+		assert.Equal(t, tc.expSayGo, sayGo, tc.desc)
+		if !tc.expSayGo {
+			assert.Equal(t, tc.expCard, c, tc.desc)
+		}
+	}
+}
+
+func TestPegStateApply(t *testing.T) {
+	s := pegState{
+		hands: [][]model.Card{
+			strToCards([]string{`kc`, `5d`}),
+			strToCards([]string{`qh`, `jh`}),
+		},
+		lastPegger: -1,
+		cardsLeft:  4,
+		seatTeam:   []int{0, 1},
+		points:     []int{0, 0},
+		needed:     []int{121, 121},
+		winner:     -1,
+	}
+
+	s.apply(pegMove{card: model.NewCardFromString(`kc`)})
+	s.apply(pegMove{card: model.NewCardFromString(`qh`)})
+	s.apply(pegMove{card: model.NewCardFromString(`5d`)})
+	assert.Equal(t, 25, s.count())
+	assert.Equal(t, []pegMove{{sayGo: true}}, s.legalMoves())
+	s.apply(pegMove{sayGo: true})
+	assert.Equal(t, []int{0, 0}, s.points)
+
+	// alice pegged last, so she takes the go when it comes back around
+	s.apply(pegMove{sayGo: true})
+	assert.Equal(t, []int{1, 0}, s.points)
+	assert.Zero(t, s.count())
+	assert.False(t, s.isOver())
+
+	// and bob takes the last card
+	assert.Equal(t, []pegMove{{card: model.NewCardFromString(`jh`)}}, s.legalMoves())
+	s.apply(pegMove{card: model.NewCardFromString(`jh`)})
+	assert.Equal(t, []int{1, 1}, s.points)
+	assert.True(t, s.isOver())
+	assert.Equal(t, []float64{0, 0}, s.rewards())
+}
+
+func TestPegStateWinner(t *testing.T) {
+	s := pegState{
+		hands: [][]model.Card{
+			strToCards([]string{`7c`, `5d`}),
+			strToCards([]string{`8h`, `jh`}),
+		},
+		lastPegger: -1,
+		cardsLeft:  4,
+		seatTeam:   []int{0, 1},
+		points:     []int{0, 0},
+		needed:     []int{5, 2},
+		winner:     -1,
+	}
+
+	s.apply(pegMove{card: model.NewCardFromString(`7c`)})
+	s.apply(pegMove{card: model.NewCardFromString(`8h`)})
+	assert.True(t, s.isOver())
+	assert.Equal(t, 1, s.winner)
+	assert.Equal(t, []float64{-2 - winningBonus, 2 + winningBonus}, s.rewards())
+}
+
+func TestNewPegView(t *testing.T) {
+	g := pegGame(
+		[]string{`kc`, `jd`, `6c`, `2c`},
+		[]string{`5h`, `9s`, `10s`, `qd`},
+		[]string{`5h`, `kc`, `9s`, `6c`},
+	)
+	// bob said go with the count at 30, so he can't have an ace
+	g.AddEvent(model.GoSaidEvent{PlayerID: `bob`})
+
+	v := newPegView(g, `alice`)
+	assert.Equal(t, 0, v.me)
+	assert.Equal(t, []int{2, 2}, v.numCards)
+	assert.Equal(t, []int{0, 2}, v.minValue)
+	assert.Equal(t, 30, v.root.count())
+	assert.Equal(t, 4, v.root.cardsLeft)
+	assert.Equal(t, 0, v.root.lastPegger)
+	assert.ElementsMatch(t, strToCards([]string{`jd`, `2c`}), v.root.hands[0])
+
+	// alice's hand, her crib cards, the cut, and the pegged cards are all seen
+	assert.Len(t, v.unseen, 52-4-2-1-2)
+	for _, c := range strToCards([]string{`kc`, `jd`, `6c`, `2c`, `as`, `ad`, `ah`, `5h`, `9s`}) {
+		assert.NotContains(t, v.unseen, c)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s := v.determinize(rng)
+		require.Len(t, s.hands[1], 2)
+		for _, c := range s.hands[1] {
+			assert.Contains(t, v.unseen, c)
+			assert.GreaterOrEqual(t, c.PegValue(), 2)
+		}
+		assert.Equal(t, v.root.hands[0], s.hands[0])
+	}
+}
+
+func TestNewPegViewAfterGoesGoAround(t *testing.T) {
+	g := pegGame(
+		[]string{`kc`, `jd`, `8c`, `9c`},
+		[]string{`5h`, `9s`, `10s`, `qd`},
+		[]string{`5h`, `kc`, `9s`},
+	)
+	g.AddAction(model.PlayerAction{ID: `alice`, Action: model.PegAction{SayGo: true}})
+	g.AddAction(model.PlayerAction{ID: `bob`, Action: model.PegAction{SayGo: true}})
+	g.AddEvent(model.GoSaidEvent{PlayerID: `alice`})
+	g.AddEvent(model.GoSaidEvent{PlayerID: `bob`})
+	require.Zero(t, g.CurrentPeg())
+
+	v := newPegView(g, `alice`)
+	assert.True(t, v.root.wentAround)
+	assert.Zero(t, v.root.count())
+	// the server only allows a go without a card that fits, so both of them
+	// are holding cards worth more than 7
+	assert.Equal(t, []int{8, 8}, v.minValue)
+}
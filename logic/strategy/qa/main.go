@@ -50,6 +50,24 @@ func reportAboutHand(cstrs []string) {
 	} else {
 		fmt.Printf("GiveCribHighestPotential: %+v\n", highCrib)
 	}
+
+	reportDiscards(cstrs, true)
+	reportDiscards(cstrs, false)
+}
+
+func reportDiscards(cstrs []string, isDealer bool) {
+	hand := strToCards(cstrs)
+	opts, err := strategy.RankDiscards(len(hand)-4, hand, isDealer, strategy.DefaultPegWeight)
+	if err != nil {
+		fmt.Printf("RankDiscards(isDealer: %v): Error! %v\n", isDealer, err)
+		return
+	}
+
+	fmt.Printf("RankDiscards(isDealer: %v):\n", isDealer)
+	for _, o := range opts {
+		fmt.Printf("  %+v: hand %.2f, crib %.2f, peg %.2f, value %.2f\n",
+			o.Crib, o.HandPoints, o.CribPoints, o.PegPoints, o.Value)
+	}
 }
 
 func strToCards(s []string) []model.Card {
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+)
+
+// Account is how a player signs in. It's kept apart from the Player, which
+// is shared with everyone they play with.
+type Account struct {
+	// The player that this account signs in as
+	PlayerID PlayerID `protobuf:"-" json:"pID" bson:"pID"` //nolint:lll
+
+	// The hash of the player's password. It's never the password itself.
+	PasswordHash []byte `protobuf:"-" json:"ph" bson:"ph"` //nolint:lll
+
+	// When the player last signed out. Any session that started
+	// before then has ended.
+	SignedOutAt time.Time `protobuf:"-" json:"so" bson:"so"` //nolint:lll
+}
@@ -28,6 +28,29 @@ func NewCardFromString(card string) Card {
 	return NewCard(suit, value)
 }
 
+// NewCardFromExternalString parses a card that came from outside of the
+// game, such as user input, and errors if it isn't a real card
+func NewCardFromExternalString(card string) (Card, error) {
+	if len(card) < 2 || len(card) > 3 || (len(card) == 3 && card[:2] != `10`) {
+		return InvalidCard, errors.New(`bad input card: ` + card)
+	}
+
+	value, err := getCardValue(card)
+	if err != nil {
+		return InvalidCard, err
+	}
+	if value < 1 || value > 13 {
+		return InvalidCard, errors.New(`bad input card: ` + card)
+	}
+
+	suit, err := getSuit(card)
+	if err != nil {
+		return InvalidCard, err
+	}
+
+	return NewCard(suit, value), nil
+}
+
 func getCardValue(card string) (int, error) {
 	switch string(card[0]) {
 	case `A`, `a`:
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"math/bits"
+)
+
+// CardSet is a set of cards from one deck, packed into the bits of a uint64. The bit
+// for each card is the number that NewCardFromNumber turns into that card.
+type CardSet uint64
+
+const (
+	// EmptyCardSet has no cards in it
+	EmptyCardSet CardSet = 0
+	// FullDeck has every card in the deck
+	FullDeck CardSet = 1<<NumCardsPerDeck - 1
+
+	cardsPerSuit = 13
+	suitMask     = 1<<cardsPerSuit - 1
+)
+
+// NewCardSet returns the set with each of the cards. Invalid cards are left out.
+func NewCardSet(cards ...Card) CardSet {
+	cs := EmptyCardSet
+	for _, c := range cards {
+		cs = cs.Add(c)
+	}
+	return cs
+}
+
+// cardBit returns the bit for the card, or 0 for an invalid card
+func cardBit(c Card) CardSet {
+	n := c.ToTinyInt()
+	if n < 0 || int(n) >= NumCardsPerDeck {
+		return EmptyCardSet
+	}
+	return 1 << uint(n)
+}
+
+// Add returns the set with the card in it
+func (cs CardSet) Add(c Card) CardSet {
+	return cs | cardBit(c)
+}
+
+// Remove returns the set without the card in it
+func (cs CardSet) Remove(c Card) CardSet {
+	return cs &^ cardBit(c)
+}
+
+// Contains returns true if the card is in the set
+func (cs CardSet) Contains(c Card) bool {
+	b := cardBit(c)
+	return b != 0 && cs&b == b
+}
+
+// Without returns the cards in this set that aren't in the other one
+func (cs CardSet) Without(other CardSet) CardSet {
+	return cs &^ other
+}
+
+// Len returns how many cards are in the set
+func (cs CardSet) Len() int {
+	return bits.OnesCount64(uint64(cs))
+}
+
+// Suit returns the cards in the set of the given suit
+func (cs CardSet) Suit(s Suit) CardSet {
+	return cs & (suitMask << (uint(s) * cardsPerSuit))
+}
+
+// Next returns the lowest numbered card in the set, and the set without it. Calling
+// it until the set is empty goes through every card without allocating. An empty
+// set returns an invalid card.
+func (cs CardSet) Next() (Card, CardSet) {
+	if cs == EmptyCardSet {
+		return InvalidCard, cs
+	}
+	n := bits.TrailingZeros64(uint64(cs))
+	return NewCardFromNumber(n), cs & (cs - 1)
+}
+
+// Cards returns each of the cards in the set, ordered by their number
+func (cs CardSet) Cards() []Card {
+	cards := make([]Card, 0, cs.Len())
+	for s := cs; s != EmptyCardSet; {
+		var c Card
+		c, s = s.Next()
+		cards = append(cards, c)
+	}
+	return cards
+}
+
+// Combinations calls fn with every set of k cards from this set
+func (cs CardSet) Combinations(k int, fn func(CardSet)) {
+	if k < 0 {
+		return
+	}
+	combinations(cs, EmptyCardSet, k, fn)
+}
+
+func combinations(remaining, chosen CardSet, k int, fn func(CardSet)) {
+	if k == 0 {
+		fn(chosen)
+		return
+	}
+	for remaining.Len() >= k {
+		lowest := remaining & -remaining
+		remaining &^= lowest
+		combinations(remaining, chosen|lowest, k-1, fn)
+	}
+}
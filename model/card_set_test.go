@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardSet(t *testing.T) {
+	cs := NewCardSet(
+		NewCardFromString(`ah`),
+		NewCardFromString(`5c`),
+		NewCardFromString(`ks`),
+		InvalidCard,
+	)
+	assert.Equal(t, 3, cs.Len())
+	assert.True(t, cs.Contains(NewCardFromString(`5c`)))
+	assert.False(t, cs.Contains(NewCardFromString(`5d`)))
+	assert.False(t, cs.Contains(InvalidCard))
+
+	assert.Equal(t, []Card{
+		NewCardFromString(`ks`),
+		NewCardFromString(`5c`),
+		NewCardFromString(`ah`),
+	}, cs.Cards())
+
+	cs = cs.Add(NewCardFromString(`5c`))
+	assert.Equal(t, 3, cs.Len())
+	cs = cs.Remove(NewCardFromString(`5c`)).Remove(NewCardFromString(`5d`))
+	assert.Equal(t, NewCardSet(NewCardFromString(`ks`), NewCardFromString(`ah`)), cs)
+
+	assert.Equal(t, NumCardsPerDeck, FullDeck.Len())
+	assert.Equal(t, NumCardsPerDeck-2, FullDeck.Without(cs).Len())
+	assert.Equal(t, []Card{NewCardFromString(`ah`)}, cs.Suit(Hearts).Cards())
+	for s := Spades; s <= Hearts; s++ {
+		assert.Equal(t, 13, FullDeck.Suit(s).Len())
+	}
+
+	c, rest := EmptyCardSet.Next()
+	assert.Equal(t, InvalidCard, c)
+	assert.Equal(t, EmptyCardSet, rest)
+	assert.Empty(t, EmptyCardSet.Cards())
+}
+
+func TestCardSetCombinations(t *testing.T) {
+	hand := NewCardSet(
+		NewCardFromString(`ah`),
+		NewCardFromString(`2h`),
+		NewCardFromString(`3h`),
+		NewCardFromString(`4h`),
+		NewCardFromString(`5h`),
+		NewCardFromString(`6h`),
+	)
+
+	for k, exp := range map[int]int{0: 1, 1: 6, 2: 15, 3: 20, 4: 15, 6: 1, 7: 0} {
+		seen := map[CardSet]struct{}{}
+		hand.Combinations(k, func(cs CardSet) {
+			assert.Equal(t, k, cs.Len())
+			assert.Equal(t, cs, cs&hand)
+			seen[cs] = struct{}{}
+		})
+		assert.Len(t, seen, exp, `k = %d`, k)
+	}
+
+	n := 0
+	FullDeck.Combinations(2, func(CardSet) { n++ })
+	assert.Equal(t, 1326, n)
+}
@@ -102,6 +102,54 @@ func TestNewCardFromStringWithWeirdInput(t *testing.T) {
 	}
 }
 
+func TestNewCardFromExternalString(t *testing.T) {
+	testCases := []struct {
+		input   string
+		expCard Card
+		expErr  bool
+	}{{
+		input:   `ah`,
+		expCard: NewCard(Hearts, 1),
+	}, {
+		input:   `10S`,
+		expCard: NewCard(Spades, 10),
+	}, {
+		input:   `Kd`,
+		expCard: NewCard(Diamonds, 13),
+	}, {
+		input:  ``,
+		expErr: true,
+	}, {
+		input:  `h`,
+		expErr: true,
+	}, {
+		input:  `0h`,
+		expErr: true,
+	}, {
+		input:  `11h`,
+		expErr: true,
+	}, {
+		input:  `5x`,
+		expErr: true,
+	}, {
+		input:  `10hh`,
+		expErr: true,
+	}, {
+		input:  `A♡`,
+		expErr: true,
+	}}
+
+	for _, tc := range testCases {
+		c, err := NewCardFromExternalString(tc.input)
+		if tc.expErr {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expCard, c, tc.input)
+	}
+}
+
 func TestPegValue(t *testing.T) {
 	testCases := []struct {
 		desc     string
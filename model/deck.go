@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	mathrand "math/rand"
 
 	"github.com/joshprzybyszewski/cribbage/utils/rand"
 )
@@ -15,13 +16,23 @@ type Deck interface {
 type deck struct {
 	cards    [52]Card
 	numDealt int
+
+	// rng is the source of randomness for this deck. When it's nil, the
+	// deck is shuffled with crypto/rand.
+	rng *mathrand.Rand
 }
 
 func NewDeck() Deck {
-	return newDeck()
+	return newDeck(nil)
+}
+
+// NewDeckWithSource returns a deck that is shuffled using the provided
+// source. Two decks with identically seeded sources deal the same cards.
+func NewDeckWithSource(src mathrand.Source) Deck {
+	return newDeck(src)
 }
 
-func newDeck() *deck {
+func newDeck(src mathrand.Source) *deck {
 	var cards [52]Card
 
 	for i := 0; i < NumCardsPerDeck; i++ {
@@ -32,9 +43,12 @@ func newDeck() *deck {
 		cards:    cards,
 		numDealt: 0,
 	}
+	if src != nil {
+		d.rng = mathrand.New(src)
+	}
 
 	// Start the deck off in a random state by shuffling it a few times
-	n := rand.Intn(10)
+	n := d.intn(10)
 	for i := 0; i < n; i++ {
 		d.Shuffle()
 	}
@@ -42,8 +56,8 @@ func newDeck() *deck {
 	return &d
 }
 
-func newDeckWithDealt(dealt map[Card]struct{}) Deck {
-	d := newDeck()
+func newDeckWithDealt(src mathrand.Source, dealt map[Card]struct{}) Deck {
+	d := newDeck(src)
 	if len(dealt) == 0 {
 		return d
 	}
@@ -69,7 +83,7 @@ func newDeckWithDealt(dealt map[Card]struct{}) Deck {
 func (d *deck) Deal() Card {
 	lastValidCard := 51 - d.numDealt
 	if lastValidCard > 0 {
-		randomIndex := rand.Intn(lastValidCard)
+		randomIndex := d.intn(lastValidCard)
 		tmp := d.cards[lastValidCard]
 		d.cards[lastValidCard] = d.cards[randomIndex]
 		d.cards[randomIndex] = tmp
@@ -84,6 +98,13 @@ func (d *deck) Deal() Card {
 	return d.cards[lastValidCard]
 }
 
+func (d *deck) intn(max int) int {
+	if d.rng == nil {
+		return rand.Intn(max)
+	}
+	return d.rng.Intn(max)
+}
+
 func (d *deck) Shuffle() {
 	d.numDealt = 0
 
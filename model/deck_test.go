@@ -1,6 +1,7 @@
 package model
 
 import (
+	mathrand "math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,29 @@ func TestDeckShuffling(t *testing.T) {
 	}
 }
 
+func TestDeckWithSource(t *testing.T) {
+	d1 := NewDeckWithSource(mathrand.NewSource(42))
+	d2 := NewDeckWithSource(mathrand.NewSource(42))
+	d3 := NewDeckWithSource(mathrand.NewSource(43))
+
+	var hand1, hand2, hand3 []Card
+	for i := 0; i < 6; i++ {
+		hand1 = append(hand1, d1.Deal())
+		hand2 = append(hand2, d2.Deal())
+		hand3 = append(hand3, d3.Deal())
+	}
+	assert.Equal(t, hand1, hand2)
+	assert.NotEqual(t, hand1, hand3)
+
+	d1.Shuffle()
+	d2.Shuffle()
+	c1, err := d1.CutDeck(0.5)
+	require.NoError(t, err)
+	c2, err := d2.CutDeck(0.5)
+	require.NoError(t, err)
+	assert.Equal(t, c1, c2)
+}
+
 func TestDeckDealing(t *testing.T) {
 	d := NewDeck()
 	fakeCard := Card{}
@@ -75,7 +99,7 @@ func Test_newDeckWithDealt(t *testing.T) {
 			Value: 2,
 		}: {},
 	}
-	dealtDeck := newDeckWithDealt(already)
+	dealtDeck := newDeckWithDealt(nil, already)
 
 	d, ok := dealtDeck.(*deck)
 	require.True(t, ok)
@@ -89,7 +113,7 @@ func Test_newDeckWithDealt(t *testing.T) {
 		Suit:  Hearts,
 		Value: 6,
 	}] = struct{}{}
-	dealtDeck = newDeckWithDealt(already)
+	dealtDeck = newDeckWithDealt(nil, already)
 
 	d, ok = dealtDeck.(*deck)
 	require.True(t, ok)
@@ -106,7 +130,7 @@ func Test_newDeckWithDealt(t *testing.T) {
 		Suit:  Clubs,
 		Value: 5,
 	}] = struct{}{}
-	dealtDeck = newDeckWithDealt(already)
+	dealtDeck = newDeckWithDealt(nil, already)
 
 	d, ok = dealtDeck.(*deck)
 	require.True(t, ok)
@@ -0,0 +1,218 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+type GameEventType int
+
+const (
+	Scored           GameEventType = 0
+	CardDealt        GameEventType = 1
+	CutRevealed      GameEventType = 2
+	CardPegged       GameEventType = 3
+	GoSaid           GameEventType = 4
+	PhaseChanged     GameEventType = 5
+	MugginsCalled    GameEventType = 6
+	unknownEventType GameEventType = -1
+)
+
+func (t GameEventType) String() string {
+	switch t {
+	case Scored:
+		return `Scored`
+	case CardDealt:
+		return `CardDealt`
+	case CutRevealed:
+		return `CutRevealed`
+	case CardPegged:
+		return `CardPegged`
+	case GoSaid:
+		return `GoSaid`
+	case PhaseChanged:
+		return `PhaseChanged`
+	case MugginsCalled:
+		return `MugginsCalled`
+	}
+	return `InvalidEventType`
+}
+
+type ScoreReason int
+
+const (
+	PegScore       ScoreReason = 0
+	GoScore        ScoreReason = 1
+	LastCardScore  ScoreReason = 2
+	NibsScore      ScoreReason = 3
+	HandScore      ScoreReason = 4
+	CribScore      ScoreReason = 5
+	MugginsScore   ScoreReason = 6
+	PoneBonusScore ScoreReason = 7
+)
+
+func (r ScoreReason) String() string {
+	switch r {
+	case PegScore:
+		return `pegging`
+	case GoScore:
+		return `the go`
+	case LastCardScore:
+		return `last card`
+	case NibsScore:
+		return `his nibs`
+	case HandScore:
+		return `hand`
+	case CribScore:
+		return `crib`
+	case MugginsScore:
+		return `muggins`
+	case PoneBonusScore:
+		return `pone bonus`
+	}
+	return `unknown`
+}
+
+// GameEvent is something that happened in a game. The Event is one of the
+// *Event types below, depending on the Type.
+type GameEvent struct {
+	Type GameEventType `json:"t" bson:"t"`
+	// The number of actions that had been taken in the game when this happened
+	Action int         `json:"aIdx" bson:"aIdx"`
+	Event  interface{} `json:"e" bson:"e"`
+}
+
+// NewGameEvent wraps the event in a GameEvent, typed by which event it is
+func NewGameEvent(action int, e interface{}) GameEvent {
+	t := unknownEventType
+	switch e.(type) {
+	case ScoreEvent:
+		t = Scored
+	case CardDealtEvent:
+		t = CardDealt
+	case CutRevealedEvent:
+		t = CutRevealed
+	case CardPeggedEvent:
+		t = CardPegged
+	case GoSaidEvent:
+		t = GoSaid
+	case PhaseChangedEvent:
+		t = PhaseChanged
+	case MugginsCalledEvent:
+		t = MugginsCalled
+	}
+
+	return GameEvent{
+		Type:   t,
+		Action: action,
+		Event:  e,
+	}
+}
+
+// IsPublic returns true if everyone watching the game can know about the event.
+// The cards dealt to a player are only told to that player.
+func (ge GameEvent) IsPublic() bool {
+	return ge.Type != CardDealt
+}
+
+func (ge GameEvent) String() string {
+	if s, ok := ge.Event.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ge.Type.String()
+}
+
+// ScoreEvent is a player being awarded points
+type ScoreEvent struct {
+	PlayerID PlayerID    `json:"pID" bson:"pID"`
+	Reason   ScoreReason `json:"r" bson:"r"`
+	Points   int         `json:"pts" bson:"pts"`
+	// Score is the player's score after being awarded the points
+	Score int `json:"s" bson:"s"`
+
+	// The cards that were counted, and the cut card if it counted with them
+	Cards   []Card `json:"cs,omitempty" bson:"cs"`
+	CutCard *Card  `json:"cc,omitempty" bson:"cc"`
+
+	// Breakdown itemizes the points for a pegged card, or for a hand or crib
+	// that was counted correctly
+	Breakdown []ScoreItem `json:"bd,omitempty" bson:"bd"`
+}
+
+func (se ScoreEvent) String() string {
+	s := fmt.Sprintf("%s scored %d for %s", se.PlayerID, se.Points, se.Reason)
+	if len(se.Cards) == 0 {
+		return s
+	}
+	cards := make([]string, len(se.Cards))
+	for i, c := range se.Cards {
+		cards[i] = c.String()
+	}
+	if se.CutCard != nil {
+		return fmt.Sprintf("%s (%s: %s)", s, se.CutCard, strings.Join(cards, `, `))
+	}
+	return fmt.Sprintf("%s (%s)", s, strings.Join(cards, `, `))
+}
+
+// CardDealtEvent is a player receiving their hand
+type CardDealtEvent struct {
+	PlayerID PlayerID `json:"pID" bson:"pID"`
+	Cards    []Card   `json:"cs" bson:"cs"`
+}
+
+func (cde CardDealtEvent) String() string {
+	cards := make([]string, len(cde.Cards))
+	for i, c := range cde.Cards {
+		cards[i] = c.String()
+	}
+	return fmt.Sprintf("%s received hand %s", cde.PlayerID, strings.Join(cards, `, `))
+}
+
+type CutRevealedEvent struct {
+	Card Card `json:"c" bson:"c"`
+}
+
+func (cre CutRevealedEvent) String() string {
+	return `cut card ` + cre.Card.String()
+}
+
+type CardPeggedEvent struct {
+	PlayerID PlayerID `json:"pID" bson:"pID"`
+	Card     Card     `json:"c" bson:"c"`
+	// Peg is the running count after this card was pegged
+	Peg int `json:"peg" bson:"peg"`
+}
+
+func (cpe CardPeggedEvent) String() string {
+	return fmt.Sprintf("%s pegged %s for %d", cpe.PlayerID, cpe.Card, cpe.Peg)
+}
+
+type GoSaidEvent struct {
+	PlayerID PlayerID `json:"pID" bson:"pID"`
+}
+
+func (gse GoSaidEvent) String() string {
+	return fmt.Sprintf("%s said go", gse.PlayerID)
+}
+
+type PhaseChangedEvent struct {
+	Phase Phase `json:"p" bson:"p"`
+}
+
+func (pce PhaseChangedEvent) String() string {
+	return `phase changed to ` + pce.Phase.String()
+}
+
+// MugginsCalledEvent is an opponent claiming the points a player missed
+type MugginsCalledEvent struct {
+	PlayerID PlayerID `json:"pID" bson:"pID"`
+	Points   int      `json:"pts" bson:"pts"`
+	Correct  bool     `json:"ok" bson:"ok"`
+}
+
+func (mce MugginsCalledEvent) String() string {
+	if mce.Correct {
+		return fmt.Sprintf("%s called muggins for %d", mce.PlayerID, mce.Points)
+	}
+	return fmt.Sprintf("%s made an incorrect muggins claim of %d", mce.PlayerID, mce.Points)
+}
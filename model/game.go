@@ -2,8 +2,17 @@ package model
 
 import (
 	"errors"
+	"math"
+	mathrand "math/rand"
+
+	"github.com/joshprzybyszewski/cribbage/utils/rand"
 )
 
+// NewGameSeed returns a random, non-zero seed for a game's decks
+func NewGameSeed() int64 {
+	return rand.Int64n(math.MaxInt64) + 1
+}
+
 func (g *Game) GetDeck() (Deck, error) {
 	emptyCard := Card{}
 	if emptyCard != g.CutCard {
@@ -20,12 +29,26 @@ func (g *Game) GetDeck() (Deck, error) {
 		allDealtCards[c] = struct{}{}
 	}
 
-	return newDeckWithDealt(allDealtCards), nil
+	return newDeckWithDealt(g.deckSource(), allDealtCards), nil
+}
+
+// deckSource returns the source of randomness for the deck at this point in the
+// game. Seeded games get the same deck for the same seed and actions, which lets
+// any hand be recreated. Games without a seed are shuffled with crypto/rand.
+func (g *Game) deckSource() mathrand.Source {
+	if g.Seed == 0 {
+		return nil
+	}
+	return mathrand.NewSource(g.Seed + int64(g.NumActions()))
 }
 
 func (g *Game) IsOver() bool {
+	if g.Status != StatusActive {
+		// the game can end before anyone wins it, when a team gives up
+		return true
+	}
 	for _, score := range g.CurrentScores {
-		if score >= WinningScore {
+		if score >= g.Options.Rules.TargetScore() {
 			return true
 		}
 	}
@@ -40,6 +63,13 @@ func (g *Game) AddAction(a PlayerAction) {
 	g.Actions = append(g.Actions, a)
 }
 
+// AddEvent records that the event happened during the current action
+func (g *Game) AddEvent(e interface{}) GameEvent {
+	ge := NewGameEvent(g.NumActions(), e)
+	g.Events = append(g.Events, ge)
+	return ge
+}
+
 func (g *Game) CurrentPeg() int {
 	if len(g.PeggedCards) == 0 {
 		return 0
@@ -57,6 +57,39 @@ func TestIsOver(t *testing.T) {
 			},
 		},
 		expOver: true,
+	}, {
+		msg: `short games are over sooner`,
+		game: model.Game{
+			Options: model.GameOptions{
+				Rules: model.GameRules{WinningScore: 61},
+			},
+			CurrentScores: map[model.PlayerColor]int{
+				model.Blue: 61,
+				model.Red:  12,
+			},
+		},
+		expOver: true,
+	}, {
+		msg: `longer games need more points`,
+		game: model.Game{
+			Options: model.GameOptions{
+				Rules: model.GameRules{WinningScore: 181},
+			},
+			CurrentScores: map[model.PlayerColor]int{
+				model.Blue: 121,
+			},
+		},
+		expOver: false,
+	}, {
+		msg: `resigned games are over early`,
+		game: model.Game{
+			CurrentScores: map[model.PlayerColor]int{
+				model.Blue: 40,
+				model.Red:  12,
+			},
+			Status: model.StatusResigned,
+		},
+		expOver: true,
 	}}
 
 	for _, tc := range testCases {
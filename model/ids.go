@@ -26,6 +26,36 @@ func NewGameID() GameID {
 	return gID
 }
 
+func NewMatchID() MatchID {
+	mID := InvalidMatchID
+	for mID == InvalidMatchID {
+		r, err := uuid.NewRandom()
+		if err != nil {
+			log.Printf("NewMatchID.NewRandom failed\n")
+			return InvalidMatchID
+		}
+
+		mID = MatchID(r.ID())
+	}
+
+	return mID
+}
+
+func NewInvitationID() InvitationID {
+	iID := InvalidInvitationID
+	for iID == InvalidInvitationID {
+		r, err := uuid.NewRandom()
+		if err != nil {
+			log.Printf("NewInvitationID.NewRandom failed\n")
+			return InvalidInvitationID
+		}
+
+		iID = InvitationID(r.ID())
+	}
+
+	return iID
+}
+
 func IsValidPlayerID(pID PlayerID) bool {
 	return validPIDRegex.MatchString(string(pID))
 }
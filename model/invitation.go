@@ -0,0 +1,248 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// InvitationStatus is whether an invitation is still waiting on players, or what
+// became of it
+type InvitationStatus int
+
+const (
+	// InvitationPending invitations are still waiting for players to accept
+	InvitationPending InvitationStatus = 0
+	// InvitationAccepted invitations were accepted by everyone, and their game was created
+	InvitationAccepted InvitationStatus = 1
+	// InvitationDeclined invitations were turned down by one of the players
+	InvitationDeclined InvitationStatus = 2
+	// InvitationExpired invitations weren't accepted by everyone in time
+	InvitationExpired       InvitationStatus = 3
+	unknownInvitationStatus InvitationStatus = -1
+)
+
+var (
+	ErrUnknownInvitationStatus = errors.New(`unknown invitation status`)
+	ErrInvalidNumPlayers       = errors.New(`invalid number of players`)
+	ErrDuplicateInvitee        = errors.New(`players can only be invited once`)
+	ErrInvitationNotPending    = errors.New(`invitation is no longer pending`)
+	ErrInvitationExpired       = errors.New(`invitation has expired`)
+	ErrNotInvited              = errors.New(`player was not invited`)
+	ErrInvitationFull          = errors.New(`invitation has no open seats`)
+)
+
+func (s InvitationStatus) String() string {
+	switch s {
+	case InvitationPending:
+		return `pending`
+	case InvitationAccepted:
+		return `accepted`
+	case InvitationDeclined:
+		return `declined`
+	case InvitationExpired:
+		return `expired`
+	}
+	return `unknown`
+}
+
+func NewInvitationStatusFromString(s string) (InvitationStatus, error) {
+	switch s {
+	case `pending`:
+		return InvitationPending, nil
+	case `accepted`:
+		return InvitationAccepted, nil
+	case `declined`:
+		return InvitationDeclined, nil
+	case `expired`:
+		return InvitationExpired, nil
+	}
+	return unknownInvitationStatus, ErrUnknownInvitationStatus
+}
+
+// Invitation asks players to sit down for a game. The game isn't created until
+// every seat is taken by a player who has accepted. Open invitations are posted
+// in the lobby, where any player can take one of the empty seats.
+type Invitation struct {
+	// The unique identifier used to reference this invitation
+	ID InvitationID `protobuf:"-" json:"id" bson:"id"` //nolint:lll
+
+	// The player who sent the invitation
+	Host PlayerID `protobuf:"-" json:"h" bson:"h"` //nolint:lll
+
+	// The players with a seat at the game, in the order they'll sit, starting
+	// with the host. Open invitations only have the players who've joined so far.
+	PlayerIDs []PlayerID `protobuf:"-" json:"pIDs" bson:"pIDs"` //nolint:lll
+
+	// The players who have accepted. The host accepts by sending the invitation.
+	Accepted []PlayerID `protobuf:"-" json:"a" bson:"a"` //nolint:lll
+
+	// How many players the game is for
+	NumPlayers int `protobuf:"-" json:"np" bson:"np"` //nolint:lll
+
+	// Open invitations let anyone join them from the lobby
+	Open bool `protobuf:"-" json:"o,omitempty" bson:"o"` //nolint:lll
+
+	// The house rules the game will be played with
+	Options GameOptions `protobuf:"-" json:"opts" bson:"opts"` //nolint:lll
+
+	Status InvitationStatus `protobuf:"-" json:"s" bson:"s"` //nolint:lll
+
+	// When the invitation expires if it hasn't been accepted by everyone
+	Expires time.Time `protobuf:"-" json:"exp" bson:"exp"` //nolint:lll
+
+	// The game that was created once everyone accepted
+	GameID GameID `protobuf:"-" json:"gID,omitempty" bson:"gID"` //nolint:lll
+}
+
+// NewInvitation returns an invitation from the host to play with the invitees.
+// The players will sit in the order they were invited.
+func NewInvitation(
+	host PlayerID,
+	invitees []PlayerID,
+	opts GameOptions,
+	expires time.Time,
+) (Invitation, error) {
+
+	pIDs := append([]PlayerID{host}, invitees...)
+	if len(pIDs) < MinPlayerGame || len(pIDs) > MaxPlayerGame {
+		return Invitation{}, ErrInvalidNumPlayers
+	}
+	seen := make(map[PlayerID]struct{}, len(pIDs))
+	for _, pID := range pIDs {
+		if _, ok := seen[pID]; ok {
+			return Invitation{}, ErrDuplicateInvitee
+		}
+		seen[pID] = struct{}{}
+	}
+
+	return Invitation{
+		ID:         NewInvitationID(),
+		Host:       host,
+		PlayerIDs:  pIDs,
+		Accepted:   []PlayerID{host},
+		NumPlayers: len(pIDs),
+		Options:    opts,
+		Status:     InvitationPending,
+		Expires:    expires,
+	}, nil
+}
+
+// NewOpenInvitation returns an invitation from the host that anyone can join until
+// there are numPlayers seated
+func NewOpenInvitation(
+	host PlayerID,
+	numPlayers int,
+	opts GameOptions,
+	expires time.Time,
+) (Invitation, error) {
+
+	if numPlayers < MinPlayerGame || numPlayers > MaxPlayerGame {
+		return Invitation{}, ErrInvalidNumPlayers
+	}
+
+	return Invitation{
+		ID:         NewInvitationID(),
+		Host:       host,
+		PlayerIDs:  []PlayerID{host},
+		Accepted:   []PlayerID{host},
+		NumPlayers: numPlayers,
+		Open:       true,
+		Options:    opts,
+		Status:     InvitationPending,
+		Expires:    expires,
+	}, nil
+}
+
+// Expire marks a pending invitation as expired if it wasn't accepted by now. It
+// returns true if the invitation just expired.
+func (inv *Invitation) Expire(now time.Time) bool {
+	if inv.Status != InvitationPending || now.Before(inv.Expires) {
+		return false
+	}
+	inv.Status = InvitationExpired
+	return true
+}
+
+// Accept has the player accept the invitation. Anyone can accept an open
+// invitation, as long as there's an empty seat.
+func (inv *Invitation) Accept(pID PlayerID, now time.Time) error {
+	if err := inv.checkPending(now); err != nil {
+		return err
+	}
+
+	if !inv.HasPlayer(pID) {
+		if !inv.Open {
+			return ErrNotInvited
+		}
+		if len(inv.PlayerIDs) >= inv.NumPlayers {
+			return ErrInvitationFull
+		}
+		inv.PlayerIDs = append(inv.PlayerIDs, pID)
+	}
+
+	if !containsPlayer(inv.Accepted, pID) {
+		inv.Accepted = append(inv.Accepted, pID)
+	}
+	return nil
+}
+
+// Decline turns down the invitation for everyone. On an open invitation, any
+// player but the host gives up their seat for someone else instead.
+func (inv *Invitation) Decline(pID PlayerID, now time.Time) error {
+	if err := inv.checkPending(now); err != nil {
+		return err
+	}
+	if !inv.HasPlayer(pID) {
+		return ErrNotInvited
+	}
+
+	if inv.Open && pID != inv.Host {
+		inv.PlayerIDs = removePlayer(inv.PlayerIDs, pID)
+		inv.Accepted = removePlayer(inv.Accepted, pID)
+		return nil
+	}
+
+	inv.Status = InvitationDeclined
+	return nil
+}
+
+// IsReady returns true once every seat is taken by a player who has accepted
+func (inv *Invitation) IsReady() bool {
+	return inv.Status == InvitationPending &&
+		len(inv.PlayerIDs) == inv.NumPlayers &&
+		len(inv.Accepted) == inv.NumPlayers
+}
+
+// HasPlayer returns true if the player has a seat at the game
+func (inv *Invitation) HasPlayer(pID PlayerID) bool {
+	return containsPlayer(inv.PlayerIDs, pID)
+}
+
+func (inv *Invitation) checkPending(now time.Time) error {
+	if inv.Expire(now) || inv.Status == InvitationExpired {
+		return ErrInvitationExpired
+	}
+	if inv.Status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+	return nil
+}
+
+func containsPlayer(pIDs []PlayerID, pID PlayerID) bool {
+	for _, id := range pIDs {
+		if id == pID {
+			return true
+		}
+	}
+	return false
+}
+
+func removePlayer(pIDs []PlayerID, pID PlayerID) []PlayerID {
+	kept := make([]PlayerID, 0, len(pIDs))
+	for _, id := range pIDs {
+		if id != pID {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,122 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestInvitationStatusStringConversions(t *testing.T) {
+	for _, s := range []model.InvitationStatus{
+		model.InvitationPending,
+		model.InvitationAccepted,
+		model.InvitationDeclined,
+		model.InvitationExpired,
+	} {
+		act, err := model.NewInvitationStatusFromString(s.String())
+		require.NoError(t, err)
+		assert.Equal(t, s, act)
+	}
+
+	_, err := model.NewInvitationStatusFromString(`other`)
+	assert.Equal(t, model.ErrUnknownInvitationStatus, err)
+	assert.Equal(t, `unknown`, model.InvitationStatus(4).String())
+}
+
+func TestNewInvitation(t *testing.T) {
+	now := time.Now()
+
+	_, err := model.NewInvitation(`alice`, nil, model.GameOptions{}, now)
+	assert.Equal(t, model.ErrInvalidNumPlayers, err)
+	_, err = model.NewInvitation(`alice`, []model.PlayerID{`a`, `b`, `c`, `d`, `e`, `f`}, model.GameOptions{}, now)
+	assert.Equal(t, model.ErrInvalidNumPlayers, err)
+	_, err = model.NewInvitation(`alice`, []model.PlayerID{`bob`, `alice`}, model.GameOptions{}, now)
+	assert.Equal(t, model.ErrDuplicateInvitee, err)
+	_, err = model.NewOpenInvitation(`alice`, 7, model.GameOptions{}, now)
+	assert.Equal(t, model.ErrInvalidNumPlayers, err)
+
+	inv, err := model.NewInvitation(`alice`, []model.PlayerID{`bob`, `charlie`}, model.GameOptions{}, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, model.InvalidInvitationID, inv.ID)
+	assert.Equal(t, []model.PlayerID{`alice`, `bob`, `charlie`}, inv.PlayerIDs)
+	assert.Equal(t, []model.PlayerID{`alice`}, inv.Accepted)
+	assert.Equal(t, 3, inv.NumPlayers)
+	assert.Equal(t, model.InvitationPending, inv.Status)
+	assert.False(t, inv.Open)
+}
+
+func TestInvitationAccept(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+
+	inv, err := model.NewInvitation(`alice`, []model.PlayerID{`bob`}, model.GameOptions{}, expires)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.ErrNotInvited, inv.Accept(`charlie`, now))
+	assert.False(t, inv.IsReady())
+	require.NoError(t, inv.Accept(`bob`, now))
+	require.NoError(t, inv.Accept(`bob`, now))
+	assert.Equal(t, []model.PlayerID{`alice`, `bob`}, inv.Accepted)
+	assert.True(t, inv.IsReady())
+
+	inv, err = model.NewOpenInvitation(`alice`, 3, model.GameOptions{}, expires)
+	require.NoError(t, err)
+	require.NoError(t, inv.Accept(`bob`, now))
+	assert.False(t, inv.IsReady())
+	require.NoError(t, inv.Accept(`charlie`, now))
+	assert.Equal(t, model.ErrInvitationFull, inv.Accept(`diane`, now))
+	assert.Equal(t, []model.PlayerID{`alice`, `bob`, `charlie`}, inv.PlayerIDs)
+	assert.True(t, inv.IsReady())
+
+	inv, err = model.NewInvitation(`alice`, []model.PlayerID{`bob`}, model.GameOptions{}, expires)
+	require.NoError(t, err)
+	assert.Equal(t, model.ErrInvitationExpired, inv.Accept(`bob`, expires))
+	assert.Equal(t, model.InvitationExpired, inv.Status)
+	assert.Equal(t, model.ErrInvitationExpired, inv.Accept(`bob`, now))
+	assert.False(t, inv.IsReady())
+}
+
+func TestInvitationDecline(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+
+	inv, err := model.NewInvitation(`alice`, []model.PlayerID{`bob`}, model.GameOptions{}, expires)
+	require.NoError(t, err)
+	assert.Equal(t, model.ErrNotInvited, inv.Decline(`charlie`, now))
+	require.NoError(t, inv.Decline(`bob`, now))
+	assert.Equal(t, model.InvitationDeclined, inv.Status)
+	assert.Equal(t, model.ErrInvitationNotPending, inv.Accept(`bob`, now))
+
+	// leaving an open invitation frees up the seat
+	inv, err = model.NewOpenInvitation(`alice`, 2, model.GameOptions{}, expires)
+	require.NoError(t, err)
+	require.NoError(t, inv.Accept(`bob`, now))
+	require.NoError(t, inv.Decline(`bob`, now))
+	assert.Equal(t, model.InvitationPending, inv.Status)
+	assert.Equal(t, []model.PlayerID{`alice`}, inv.PlayerIDs)
+	assert.Equal(t, []model.PlayerID{`alice`}, inv.Accepted)
+	require.NoError(t, inv.Accept(`charlie`, now))
+	assert.True(t, inv.IsReady())
+
+	require.NoError(t, inv.Decline(`alice`, now))
+	assert.Equal(t, model.InvitationDeclined, inv.Status)
+}
+
+func TestInvitationExpire(t *testing.T) {
+	now := time.Now()
+
+	inv, err := model.NewInvitation(`alice`, []model.PlayerID{`bob`}, model.GameOptions{}, now)
+	require.NoError(t, err)
+	assert.False(t, inv.Expire(now.Add(-time.Second)))
+	assert.True(t, inv.Expire(now))
+	assert.Equal(t, model.InvitationExpired, inv.Status)
+	assert.False(t, inv.Expire(now))
+
+	inv.Status = model.InvitationAccepted
+	assert.False(t, inv.Expire(now.Add(time.Hour)))
+	assert.Equal(t, model.InvitationAccepted, inv.Status)
+}
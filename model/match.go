@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+)
+
+const (
+	// MaxMatchLength is the most games a match can be played over
+	MaxMatchLength int = 15
+)
+
+var (
+	ErrInvalidMatchLength = errors.New(`a match must be played over an odd number of games`)
+	ErrGameNotInMatch     = errors.New(`game is not part of this match`)
+)
+
+// Match is a best-of-N series of games between the same players
+type Match struct {
+	// The unique identifier used to reference this match
+	ID MatchID `protobuf:"-" json:"id" bson:"id"` //nolint:lll
+
+	// The players in this match, in the order they're seated for every game
+	PlayerIDs []PlayerID `protobuf:"-" json:"pIDs" bson:"pIDs"` //nolint:lll
+
+	// The house rules every game in the match is played with
+	Options GameOptions `protobuf:"-" json:"opts" bson:"opts"` //nolint:lll
+
+	// How many games the match is played over
+	BestOf int `protobuf:"-" json:"bo" bson:"bo"` //nolint:lll
+
+	// The games in this match, in the order they were played
+	GameIDs []GameID `protobuf:"-" json:"gIDs,omitempty" bson:"gIDs"` //nolint:lll
+}
+
+// MatchStandings are the tallied results of the finished games in a match
+type MatchStandings struct {
+	// How many games each player has won
+	Wins map[PlayerID]int
+	// How many game points each player has been awarded
+	GamePoints map[PlayerID]int
+	// The players who won the match. Empty until the match is decided.
+	Winners []PlayerID
+}
+
+// ValidateMatchLength returns an error if a match can't be played over bestOf games
+func ValidateMatchLength(bestOf int) error {
+	if bestOf < 1 || bestOf > MaxMatchLength || bestOf%2 == 0 {
+		return ErrInvalidMatchLength
+	}
+	return nil
+}
+
+// GamesToWin is the number of games a player needs to win the match
+func (m *Match) GamesToWin() int {
+	return m.BestOf/2 + 1
+}
+
+// Standings tallies the results of the provided games in this match. Games
+// that aren't over yet are ignored.
+func (m *Match) Standings(games []Game) (MatchStandings, error) {
+	ms := MatchStandings{
+		Wins:       make(map[PlayerID]int, len(m.PlayerIDs)),
+		GamePoints: make(map[PlayerID]int, len(m.PlayerIDs)),
+	}
+	for _, pID := range m.PlayerIDs {
+		ms.Wins[pID] = 0
+		ms.GamePoints[pID] = 0
+	}
+
+	for i := range games {
+		g := &games[i]
+		if !m.hasGame(g.ID) {
+			return MatchStandings{}, ErrGameNotInMatch
+		}
+		if g.Result == nil {
+			continue
+		}
+		for pID, color := range g.PlayerColors {
+			if color != g.Result.Winner {
+				continue
+			}
+			ms.Wins[pID]++
+			ms.GamePoints[pID] += g.Result.GamePoints
+		}
+	}
+
+	for _, pID := range m.PlayerIDs {
+		if ms.Wins[pID] >= m.GamesToWin() {
+			ms.Winners = append(ms.Winners, pID)
+		}
+	}
+
+	return ms, nil
+}
+
+func (m *Match) hasGame(gID GameID) bool {
+	for _, id := range m.GameIDs {
+		if id == gID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestValidateMatchLength(t *testing.T) {
+	for _, n := range []int{1, 3, 5, 15} {
+		assert.NoError(t, model.ValidateMatchLength(n), n)
+	}
+	for _, n := range []int{-1, 0, 2, 4, 17} {
+		assert.Equal(t, model.ErrInvalidMatchLength, model.ValidateMatchLength(n), n)
+	}
+}
+
+func TestMatchStandings(t *testing.T) {
+	alice := model.PlayerID(`alice`)
+	bob := model.PlayerID(`bob`)
+	colors := map[model.PlayerID]model.PlayerColor{
+		alice: model.Blue,
+		bob:   model.Red,
+	}
+
+	m := model.Match{
+		ID:        model.MatchID(3),
+		PlayerIDs: []model.PlayerID{alice, bob},
+		BestOf:    3,
+		GameIDs:   []model.GameID{1, 2, 3},
+	}
+	assert.Equal(t, 2, m.GamesToWin())
+
+	games := []model.Game{{
+		ID:           1,
+		PlayerColors: colors,
+		Result: &model.GameResult{
+			Winner:     model.Blue,
+			Skunk:      model.Skunked,
+			GamePoints: 2,
+		},
+	}, {
+		ID:           2,
+		PlayerColors: colors,
+		Result: &model.GameResult{
+			Winner:     model.Red,
+			GamePoints: 1,
+		},
+	}, {
+		ID:           3,
+		PlayerColors: colors,
+	}}
+
+	ms, err := m.Standings(games)
+	require.NoError(t, err)
+	assert.Equal(t, map[model.PlayerID]int{alice: 1, bob: 1}, ms.Wins)
+	assert.Equal(t, map[model.PlayerID]int{alice: 2, bob: 1}, ms.GamePoints)
+	assert.Empty(t, ms.Winners)
+
+	games[2].Result = &model.GameResult{
+		Winner:     model.Blue,
+		GamePoints: 1,
+	}
+	ms, err = m.Standings(games)
+	require.NoError(t, err)
+	assert.Equal(t, map[model.PlayerID]int{alice: 2, bob: 1}, ms.Wins)
+	assert.Equal(t, map[model.PlayerID]int{alice: 3, bob: 1}, ms.GamePoints)
+	assert.Equal(t, []model.PlayerID{alice}, ms.Winners)
+
+	_, err = m.Standings([]model.Game{{ID: 4}})
+	assert.Equal(t, model.ErrGameNotInMatch, err)
+}
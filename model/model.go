@@ -43,10 +43,14 @@ type PeggedCard struct {
 
 type PlayerID string
 type GameID uint32
+type MatchID uint32
+type InvitationID uint32
 
 const (
-	InvalidPlayerID PlayerID = ``
-	InvalidGameID   GameID   = 0
+	InvalidPlayerID     PlayerID     = ``
+	InvalidGameID       GameID       = 0
+	InvalidMatchID      MatchID      = 0
+	InvalidInvitationID InvitationID = 0
 )
 
 type PlayerColor int8
@@ -56,6 +60,8 @@ const (
 	Green              PlayerColor = 1
 	Blue               PlayerColor = 2
 	Red                PlayerColor = 3
+	Yellow             PlayerColor = 4
+	Purple             PlayerColor = 5
 	unknownPlayerColor PlayerColor = -1
 )
 
@@ -67,6 +73,10 @@ func (c PlayerColor) String() string {
 		return `red`
 	case Green:
 		return `green`
+	case Yellow:
+		return `yellow`
+	case Purple:
+		return `purple`
 	case UnsetColor:
 		return `unset`
 	}
@@ -81,6 +91,10 @@ func NewPlayerColorFromString(c string) PlayerColor {
 		return Red
 	case `green`:
 		return Green
+	case `yellow`:
+		return Yellow
+	case `purple`:
+		return Purple
 	case `unset`:
 		return UnsetColor
 	}
@@ -102,6 +116,10 @@ const (
 	PegCard        Blocker = 3
 	CountHand      Blocker = 4
 	CountCrib      Blocker = 5
+	CallMuggins    Blocker = 6
+	Undo           Blocker = 7
+	Forfeit        Blocker = 8
+	Resign         Blocker = 9
 	unknownBlocker Blocker = -1
 )
 
@@ -119,6 +137,14 @@ func (b Blocker) String() string {
 		return `CountHand`
 	case CountCrib:
 		return `CountCrib`
+	case CallMuggins:
+		return `CallMuggins`
+	case Undo:
+		return `Undo`
+	case Forfeit:
+		return `Forfeit`
+	case Resign:
+		return `Resign`
 	}
 	return `InvalidBlocker`
 }
@@ -137,6 +163,14 @@ func NewBlockerFromString(b string) Blocker {
 		return CountHand
 	case `CountCrib`:
 		return CountCrib
+	case `CallMuggins`:
+		return CallMuggins
+	case `Undo`:
+		return Undo
+	case `Forfeit`:
+		return Forfeit
+	case `Resign`:
+		return Resign
 	}
 	return unknownBlocker
 }
@@ -180,6 +214,29 @@ type CountCribAction struct {
 	Pts int `json:"pts" bson:"pts"`
 }
 
+// CallMugginsAction is an opponent's claim on the points a player missed
+// when counting. Claiming zero points passes on calling muggins.
+type CallMugginsAction struct {
+	Pts int `json:"pts" bson:"pts"`
+}
+
+// UndoAction asks to take back the most recent action when no undo is pending.
+// Otherwise, it's another player's answer to that ask. The players who need to
+// answer are blocked by Undo.
+type UndoAction struct {
+	Approve bool `json:"a,omitempty" bson:"a"`
+}
+
+// ForfeitAction ends the game with the player's team losing. Nobody is ever
+// blocked by Forfeit; it's what a player who runs out of time overcomes.
+type ForfeitAction struct {
+	TimedOut bool `json:"to,omitempty" bson:"to"`
+}
+
+// ResignAction gives up the game, and the player's team loses it. A player can
+// resign at any time, so nobody is ever blocked by Resign either.
+type ResignAction struct{}
+
 type Phase int
 
 const (
@@ -265,11 +322,25 @@ const (
 
 const (
 	MinPlayerGame int = 2
-	MaxPlayerGame int = 4
+	MaxPlayerGame int = 6
 )
 
+// GameOptions are the house rules chosen when the game is created
+type GameOptions struct {
+	// Muggins lets opponents claim any points a player fails to count
+	Muggins bool `protobuf:"-" json:"m,omitempty" bson:"m"` //nolint:lll
+
+	// Rules are the variant of cribbage this game is played with
+	Rules GameRules `protobuf:"-" json:"r" bson:"r"` //nolint:lll
+
+	// TurnTimeLimit is how long each player has to act, and TimeoutPolicy is what
+	// happens when they don't. A zero limit means players can take as long as they like.
+	TurnTimeLimit time.Duration `protobuf:"-" json:"ttl,omitempty" bson:"ttl"` //nolint:lll
+	TimeoutPolicy TimeoutPolicy `protobuf:"-" json:"tp,omitempty" bson:"tp"`   //nolint:lll
+}
+
 // Game represents all of the data needed for a game of cribbage
-// between 2, 3, or 4 players
+// between 2 and 6 players
 type Game struct {
 	// The unique identifier used to reference this game
 	ID GameID `protobuf:"-" json:"id" bson:"id"` //nolint:lll
@@ -278,6 +349,13 @@ type Game struct {
 	Players      []Player                 `protobuf:"-" json:"ps" bson:"ps"`             //nolint:lll
 	PlayerColors map[PlayerID]PlayerColor `protobuf:"-" json:"pcs,omitempty" bson:"pcs"` //nolint:lll
 
+	// The house rules this game is played with
+	Options GameOptions `protobuf:"-" json:"opts" bson:"opts"` //nolint:lll
+
+	// The seed that every deck in this game is shuffled from. It should not be
+	// shown to players until the game is over.
+	Seed int64 `protobuf:"-" json:"seed,omitempty" bson:"seed"` //nolint:lll
+
 	// The current (and lagging) scores
 	CurrentScores map[PlayerColor]int `protobuf:"-" json:"cs" bson:"cs"` //nolint:lll
 	LagScores     map[PlayerColor]int `protobuf:"-" json:"ls" bson:"ls"` //nolint:lll
@@ -286,6 +364,8 @@ type Game struct {
 	Phase Phase `protobuf:"-" json:"p" bson:"p"` //nolint:lll
 	// Who is blocking and why
 	BlockingPlayers map[PlayerID]Blocker `protobuf:"-" json:"bps,omitempty" bson:"bps"` //nolint:lll
+	// When each blocking player needs to act by, if the game has a turn time limit
+	Deadlines map[PlayerID]time.Time `protobuf:"-" json:"dls,omitempty" bson:"dls"` //nolint:lll
 
 	// The identifier for the current dealer
 	CurrentDealer PlayerID `protobuf:"-" json:"cd" bson:"cd"` //nolint:lll
@@ -303,4 +383,13 @@ type Game struct {
 
 	// An ordered list of player actions
 	Actions []PlayerAction `protobuf:"-" json:"as" bson:"as"` //nolint:lll
+
+	// An ordered list of what has happened in the game, most recent last
+	Events []GameEvent `protobuf:"-" json:"evs,omitempty" bson:"evs"` //nolint:lll
+
+	// Whether the game is still being played, or how it ended
+	Status GameStatus `protobuf:"-" json:"st,omitempty" bson:"st"` //nolint:lll
+
+	// The outcome of the game, once it's over
+	Result *GameResult `protobuf:"-" json:"res,omitempty" bson:"res"` //nolint:lll
 }
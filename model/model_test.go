@@ -12,12 +12,14 @@ func TestPlayerColorStringConversions(t *testing.T) {
 		Green,
 		Blue,
 		Red,
+		Yellow,
+		Purple,
 	} {
 		assert.Equal(t, pc, NewPlayerColorFromString(pc.String()))
 	}
 
 	assert.Equal(t, `notacolor`, unknownPlayerColor.String())
-	assert.Equal(t, `notacolor`, (PlayerColor)(4).String())
+	assert.Equal(t, `notacolor`, (PlayerColor)(6).String())
 	assert.Equal(t, unknownPlayerColor, NewPlayerColorFromString(`other`))
 }
 
@@ -29,12 +31,16 @@ func TestBlockerStringConversions(t *testing.T) {
 		PegCard,
 		CountHand,
 		CountCrib,
+		CallMuggins,
+		Undo,
+		Forfeit,
+		Resign,
 	} {
 		assert.Equal(t, b, NewBlockerFromString(b.String()))
 	}
 
 	assert.Equal(t, `InvalidBlocker`, unknownBlocker.String())
-	assert.Equal(t, `InvalidBlocker`, (Blocker)(6).String())
+	assert.Equal(t, `InvalidBlocker`, (Blocker)(10).String())
 	assert.Equal(t, unknownBlocker, NewBlockerFromString(`other`))
 }
 
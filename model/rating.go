@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+)
+
+// RatingPool is the kind of games a rating was earned in. Players are rated
+// separately in each pool, since being good at heads up standard cribbage says
+// little about how good someone is at four player seven-card.
+type RatingPool struct {
+	// How many players were in the games
+	NumPlayers int `protobuf:"-" json:"np" bson:"np"` //nolint:lll
+
+	// The variant the games' rules started from
+	Variant string `protobuf:"-" json:"v" bson:"v"` //nolint:lll
+}
+
+// NewRatingPool returns the pool that the game is rated in. It returns false for
+// games played with any of their variant's rules changed, which aren't rated,
+// since they'd be mixed in with the games of the variant they started from.
+func NewRatingPool(g Game) (RatingPool, bool) {
+	r := g.Options.Rules
+	if !r.IsVariant() {
+		return RatingPool{}, false
+	}
+	return RatingPool{
+		NumPlayers: len(g.Players),
+		Variant:    r.withDefaults().Variant,
+	}, true
+}
+
+// Rating is a player's Glicko-2 rating in one pool. The rating is on the Glicko
+// scale, where new players start at 1500.
+type Rating struct {
+	PlayerID PlayerID   `protobuf:"-" json:"pID" bson:"pID"` //nolint:lll
+	Pool     RatingPool `protobuf:"-" json:"p" bson:"p"`     //nolint:lll
+
+	// The player's rating, how uncertain it is, and how erratically they play
+	Rating     float64 `protobuf:"-" json:"r" bson:"r"`     //nolint:lll
+	Deviation  float64 `protobuf:"-" json:"rd" bson:"rd"`   //nolint:lll
+	Volatility float64 `protobuf:"-" json:"vol" bson:"vol"` //nolint:lll
+
+	// How many rated games the player has finished in the pool
+	Games int `protobuf:"-" json:"g" bson:"g"` //nolint:lll
+
+	// When the rating last changed. The longer a player goes without playing,
+	// the less certain their rating becomes.
+	Updated time.Time `protobuf:"-" json:"u" bson:"u"` //nolint:lll
+}
+
+// RatingChange is how one game changed a player's rating
+type RatingChange struct {
+	PlayerID PlayerID   `protobuf:"-" json:"pID" bson:"pID"` //nolint:lll
+	Pool     RatingPool `protobuf:"-" json:"p" bson:"p"`     //nolint:lll
+	GameID   GameID     `protobuf:"-" json:"gID" bson:"gID"` //nolint:lll
+
+	// The player's rating before and after the game
+	Before float64 `protobuf:"-" json:"b" bson:"b"` //nolint:lll
+	After  float64 `protobuf:"-" json:"a" bson:"a"` //nolint:lll
+
+	// The deviation of the rating after the game
+	Deviation float64 `protobuf:"-" json:"rd" bson:"rd"` //nolint:lll
+
+	At time.Time `protobuf:"-" json:"at" bson:"at"` //nolint:lll
+}
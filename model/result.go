@@ -0,0 +1,142 @@
+package model
+
+import (
+	"errors"
+)
+
+type SkunkLevel int
+
+const (
+	NotSkunked        SkunkLevel = 0
+	Skunked           SkunkLevel = 1
+	DoubleSkunked     SkunkLevel = 2
+	unknownSkunkLevel SkunkLevel = -1
+)
+
+func (s SkunkLevel) String() string {
+	switch s {
+	case NotSkunked:
+		return `none`
+	case Skunked:
+		return `skunk`
+	case DoubleSkunked:
+		return `double skunk`
+	}
+	return `notaskunk`
+}
+
+func NewSkunkLevelFromString(s string) SkunkLevel {
+	switch s {
+	case `none`:
+		return NotSkunked
+	case `skunk`:
+		return Skunked
+	case `double skunk`:
+		return DoubleSkunked
+	}
+	return unknownSkunkLevel
+}
+
+const (
+	// the number of game points awarded for winning a game, by how badly the loser lost
+	winGamePoints         int = 1
+	skunkGamePoints       int = 2
+	doubleSkunkGamePoints int = 3
+)
+
+var (
+	ErrGameNotOver = errors.New(`game is not over`)
+)
+
+// GameResult is the outcome of a finished game
+type GameResult struct {
+	// The color that won the game, and the score it finished with
+	Winner      PlayerColor `protobuf:"-" json:"w" bson:"w"`   //nolint:lll
+	WinnerScore int         `protobuf:"-" json:"ws" bson:"ws"` //nolint:lll
+
+	// The best score among the losers, and how far behind the winner it was
+	LoserScore int `protobuf:"-" json:"ls" bson:"ls"` //nolint:lll
+	Margin     int `protobuf:"-" json:"m" bson:"m"`   //nolint:lll
+
+	// How badly the losers lost, and what that's worth to the winner
+	Skunk      SkunkLevel `protobuf:"-" json:"s" bson:"s"`   //nolint:lll
+	GamePoints int        `protobuf:"-" json:"gp" bson:"gp"` //nolint:lll
+
+	// Forfeit is set when the losers gave the game up before anyone won it
+	Forfeit bool `protobuf:"-" json:"f,omitempty" bson:"f"` //nolint:lll
+}
+
+// NewGameResult calculates the result of a finished game. With more than two
+// colors, the losers are only skunked if every one of them is below the line.
+func NewGameResult(g *Game) (GameResult, error) {
+	if !g.IsOver() {
+		return GameResult{}, ErrGameNotOver
+	}
+	if g.Status == StatusAbandoned {
+		return GameResult{}, ErrGameAbandoned
+	}
+
+	res := GameResult{
+		Winner:     UnsetColor,
+		LoserScore: -1,
+	}
+	for color, score := range g.CurrentScores {
+		if score >= g.Options.Rules.TargetScore() {
+			res.Winner = color
+			res.WinnerScore = score
+		}
+	}
+	for color, score := range g.CurrentScores {
+		if color != res.Winner && score > res.LoserScore {
+			res.LoserScore = score
+		}
+	}
+	if res.LoserScore < 0 {
+		return GameResult{}, errors.New(`game has no losers`)
+	}
+	res.Margin = res.WinnerScore - res.LoserScore
+
+	switch rules := g.Options.Rules; {
+	case res.LoserScore < rules.DoubleSkunk():
+		res.Skunk = DoubleSkunked
+		res.GamePoints = doubleSkunkGamePoints
+	case res.LoserScore < rules.Skunk():
+		res.Skunk = Skunked
+		res.GamePoints = skunkGamePoints
+	default:
+		res.Skunk = NotSkunked
+		res.GamePoints = winGamePoints
+	}
+
+	return res, nil
+}
+
+// NewForfeitResult returns the result of a game that the loser gave up. The
+// color with the best score of the rest wins, and it's never a skunk.
+func NewForfeitResult(g *Game, loser PlayerColor) (GameResult, error) {
+	res := GameResult{
+		Winner:     UnsetColor,
+		LoserScore: g.CurrentScores[loser],
+		Forfeit:    true,
+		Skunk:      NotSkunked,
+		GamePoints: winGamePoints,
+	}
+	for color, score := range g.CurrentScores {
+		if color == loser {
+			continue
+		}
+		// ties go to the lowest color so that the result doesn't depend on map order
+		if res.Winner == UnsetColor || score > res.WinnerScore ||
+			(score == res.WinnerScore && color < res.Winner) {
+
+			res.Winner = color
+			res.WinnerScore = score
+		}
+	}
+	if res.Winner == UnsetColor {
+		return GameResult{}, errors.New(`game has no winners`)
+	}
+	res.Margin = res.WinnerScore - res.LoserScore
+
+	return res, nil
+}
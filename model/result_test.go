@@ -0,0 +1,181 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestSkunkLevelStringConversions(t *testing.T) {
+	for _, s := range []model.SkunkLevel{
+		model.NotSkunked,
+		model.Skunked,
+		model.DoubleSkunked,
+	} {
+		assert.Equal(t, s, model.NewSkunkLevelFromString(s.String()))
+	}
+	assert.Equal(t, `notaskunk`, model.SkunkLevel(7).String())
+}
+
+func TestNewGameResult(t *testing.T) {
+	testCases := []struct {
+		msg       string
+		rules     model.GameRules
+		scores    map[model.PlayerColor]int
+		expResult model.GameResult
+	}{{
+		msg: `close game`,
+		scores: map[model.PlayerColor]int{
+			model.Blue: 121,
+			model.Red:  118,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Blue,
+			WinnerScore: 121,
+			LoserScore:  118,
+			Margin:      3,
+			Skunk:       model.NotSkunked,
+			GamePoints:  1,
+		},
+	}, {
+		msg: `skunk`,
+		scores: map[model.PlayerColor]int{
+			model.Blue: 90,
+			model.Red:  125,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Red,
+			WinnerScore: 125,
+			LoserScore:  90,
+			Margin:      35,
+			Skunk:       model.Skunked,
+			GamePoints:  2,
+		},
+	}, {
+		msg: `just avoided the skunk`,
+		scores: map[model.PlayerColor]int{
+			model.Blue: 91,
+			model.Red:  121,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Red,
+			WinnerScore: 121,
+			LoserScore:  91,
+			Margin:      30,
+			Skunk:       model.NotSkunked,
+			GamePoints:  1,
+		},
+	}, {
+		msg: `double skunk`,
+		scores: map[model.PlayerColor]int{
+			model.Blue: 60,
+			model.Red:  121,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Red,
+			WinnerScore: 121,
+			LoserScore:  60,
+			Margin:      61,
+			Skunk:       model.DoubleSkunked,
+			GamePoints:  3,
+		},
+	}, {
+		msg: `three player game skunks only if everyone is skunked`,
+		scores: map[model.PlayerColor]int{
+			model.Blue:  121,
+			model.Red:   50,
+			model.Green: 95,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Blue,
+			WinnerScore: 121,
+			LoserScore:  95,
+			Margin:      26,
+			Skunk:       model.NotSkunked,
+			GamePoints:  1,
+		},
+	}, {
+		msg:   `short game skunk`,
+		rules: model.GameRules{WinningScore: 61},
+		scores: map[model.PlayerColor]int{
+			model.Blue: 61,
+			model.Red:  30,
+		},
+		expResult: model.GameResult{
+			Winner:      model.Blue,
+			WinnerScore: 61,
+			LoserScore:  30,
+			Margin:      31,
+			Skunk:       model.Skunked,
+			GamePoints:  2,
+		},
+	}}
+
+	for _, tc := range testCases {
+		g := model.Game{
+			Options:       model.GameOptions{Rules: tc.rules},
+			CurrentScores: tc.scores,
+		}
+		res, err := model.NewGameResult(&g)
+		require.NoError(t, err, tc.msg)
+		assert.Equal(t, tc.expResult, res, tc.msg)
+	}
+}
+
+func TestNewGameResultBeforeGameIsOver(t *testing.T) {
+	g := model.Game{
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue: 120,
+			model.Red:  12,
+		},
+	}
+	_, err := model.NewGameResult(&g)
+	assert.Equal(t, model.ErrGameNotOver, err)
+}
+
+func TestNewGameResultAbandoned(t *testing.T) {
+	g := model.Game{
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue: 0,
+			model.Red:  0,
+		},
+		Status: model.StatusAbandoned,
+	}
+	_, err := model.NewGameResult(&g)
+	assert.Equal(t, model.ErrGameAbandoned, err)
+}
+
+func TestNewForfeitResult(t *testing.T) {
+	g := model.Game{
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue:  40,
+			model.Red:   12,
+			model.Green: 40,
+		},
+	}
+	res, err := model.NewForfeitResult(&g, model.Red)
+	require.NoError(t, err)
+	assert.Equal(t, model.GameResult{
+		// ties go to the lower color
+		Winner:      model.Green,
+		WinnerScore: 40,
+		LoserScore:  12,
+		Margin:      28,
+		Skunk:       model.NotSkunked,
+		GamePoints:  1,
+		Forfeit:     true,
+	}, res)
+
+	// a forfeited game is over, even though nobody reached the winning score
+	g.Result = &res
+	g.Status = model.StatusExpired
+	assert.True(t, g.IsOver())
+
+	_, err = model.NewForfeitResult(&model.Game{
+		CurrentScores: map[model.PlayerColor]int{model.Blue: 0},
+	}, model.Blue)
+	assert.Error(t, err)
+}
@@ -0,0 +1,172 @@
+package model
+
+import (
+	"errors"
+)
+
+const (
+	StandardVariant  string = `standard`
+	ShortVariant     string = `short`
+	FiveCardVariant  string = `five-card`
+	SevenCardVariant string = `seven-card`
+)
+
+const (
+	// MaxWinningScore is the highest score a game can be played to
+	MaxWinningScore int = 181
+
+	standardHandSize int = 6
+	minHandSize      int = 5
+	maxHandSize      int = 7
+
+	// every player keeps all but two of the cards they'd be dealt in a two player game
+	numDiscards int = 2
+
+	// the skunk lines (by default) are thirty and sixty points shy of winning
+	skunkMargin       int = 30
+	doubleSkunkMargin int = 60
+)
+
+var (
+	ErrUnknownVariant      = errors.New(`unknown game variant`)
+	ErrInvalidWinningScore = errors.New(`invalid winning score`)
+	ErrInvalidHandSize     = errors.New(`invalid hand size`)
+	ErrInvalidSkunkLine    = errors.New(`invalid skunk line`)
+	ErrInvalidPoneBonus    = errors.New(`invalid pone bonus`)
+)
+
+// GameRules describe the variant of cribbage being played. Any zero value falls
+// back to the standard six-card, 121 point rules.
+type GameRules struct {
+	// Variant is the name of the variant these rules started from
+	Variant string `protobuf:"-" json:"v,omitempty" bson:"v"` //nolint:lll
+
+	// WinningScore is the number of points needed to win the game
+	WinningScore int `protobuf:"-" json:"ws,omitempty" bson:"ws"` //nolint:lll
+
+	// HandSize is the number of cards dealt to each player in a two player game
+	HandSize int `protobuf:"-" json:"hs,omitempty" bson:"hs"` //nolint:lll
+
+	// A loser who finishes below the SkunkLine has been skunked, and a loser
+	// below the DoubleSkunkLine has been double skunked
+	SkunkLine       int `protobuf:"-" json:"sl,omitempty" bson:"sl"`   //nolint:lll
+	DoubleSkunkLine int `protobuf:"-" json:"dsl,omitempty" bson:"dsl"` //nolint:lll
+
+	// PoneBonus is the number of points given to the player who leads the pegging
+	// on the first hand of the game (five-card cribbage's "three for last")
+	PoneBonus int `protobuf:"-" json:"pb,omitempty" bson:"pb"` //nolint:lll
+}
+
+// NewGameRules returns the rules for the named variant. An empty variant is
+// the standard game.
+func NewGameRules(variant string) (GameRules, error) {
+	switch variant {
+	case ``, StandardVariant:
+		return GameRules{
+			Variant:      StandardVariant,
+			WinningScore: WinningScore,
+			HandSize:     standardHandSize,
+		}, nil
+	case ShortVariant:
+		return GameRules{
+			Variant:      ShortVariant,
+			WinningScore: 61,
+			HandSize:     standardHandSize,
+		}, nil
+	case FiveCardVariant:
+		return GameRules{
+			Variant:      FiveCardVariant,
+			WinningScore: 61,
+			HandSize:     5,
+			PoneBonus:    3,
+		}, nil
+	case SevenCardVariant:
+		return GameRules{
+			Variant:      SevenCardVariant,
+			WinningScore: MaxWinningScore,
+			HandSize:     7,
+		}, nil
+	}
+	return GameRules{}, ErrUnknownVariant
+}
+
+// Validate returns an error if the game cannot be played with these rules
+func (r GameRules) Validate() error {
+	switch {
+	case !isBetween(r.WinningScore, 0, MaxWinningScore):
+		return ErrInvalidWinningScore
+	case r.HandSize != 0 && !isBetween(r.HandSize, minHandSize, maxHandSize):
+		return ErrInvalidHandSize
+	case !isBetween(r.SkunkLine, 0, r.TargetScore()),
+		!isBetween(r.DoubleSkunkLine, 0, r.Skunk()):
+		return ErrInvalidSkunkLine
+	case !isBetween(r.PoneBonus, 0, r.TargetScore()-1):
+		return ErrInvalidPoneBonus
+	}
+	return nil
+}
+
+// isBetween returns true if min <= n <= max
+func isBetween(n, min, max int) bool {
+	return min <= n && n <= max
+}
+
+// TargetScore is the number of points needed to win
+func (r GameRules) TargetScore() int {
+	if r.WinningScore > 0 {
+		return r.WinningScore
+	}
+	return WinningScore
+}
+
+// KeptHandSize is the number of cards each player has after building the crib
+func (r GameRules) KeptHandSize() int {
+	if r.HandSize > 0 {
+		return r.HandSize - numDiscards
+	}
+	return standardHandSize - numDiscards
+}
+
+// Skunk returns the score a loser must reach to avoid being skunked
+func (r GameRules) Skunk() int {
+	if r.SkunkLine > 0 {
+		return r.SkunkLine
+	}
+	return r.TargetScore() - skunkMargin
+}
+
+// DoubleSkunk returns the score a loser must reach to avoid being double skunked
+func (r GameRules) DoubleSkunk() int {
+	if r.DoubleSkunkLine > 0 {
+		return r.DoubleSkunkLine
+	}
+	if s := r.TargetScore() - doubleSkunkMargin; s > 0 {
+		return s
+	}
+	return 0
+}
+
+// IsVariant returns true if the rules are the ones of the variant they're named
+// after, without any of them changed
+func (r GameRules) IsVariant() bool {
+	vr, err := NewGameRules(r.Variant)
+	if err != nil {
+		return false
+	}
+	return r.withDefaults() == vr.withDefaults()
+}
+
+// withDefaults returns the rules with every zero value filled in with what the
+// game would fall back to
+func (r GameRules) withDefaults() GameRules {
+	if r.Variant == `` {
+		r.Variant = StandardVariant
+	}
+	if r.HandSize == 0 {
+		r.HandSize = standardHandSize
+	}
+	r.SkunkLine = r.Skunk()
+	r.DoubleSkunkLine = r.DoubleSkunk()
+	r.WinningScore = r.TargetScore()
+	return r
+}
@@ -0,0 +1,139 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestNewGameRules(t *testing.T) {
+	testCases := []struct {
+		variant        string
+		expTarget      int
+		expKept        int
+		expSkunk       int
+		expDoubleSkunk int
+		expPoneBonus   int
+	}{{
+		variant:        ``,
+		expTarget:      121,
+		expKept:        4,
+		expSkunk:       91,
+		expDoubleSkunk: 61,
+	}, {
+		variant:        model.StandardVariant,
+		expTarget:      121,
+		expKept:        4,
+		expSkunk:       91,
+		expDoubleSkunk: 61,
+	}, {
+		variant:        model.ShortVariant,
+		expTarget:      61,
+		expKept:        4,
+		expSkunk:       31,
+		expDoubleSkunk: 1,
+	}, {
+		variant:        model.FiveCardVariant,
+		expTarget:      61,
+		expKept:        3,
+		expSkunk:       31,
+		expDoubleSkunk: 1,
+		expPoneBonus:   3,
+	}, {
+		variant:        model.SevenCardVariant,
+		expTarget:      181,
+		expKept:        5,
+		expSkunk:       151,
+		expDoubleSkunk: 121,
+	}}
+
+	for _, tc := range testCases {
+		r, err := model.NewGameRules(tc.variant)
+		require.NoError(t, err, tc.variant)
+		assert.NoError(t, r.Validate(), tc.variant)
+		assert.Equal(t, tc.expTarget, r.TargetScore(), tc.variant)
+		assert.Equal(t, tc.expKept, r.KeptHandSize(), tc.variant)
+		assert.Equal(t, tc.expSkunk, r.Skunk(), tc.variant)
+		assert.Equal(t, tc.expDoubleSkunk, r.DoubleSkunk(), tc.variant)
+		assert.Equal(t, tc.expPoneBonus, r.PoneBonus, tc.variant)
+	}
+
+	_, err := model.NewGameRules(`eight-card`)
+	assert.Equal(t, model.ErrUnknownVariant, err)
+}
+
+func TestZeroGameRulesAreStandard(t *testing.T) {
+	r := model.GameRules{}
+	assert.NoError(t, r.Validate())
+	assert.Equal(t, model.WinningScore, r.TargetScore())
+	assert.Equal(t, 4, r.KeptHandSize())
+	assert.Equal(t, 91, r.Skunk())
+	assert.Equal(t, 61, r.DoubleSkunk())
+}
+
+func TestGameRulesIsVariant(t *testing.T) {
+	for _, v := range []string{
+		model.StandardVariant,
+		model.ShortVariant,
+		model.FiveCardVariant,
+		model.SevenCardVariant,
+	} {
+		r, err := model.NewGameRules(v)
+		require.NoError(t, err)
+		assert.True(t, r.IsVariant(), v)
+
+		r.WinningScore = 91
+		assert.False(t, r.IsVariant(), v)
+	}
+
+	assert.True(t, model.GameRules{}.IsVariant())
+	assert.True(t, model.GameRules{Variant: model.ShortVariant, WinningScore: 61, SkunkLine: 31}.IsVariant())
+	assert.False(t, model.GameRules{Variant: model.StandardVariant, HandSize: 5}.IsVariant())
+	assert.False(t, model.GameRules{Variant: `nine-card`}.IsVariant())
+}
+
+func TestGameRulesValidate(t *testing.T) {
+	testCases := []struct {
+		msg    string
+		rules  model.GameRules
+		expErr error
+	}{{
+		msg:   `custom target`,
+		rules: model.GameRules{WinningScore: 91},
+	}, {
+		msg:    `negative target`,
+		rules:  model.GameRules{WinningScore: -1},
+		expErr: model.ErrInvalidWinningScore,
+	}, {
+		msg:    `target too high`,
+		rules:  model.GameRules{WinningScore: 500},
+		expErr: model.ErrInvalidWinningScore,
+	}, {
+		msg:    `hand too small`,
+		rules:  model.GameRules{HandSize: 4},
+		expErr: model.ErrInvalidHandSize,
+	}, {
+		msg:    `hand too big`,
+		rules:  model.GameRules{HandSize: 8},
+		expErr: model.ErrInvalidHandSize,
+	}, {
+		msg:    `skunk line above the target`,
+		rules:  model.GameRules{WinningScore: 61, SkunkLine: 91},
+		expErr: model.ErrInvalidSkunkLine,
+	}, {
+		msg:    `double skunk line above the skunk line`,
+		rules:  model.GameRules{SkunkLine: 50, DoubleSkunkLine: 60},
+		expErr: model.ErrInvalidSkunkLine,
+	}, {
+		msg:    `negative pone bonus`,
+		rules:  model.GameRules{PoneBonus: -3},
+		expErr: model.ErrInvalidPoneBonus,
+	}}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expErr, tc.rules.Validate(), tc.msg)
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ScoreItemType int
+
+const (
+	FifteenItem ScoreItemType = 0
+	PairItem    ScoreItemType = 1
+	RunItem     ScoreItemType = 2
+	FlushItem   ScoreItemType = 3
+	NobsItem    ScoreItemType = 4
+
+	// ThirtyOneItem is only scored while pegging
+	ThirtyOneItem ScoreItemType = 5
+)
+
+func (t ScoreItemType) String() string {
+	switch t {
+	case FifteenItem:
+		return `fifteen`
+	case PairItem:
+		return `pair`
+	case RunItem:
+		return `run`
+	case FlushItem:
+		return `flush`
+	case NobsItem:
+		return `nobs`
+	case ThirtyOneItem:
+		return `thirty-one`
+	}
+	return `unknown`
+}
+
+// ScoreItem is a single scoring combination in a hand or crib, such as
+// one fifteen or one pair, along with the cards that made it
+type ScoreItem struct {
+	Type   ScoreItemType `json:"t" bson:"t"`
+	Cards  []Card        `json:"cs" bson:"cs"`
+	Points int           `json:"pts" bson:"pts"`
+}
+
+func (si ScoreItem) Name() string {
+	switch si.Type {
+	case FifteenItem, NobsItem, ThirtyOneItem:
+		return si.Type.String()
+	case PairItem:
+		// pegging can score three or four of a kind as a single item
+		switch len(si.Cards) {
+		case 3:
+			return `a pair royal`
+		case 4:
+			return `a double pair royal`
+		}
+		return `a pair`
+	case RunItem:
+		return fmt.Sprintf("a run of %d", len(si.Cards))
+	case FlushItem:
+		return fmt.Sprintf("a flush of %d", len(si.Cards))
+	}
+	return si.Type.String()
+}
+
+func (si ScoreItem) String() string {
+	cards := make([]string, len(si.Cards))
+	for i, c := range si.Cards {
+		cards[i] = c.String()
+	}
+	return fmt.Sprintf("%s (%s) for %d", si.Name(), strings.Join(cards, `, `), si.Points)
+}
+
+// TotalPoints adds up the points of all of the items
+func TotalPoints(items []ScoreItem) int {
+	pts := 0
+	for _, si := range items {
+		pts += si.Points
+	}
+	return pts
+}
+
+// DescribeScoreItems counts the items out loud the way a player would:
+// "fifteen 2, fifteen 4, and a pair is 6"
+func DescribeScoreItems(items []ScoreItem) string {
+	if len(items) == 0 {
+		// there's no way to score 19 points, so it's what a zero is called
+		return `nineteen`
+	}
+
+	total := 0
+	parts := make([]string, 0, len(items)-1)
+	for _, si := range items[:len(items)-1] {
+		total += si.Points
+		parts = append(parts, fmt.Sprintf("%s %d", si.Name(), total))
+	}
+
+	last := items[len(items)-1]
+	total += last.Points
+	if len(parts) == 0 {
+		return fmt.Sprintf("%s is %d", last.Name(), total)
+	}
+	return fmt.Sprintf("%s, and %s is %d", strings.Join(parts, `, `), last.Name(), total)
+}
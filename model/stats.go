@@ -0,0 +1,98 @@
+package model
+
+// PlayerStats are the totals of how a player has done over the games they've
+// finished. They're kept as totals so that each game can be added as it ends.
+type PlayerStats struct {
+	// The player these stats are for
+	PlayerID PlayerID `protobuf:"-" json:"pID" bson:"pID"` //nolint:lll
+
+	// The finished games the player has won and lost. A game that was
+	// abandoned before anyone acted is neither.
+	Wins   int `protobuf:"-" json:"w" bson:"w"` //nolint:lll
+	Losses int `protobuf:"-" json:"l" bson:"l"` //nolint:lll
+
+	// The games won by skunking the losers, and lost by being skunked. Double
+	// skunks are counted as skunks too.
+	SkunksGiven int `protobuf:"-" json:"sg" bson:"sg"` //nolint:lll
+	SkunksTaken int `protobuf:"-" json:"st" bson:"st"` //nolint:lll
+
+	// How many hands the player was dealt, and the points they pegged over them.
+	// Pegging points include the go and the last card.
+	Deals     int `protobuf:"-" json:"d" bson:"d"`   //nolint:lll
+	PegPoints int `protobuf:"-" json:"pp" bson:"pp"` //nolint:lll
+
+	// How many hands the player counted, and the points they counted for them
+	Hands      int `protobuf:"-" json:"h" bson:"h"`   //nolint:lll
+	HandPoints int `protobuf:"-" json:"hp" bson:"hp"` //nolint:lll
+
+	// How many cribs the player counted, and the points they counted for them
+	Cribs      int `protobuf:"-" json:"c" bson:"c"`   //nolint:lll
+	CribPoints int `protobuf:"-" json:"cp" bson:"cp"` //nolint:lll
+
+	// The most a hand the player kept was worth, and the game it was in. This is
+	// what the hand was worth, even if the player didn't count all of it.
+	BestHand     int    `protobuf:"-" json:"bh" bson:"bh"`   //nolint:lll
+	BestHandGame GameID `protobuf:"-" json:"bhg" bson:"bhg"` //nolint:lll
+
+	// How many perfect 29 point hands the player has kept
+	TwentyNines int `protobuf:"-" json:"29" bson:"29"` //nolint:lll
+
+	// How many of the player's discards were judged, and how many fewer points, in
+	// total, their kept hands were expected to score than the best ones they could've kept
+	Discards   int     `protobuf:"-" json:"ds" bson:"ds"` //nolint:lll
+	PointsLeft float64 `protobuf:"-" json:"pl" bson:"pl"` //nolint:lll
+}
+
+// Games is the number of games the player has finished
+func (ps PlayerStats) Games() int {
+	return ps.Wins + ps.Losses
+}
+
+// Add adds the other stats into these ones
+func (ps *PlayerStats) Add(other PlayerStats) {
+	ps.Wins += other.Wins
+	ps.Losses += other.Losses
+	ps.SkunksGiven += other.SkunksGiven
+	ps.SkunksTaken += other.SkunksTaken
+	ps.Deals += other.Deals
+	ps.PegPoints += other.PegPoints
+	ps.Hands += other.Hands
+	ps.HandPoints += other.HandPoints
+	ps.Cribs += other.Cribs
+	ps.CribPoints += other.CribPoints
+	if other.BestHand > ps.BestHand {
+		ps.BestHand = other.BestHand
+		ps.BestHandGame = other.BestHandGame
+	}
+	ps.TwentyNines += other.TwentyNines
+	ps.Discards += other.Discards
+	ps.PointsLeft += other.PointsLeft
+}
+
+// AverageHandPoints is the average number of points the player counted in their hand
+func (ps PlayerStats) AverageHandPoints() float64 {
+	return average(float64(ps.HandPoints), ps.Hands)
+}
+
+// AverageCribPoints is the average number of points the player counted in their crib
+func (ps PlayerStats) AverageCribPoints() float64 {
+	return average(float64(ps.CribPoints), ps.Cribs)
+}
+
+// AveragePegPoints is the average number of points the player pegged each deal
+func (ps PlayerStats) AveragePegPoints() float64 {
+	return average(float64(ps.PegPoints), ps.Deals)
+}
+
+// AveragePointsLeft is the average number of points the player's discards left on
+// the table
+func (ps PlayerStats) AveragePointsLeft() float64 {
+	return average(ps.PointsLeft, ps.Discards)
+}
+
+func average(total float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+)
+
+// GameStatus is whether a game is still being played, or how it ended
+type GameStatus int
+
+const (
+	// StatusActive games are still being played. Games saved before
+	// statuses existed are active until they're played again.
+	StatusActive GameStatus = 0
+	// StatusCompleted games were won by a team reaching the target score
+	StatusCompleted GameStatus = 1
+	// StatusResigned games were given up by a team, and the rest won
+	StatusResigned GameStatus = 2
+	// StatusAbandoned games were given up before anyone acted, so nobody won
+	StatusAbandoned GameStatus = 3
+	// StatusExpired games were forfeited by a team that ran out of time
+	StatusExpired     GameStatus = 4
+	unknownGameStatus GameStatus = -1
+)
+
+var (
+	ErrUnknownGameStatus = errors.New(`unknown game status`)
+	ErrGameAbandoned     = errors.New(`game was abandoned`)
+)
+
+func (s GameStatus) String() string {
+	switch s {
+	case StatusActive:
+		return `active`
+	case StatusCompleted:
+		return `completed`
+	case StatusResigned:
+		return `resigned`
+	case StatusAbandoned:
+		return `abandoned`
+	case StatusExpired:
+		return `expired`
+	}
+	return `unknown`
+}
+
+func NewGameStatusFromString(s string) (GameStatus, error) {
+	switch s {
+	case `active`:
+		return StatusActive, nil
+	case `completed`:
+		return StatusCompleted, nil
+	case `resigned`:
+		return StatusResigned, nil
+	case `abandoned`:
+		return StatusAbandoned, nil
+	case `expired`:
+		return StatusExpired, nil
+	}
+	return unknownGameStatus, ErrUnknownGameStatus
+}
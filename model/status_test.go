@@ -0,0 +1,28 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestGameStatusStringConversions(t *testing.T) {
+	for _, s := range []model.GameStatus{
+		model.StatusActive,
+		model.StatusCompleted,
+		model.StatusResigned,
+		model.StatusAbandoned,
+		model.StatusExpired,
+	} {
+		act, err := model.NewGameStatusFromString(s.String())
+		require.NoError(t, err)
+		assert.Equal(t, s, act)
+	}
+
+	_, err := model.NewGameStatusFromString(`other`)
+	assert.Equal(t, model.ErrUnknownGameStatus, err)
+	assert.Equal(t, `unknown`, model.GameStatus(5).String())
+}
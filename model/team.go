@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidTeams = errors.New(`each team needs the same number of players`)
+)
+
+// Team is the players who share a score and a color on the board. Partners
+// sit evenly around the table, so they take turns with the other teams.
+type Team struct {
+	Color   PlayerColor
+	Players []PlayerID
+}
+
+// TeamSize is how many players are on each team in a game of numPlayers.
+// Four player games are played in two partnerships, and six player games
+// in three.
+func TeamSize(numPlayers int) int {
+	if numPlayers == 4 || numPlayers == 6 {
+		return 2
+	}
+	return 1
+}
+
+// NumTeams is how many teams there are in a game of numPlayers
+func NumTeams(numPlayers int) int {
+	return numPlayers / TeamSize(numPlayers)
+}
+
+// Teams returns the teams in this game, in the order that they sit. The
+// players in each team are in the order they sit as well.
+func (g *Game) Teams() []Team {
+	numTeams := NumTeams(len(g.Players))
+	teams := make([]Team, numTeams)
+	for i, p := range g.Players {
+		t := &teams[i%numTeams]
+		if len(t.Players) == 0 {
+			t.Color = g.PlayerColors[p.ID]
+		}
+		t.Players = append(t.Players, p.ID)
+	}
+	return teams
+}
+
+// Partners returns the other players on the same team as pID
+func (g *Game) Partners(pID PlayerID) []PlayerID {
+	for _, t := range g.Teams() {
+		var partners []PlayerID
+		isOnTeam := false
+		for _, id := range t.Players {
+			if id == pID {
+				isOnTeam = true
+				continue
+			}
+			partners = append(partners, id)
+		}
+		if isOnTeam {
+			return partners
+		}
+	}
+	return nil
+}
+
+// SeatTeams returns the order that the players on these teams should sit
+// in, so that partners sit evenly around the table.
+func SeatTeams(teams [][]PlayerID) ([]PlayerID, error) {
+	numPlayers := 0
+	for _, t := range teams {
+		numPlayers += len(t)
+	}
+	if numPlayers < MinPlayerGame || numPlayers > MaxPlayerGame {
+		return nil, errors.New(`invalid number of players`)
+	}
+
+	size := TeamSize(numPlayers)
+	seen := make(map[PlayerID]struct{}, numPlayers)
+	for _, t := range teams {
+		if len(t) != size {
+			return nil, ErrInvalidTeams
+		}
+		for _, pID := range t {
+			if _, ok := seen[pID]; ok {
+				return nil, errors.New(`players can only be on one team`)
+			}
+			seen[pID] = struct{}{}
+		}
+	}
+
+	seating := make([]PlayerID, 0, numPlayers)
+	for i := 0; i < size; i++ {
+		for _, t := range teams {
+			seating = append(seating, t[i])
+		}
+	}
+	return seating, nil
+}
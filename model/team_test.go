@@ -0,0 +1,105 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestTeams(t *testing.T) {
+	g := model.Game{
+		Players: []model.Player{{ID: `a`}, {ID: `b`}, {ID: `c`}, {ID: `d`}},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`a`: model.Blue,
+			`b`: model.Red,
+			`c`: model.Blue,
+			`d`: model.Red,
+		},
+	}
+	assert.Equal(t, []model.Team{{
+		Color:   model.Blue,
+		Players: []model.PlayerID{`a`, `c`},
+	}, {
+		Color:   model.Red,
+		Players: []model.PlayerID{`b`, `d`},
+	}}, g.Teams())
+	assert.Equal(t, []model.PlayerID{`c`}, g.Partners(`a`))
+	assert.Equal(t, []model.PlayerID{`b`}, g.Partners(`d`))
+	assert.Empty(t, g.Partners(`e`))
+
+	g.Players = g.Players[:3]
+	g.PlayerColors[`c`] = model.Green
+	assert.Equal(t, []model.Team{{
+		Color:   model.Blue,
+		Players: []model.PlayerID{`a`},
+	}, {
+		Color:   model.Red,
+		Players: []model.PlayerID{`b`},
+	}, {
+		Color:   model.Green,
+		Players: []model.PlayerID{`c`},
+	}}, g.Teams())
+	assert.Empty(t, g.Partners(`a`))
+}
+
+func TestSeatTeams(t *testing.T) {
+	testCases := []struct {
+		msg    string
+		teams  [][]model.PlayerID
+		exp    []model.PlayerID
+		expErr string
+	}{{
+		msg:   `head to head`,
+		teams: [][]model.PlayerID{{`a`}, {`b`}},
+		exp:   []model.PlayerID{`a`, `b`},
+	}, {
+		msg:   `three players`,
+		teams: [][]model.PlayerID{{`a`}, {`b`}, {`c`}},
+		exp:   []model.PlayerID{`a`, `b`, `c`},
+	}, {
+		msg:   `partners sit across from each other`,
+		teams: [][]model.PlayerID{{`a`, `b`}, {`c`, `d`}},
+		exp:   []model.PlayerID{`a`, `c`, `b`, `d`},
+	}, {
+		msg:   `five players`,
+		teams: [][]model.PlayerID{{`a`}, {`b`}, {`c`}, {`d`}, {`e`}},
+		exp:   []model.PlayerID{`a`, `b`, `c`, `d`, `e`},
+	}, {
+		msg:   `three partnerships`,
+		teams: [][]model.PlayerID{{`a`, `b`}, {`c`, `d`}, {`e`, `f`}},
+		exp:   []model.PlayerID{`a`, `c`, `e`, `b`, `d`, `f`},
+	}, {
+		msg:    `six players must have partners`,
+		teams:  [][]model.PlayerID{{`a`, `b`, `c`}, {`d`, `e`, `f`}},
+		expErr: model.ErrInvalidTeams.Error(),
+	}, {
+		msg:    `four players must have partners`,
+		teams:  [][]model.PlayerID{{`a`}, {`b`}, {`c`}, {`d`}},
+		expErr: model.ErrInvalidTeams.Error(),
+	}, {
+		msg:    `uneven teams`,
+		teams:  [][]model.PlayerID{{`a`, `b`, `c`}, {`d`}},
+		expErr: model.ErrInvalidTeams.Error(),
+	}, {
+		msg:    `too few players`,
+		teams:  [][]model.PlayerID{{`a`}},
+		expErr: `invalid number of players`,
+	}, {
+		msg:    `same player twice`,
+		teams:  [][]model.PlayerID{{`a`, `b`}, {`c`, `a`}},
+		expErr: `players can only be on one team`,
+	}}
+
+	for _, tc := range testCases {
+		seating, err := model.SeatTeams(tc.teams)
+		if tc.expErr != `` {
+			assert.EqualError(t, err, tc.expErr, tc.msg)
+			continue
+		}
+		require.NoError(t, err, tc.msg)
+		assert.Equal(t, tc.exp, seating, tc.msg)
+	}
+}
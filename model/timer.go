@@ -0,0 +1,124 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// TimeoutPolicy is what happens to a player who doesn't act before their deadline
+type TimeoutPolicy int
+
+const (
+	// AutoPlay has the calculated NPC act for the player
+	AutoPlay TimeoutPolicy = 0
+	// ForfeitGame ends the game, and the player's team loses
+	ForfeitGame TimeoutPolicy = 1
+	// Nudge reminds the player, and gives them another turn's worth of time
+	Nudge                TimeoutPolicy = 2
+	unknownTimeoutPolicy TimeoutPolicy = -1
+)
+
+func (tp TimeoutPolicy) String() string {
+	switch tp {
+	case AutoPlay:
+		return `autoplay`
+	case ForfeitGame:
+		return `forfeit`
+	case Nudge:
+		return `nudge`
+	}
+	return `unknown`
+}
+
+// NewTimeoutPolicyFromString returns the named policy. An empty name is AutoPlay.
+func NewTimeoutPolicyFromString(s string) (TimeoutPolicy, error) {
+	switch s {
+	case ``, `autoplay`:
+		return AutoPlay, nil
+	case `forfeit`:
+		return ForfeitGame, nil
+	case `nudge`:
+		return Nudge, nil
+	}
+	return unknownTimeoutPolicy, ErrUnknownTimeoutPolicy
+}
+
+const (
+	// players need long enough to read the cards, and games can't
+	// sit around for more than a week at a time
+	MinTurnTimeLimit = 10 * time.Second
+	MaxTurnTimeLimit = 7 * 24 * time.Hour
+)
+
+var (
+	ErrUnknownTimeoutPolicy = errors.New(`unknown timeout policy`)
+	ErrInvalidTurnTimeLimit = errors.New(`invalid turn time limit`)
+)
+
+// Validate returns an error if a game cannot be played with these options
+func (o GameOptions) Validate() error {
+	if err := o.Rules.Validate(); err != nil {
+		return err
+	}
+	if o.TurnTimeLimit != 0 &&
+		(o.TurnTimeLimit < MinTurnTimeLimit || o.TurnTimeLimit > MaxTurnTimeLimit) {
+
+		return ErrInvalidTurnTimeLimit
+	}
+	switch o.TimeoutPolicy {
+	case AutoPlay, ForfeitGame, Nudge:
+		return nil
+	}
+	return ErrUnknownTimeoutPolicy
+}
+
+// HasTurnTimer returns true if players have a limited amount of time to act
+func (o GameOptions) HasTurnTimer() bool {
+	return o.TurnTimeLimit > 0
+}
+
+// IsEquivalent returns true if games with either options would be played the same.
+// For example, rules that don't set a winning score are played to 121.
+func (o GameOptions) IsEquivalent(other GameOptions) bool {
+	a, b := o, other
+	a.Rules, b.Rules = a.Rules.withDefaults(), b.Rules.withDefaults()
+	if !a.HasTurnTimer() {
+		a.TimeoutPolicy = AutoPlay
+	}
+	if !b.HasTurnTimer() {
+		b.TimeoutPolicy = AutoPlay
+	}
+	return a == b
+}
+
+// NextDeadline returns the earliest time that any player needs to act by. It
+// returns false if nobody has a deadline.
+func (g *Game) NextDeadline() (time.Time, bool) {
+	var next time.Time
+	for _, dl := range g.Deadlines {
+		if next.IsZero() || dl.Before(next) {
+			next = dl
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// Overdue returns the blocking players whose deadlines are not after now,
+// ordered by how long they've been overdue
+func (g *Game) Overdue(now time.Time) []PlayerID {
+	var pIDs []PlayerID
+	for pID, dl := range g.Deadlines {
+		if _, ok := g.BlockingPlayers[pID]; ok && !dl.After(now) {
+			pIDs = append(pIDs, pID)
+		}
+	}
+	sort.Slice(pIDs, func(i, j int) bool {
+		di, dj := g.Deadlines[pIDs[i]], g.Deadlines[pIDs[j]]
+		if di.Equal(dj) {
+			return pIDs[i] < pIDs[j]
+		}
+		return di.Before(dj)
+	})
+	return pIDs
+}
@@ -0,0 +1,125 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestTimeoutPolicyStringConversions(t *testing.T) {
+	for _, tp := range []model.TimeoutPolicy{
+		model.AutoPlay,
+		model.ForfeitGame,
+		model.Nudge,
+	} {
+		act, err := model.NewTimeoutPolicyFromString(tp.String())
+		require.NoError(t, err)
+		assert.Equal(t, tp, act)
+	}
+
+	act, err := model.NewTimeoutPolicyFromString(``)
+	require.NoError(t, err)
+	assert.Equal(t, model.AutoPlay, act)
+
+	_, err = model.NewTimeoutPolicyFromString(`other`)
+	assert.Equal(t, model.ErrUnknownTimeoutPolicy, err)
+	assert.Equal(t, `unknown`, model.TimeoutPolicy(3).String())
+}
+
+func TestGameOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		msg    string
+		opts   model.GameOptions
+		expErr error
+	}{{
+		msg:  `no timer`,
+		opts: model.GameOptions{},
+	}, {
+		msg: `a minute to act`,
+		opts: model.GameOptions{
+			TurnTimeLimit: time.Minute,
+			TimeoutPolicy: model.Nudge,
+		},
+	}, {
+		msg: `too short`,
+		opts: model.GameOptions{
+			TurnTimeLimit: time.Second,
+		},
+		expErr: model.ErrInvalidTurnTimeLimit,
+	}, {
+		msg: `too long`,
+		opts: model.GameOptions{
+			TurnTimeLimit: 30 * 24 * time.Hour,
+		},
+		expErr: model.ErrInvalidTurnTimeLimit,
+	}, {
+		msg: `unknown policy`,
+		opts: model.GameOptions{
+			TurnTimeLimit: time.Minute,
+			TimeoutPolicy: model.TimeoutPolicy(7),
+		},
+		expErr: model.ErrUnknownTimeoutPolicy,
+	}, {
+		msg: `invalid rules`,
+		opts: model.GameOptions{
+			Rules: model.GameRules{HandSize: 9},
+		},
+		expErr: model.ErrInvalidHandSize,
+	}}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expErr, tc.opts.Validate(), tc.msg)
+	}
+}
+
+func TestOverdue(t *testing.T) {
+	now := time.Date(2020, time.June, 1, 12, 0, 0, 0, time.UTC)
+	g := model.Game{
+		BlockingPlayers: map[model.PlayerID]model.Blocker{
+			`alice`:   model.CribCard,
+			`bob`:     model.CribCard,
+			`charlie`: model.CribCard,
+		},
+	}
+
+	_, ok := g.NextDeadline()
+	assert.False(t, ok)
+	assert.Empty(t, g.Overdue(now))
+
+	g.Deadlines = map[model.PlayerID]time.Time{
+		`alice`:   now.Add(time.Second),
+		`bob`:     now,
+		`charlie`: now.Add(-time.Minute),
+		// diane acted already, so she isn't overdue
+		`diane`: now.Add(-time.Hour),
+	}
+	next, ok := g.NextDeadline()
+	require.True(t, ok)
+	assert.Equal(t, now.Add(-time.Hour), next)
+	assert.Equal(t, []model.PlayerID{`charlie`, `bob`}, g.Overdue(now))
+}
+
+func TestGameOptionsIsEquivalent(t *testing.T) {
+	standard, err := model.NewGameRules(model.StandardVariant)
+	require.NoError(t, err)
+	short, err := model.NewGameRules(model.ShortVariant)
+	require.NoError(t, err)
+
+	assert.True(t, model.GameOptions{}.IsEquivalent(model.GameOptions{Rules: standard}))
+	assert.True(t, model.GameOptions{}.IsEquivalent(model.GameOptions{
+		Rules: model.GameRules{WinningScore: 121, SkunkLine: 91},
+	}))
+	assert.True(t, model.GameOptions{TimeoutPolicy: model.Nudge}.IsEquivalent(model.GameOptions{}))
+	assert.False(t, model.GameOptions{}.IsEquivalent(model.GameOptions{Rules: short}))
+	assert.False(t, model.GameOptions{}.IsEquivalent(model.GameOptions{Muggins: true}))
+	assert.False(t, model.GameOptions{
+		TurnTimeLimit: time.Minute,
+		TimeoutPolicy: model.Nudge,
+	}.IsEquivalent(model.GameOptions{
+		TurnTimeLimit: time.Minute,
+	}))
+}
@@ -18,6 +18,19 @@ func convertFromPhase(p string) model.Phase {
 	return model.NewPhaseFromString(p)
 }
 
+func convertToGameStatus(s model.GameStatus) string {
+	return s.String()
+}
+
+func convertFromGameStatus(s string) model.GameStatus {
+	gs, err := model.NewGameStatusFromString(s)
+	if err != nil {
+		// clients that don't know about statuses are only shown games being played
+		return model.StatusActive
+	}
+	return gs
+}
+
 func convertToBlocker(b model.Blocker) string {
 	return b.String()
 }
@@ -62,18 +75,20 @@ func convertFromBlockingPlayers(bs map[model.PlayerID]string) map[model.PlayerID
 }
 
 func convertToTeams(g model.Game) []GetGameResponseTeam {
-	teams := make([]GetGameResponseTeam, 0, 3)
-	for i, p := range g.Players {
-		ps := make([]Player, 0, 2)
-		ps = append(ps, convertToPlayer(p))
-		if len(g.Players) == 4 {
-			if i > 1 {
-				break
-			}
-			ps = append(ps, convertToPlayer(g.Players[i+2]))
+	playersByID := make(map[model.PlayerID]model.Player, len(g.Players))
+	for _, p := range g.Players {
+		playersByID[p.ID] = p
+	}
+
+	mTeams := g.Teams()
+	teams := make([]GetGameResponseTeam, 0, len(mTeams))
+	for _, mt := range mTeams {
+		ps := make([]Player, 0, len(mt.Players))
+		for _, pID := range mt.Players {
+			ps = append(ps, convertToPlayer(playersByID[pID]))
 		}
 
-		color, ok := p.Games[g.ID]
+		color, ok := playersByID[mt.Players[0]].Games[g.ID]
 		if !ok {
 			color = model.UnsetColor
 		}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 )
@@ -145,3 +146,39 @@ func TestConvertToTeams(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertToTeamsKeepsSeating(t *testing.T) {
+	gID := model.NewGameID()
+	g := model.Game{
+		ID:            gID,
+		CurrentScores: map[model.PlayerColor]int{},
+		LagScores:     map[model.PlayerColor]int{},
+		PlayerColors:  map[model.PlayerID]model.PlayerColor{},
+	}
+	for i, id := range []model.PlayerID{`alice`, `bob`, `charlie`, `diane`} {
+		color := model.Blue
+		if i%2 == 1 {
+			color = model.Red
+		}
+		g.Players = append(g.Players, model.Player{
+			ID:    id,
+			Games: map[model.GameID]model.PlayerColor{gID: color},
+		})
+		g.PlayerColors[id] = color
+	}
+
+	teams := convertToTeams(g)
+	require.Len(t, teams, 2)
+	assert.Equal(t, `blue`, teams[0].Color)
+	assert.Equal(t, []Player{{ID: `alice`}, {ID: `charlie`}}, teams[0].Players)
+	assert.Equal(t, `red`, teams[1].Color)
+	assert.Equal(t, []Player{{ID: `bob`}, {ID: `diane`}}, teams[1].Players)
+
+	// converting back puts partners across from each other again
+	ps, pcs := convertTeamsToPlayersAndPlayerColors(teams)
+	require.Len(t, ps, 4)
+	for i, p := range ps {
+		assert.Equal(t, g.Players[i].ID, p.ID)
+	}
+	assert.Equal(t, g.PlayerColors, pcs)
+}
@@ -0,0 +1,71 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// GameEvent is something that happened in a game. Which fields are set
+// depends on the Type.
+type GameEvent struct {
+	Type        string `json:"type"`
+	Action      int    `json:"action"`
+	Description string `json:"description"`
+
+	PlayerID model.PlayerID `json:"playerID,omitempty"`
+	Card     *Card          `json:"card,omitempty"`
+	Cards    []Card         `json:"cards,omitempty"`
+	CutCard  *Card          `json:"cut_card,omitempty"`
+	Peg      int            `json:"peg,omitempty"`
+	Phase    string         `json:"phase,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+	Points   int            `json:"points,omitempty"`
+	Score    int            `json:"score,omitempty"`
+	Correct  bool           `json:"correct,omitempty"`
+
+	Breakdown []ScoreItem `json:"breakdown,omitempty"`
+}
+
+func ConvertToGameEvent(ge model.GameEvent) GameEvent {
+	e := GameEvent{
+		Type:        ge.Type.String(),
+		Action:      ge.Action,
+		Description: ge.String(),
+	}
+
+	switch me := ge.Event.(type) {
+	case model.ScoreEvent:
+		e.PlayerID = me.PlayerID
+		e.Reason = me.Reason.String()
+		e.Points = me.Points
+		e.Score = me.Score
+		e.Cards = convertToCards(me.Cards)
+		if me.CutCard != nil {
+			e.CutCard = convertToCardPtr(*me.CutCard)
+		}
+		e.Breakdown = convertToScoreItems(me.Breakdown)
+	case model.CardDealtEvent:
+		e.PlayerID = me.PlayerID
+		e.Cards = convertToCards(me.Cards)
+	case model.CutRevealedEvent:
+		e.Card = convertToCardPtr(me.Card)
+	case model.CardPeggedEvent:
+		e.PlayerID = me.PlayerID
+		e.Card = convertToCardPtr(me.Card)
+		e.Peg = me.Peg
+	case model.GoSaidEvent:
+		e.PlayerID = me.PlayerID
+	case model.PhaseChangedEvent:
+		e.Phase = convertToPhase(me.Phase)
+	case model.MugginsCalledEvent:
+		e.PlayerID = me.PlayerID
+		e.Points = me.Points
+		e.Correct = me.Correct
+	}
+
+	return e
+}
+
+func convertToCardPtr(c model.Card) *Card {
+	nc := convertToCard(c)
+	return &nc
+}
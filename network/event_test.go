@@ -0,0 +1,97 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestConvertToGameEvent(t *testing.T) {
+	cut := model.NewCardFromString(`7h`)
+	testCases := []struct {
+		msg   string
+		event interface{}
+		exp   GameEvent
+	}{{
+		msg: `hand count`,
+		event: model.ScoreEvent{
+			PlayerID: `alice`,
+			Reason:   model.HandScore,
+			Points:   4,
+			Score:    20,
+			Cards:    []model.Card{model.NewCardFromString(`8c`), model.NewCardFromString(`7c`)},
+			CutCard:  &cut,
+			Breakdown: []model.ScoreItem{{
+				Type:   model.FifteenItem,
+				Cards:  []model.Card{model.NewCardFromString(`8c`), cut},
+				Points: 2,
+			}},
+		},
+		exp: GameEvent{
+			Type:        `Scored`,
+			Action:      3,
+			Description: `alice scored 4 for hand (7H: 8C, 7C)`,
+			PlayerID:    `alice`,
+			Reason:      `hand`,
+			Points:      4,
+			Score:       20,
+			Cards: []Card{
+				{Suit: `Clubs`, Value: 8, Name: `8C`},
+				{Suit: `Clubs`, Value: 7, Name: `7C`},
+			},
+			CutCard: &Card{Suit: `Hearts`, Value: 7, Name: `7H`},
+			Breakdown: []ScoreItem{{
+				Type: `fifteen`,
+				Cards: []Card{
+					{Suit: `Clubs`, Value: 8, Name: `8C`},
+					{Suit: `Hearts`, Value: 7, Name: `7H`},
+				},
+				Points:      2,
+				Description: `fifteen (8C, 7H) for 2`,
+			}},
+		},
+	}, {
+		msg: `pegged card`,
+		event: model.CardPeggedEvent{
+			PlayerID: `bob`,
+			Card:     model.NewCardFromString(`5s`),
+			Peg:      15,
+		},
+		exp: GameEvent{
+			Type:        `CardPegged`,
+			Action:      3,
+			Description: `bob pegged 5S for 15`,
+			PlayerID:    `bob`,
+			Card:        &Card{Suit: `Spades`, Value: 5, Name: `5S`},
+			Peg:         15,
+		},
+	}, {
+		msg:   `phase change`,
+		event: model.PhaseChangedEvent{Phase: model.Pegging},
+		exp: GameEvent{
+			Type:        `PhaseChanged`,
+			Action:      3,
+			Description: `phase changed to Pegging`,
+			Phase:       `Pegging`,
+		},
+	}, {
+		msg: `incorrect muggins`,
+		event: model.MugginsCalledEvent{
+			PlayerID: `bob`,
+			Points:   2,
+		},
+		exp: GameEvent{
+			Type:        `MugginsCalled`,
+			Action:      3,
+			Description: `bob made an incorrect muggins claim of 2`,
+			PlayerID:    `bob`,
+			Points:      2,
+		},
+	}}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, ConvertToGameEvent(model.NewGameEvent(3, tc.event)), tc.msg)
+	}
+}
@@ -2,12 +2,90 @@ package network
 
 import (
 	"errors"
+	"time"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 )
 
 type CreateGameRequest struct {
 	PlayerIDs []model.PlayerID `json:"playerIDs"`
+	// Teams can be set instead of PlayerIDs to choose partners
+	Teams     [][]model.PlayerID `json:"teams,omitempty"`
+	Muggins   bool               `json:"muggins,omitempty"`
+	Rules     *GameRules         `json:"rules,omitempty"`
+	TurnTimer *TurnTimer         `json:"turn_timer,omitempty"`
+}
+
+// GameRules describe the variant of cribbage to play. Any rules
+// that are set override the defaults for the variant.
+type GameRules struct {
+	Variant         string `json:"variant,omitempty"`
+	WinningScore    int    `json:"winning_score,omitempty"`
+	HandSize        int    `json:"hand_size,omitempty"`
+	SkunkLine       int    `json:"skunk_line,omitempty"`
+	DoubleSkunkLine int    `json:"double_skunk_line,omitempty"`
+	PoneBonus       int    `json:"pone_bonus,omitempty"`
+}
+
+func ConvertFromGameRules(r *GameRules) (model.GameRules, error) {
+	if r == nil {
+		return model.GameRules{}, nil
+	}
+
+	mr, err := model.NewGameRules(r.Variant)
+	if err != nil {
+		return model.GameRules{}, err
+	}
+
+	if r.WinningScore != 0 {
+		mr.WinningScore = r.WinningScore
+	}
+	if r.HandSize != 0 {
+		mr.HandSize = r.HandSize
+	}
+	if r.SkunkLine != 0 {
+		mr.SkunkLine = r.SkunkLine
+	}
+	if r.DoubleSkunkLine != 0 {
+		mr.DoubleSkunkLine = r.DoubleSkunkLine
+	}
+	if r.PoneBonus != 0 {
+		mr.PoneBonus = r.PoneBonus
+	}
+
+	if err := mr.Validate(); err != nil {
+		return model.GameRules{}, err
+	}
+	return mr, nil
+}
+
+func convertToGameRules(r model.GameRules) *GameRules {
+	if r == (model.GameRules{}) {
+		// the standard game doesn't need to describe its rules
+		return nil
+	}
+	return &GameRules{
+		Variant:         r.Variant,
+		WinningScore:    r.WinningScore,
+		HandSize:        r.HandSize,
+		SkunkLine:       r.SkunkLine,
+		DoubleSkunkLine: r.DoubleSkunkLine,
+		PoneBonus:       r.PoneBonus,
+	}
+}
+
+func convertFromGameRules(r *GameRules) model.GameRules {
+	if r == nil {
+		return model.GameRules{}
+	}
+	return model.GameRules{
+		Variant:         r.Variant,
+		WinningScore:    r.WinningScore,
+		HandSize:        r.HandSize,
+		SkunkLine:       r.SkunkLine,
+		DoubleSkunkLine: r.DoubleSkunkLine,
+		PoneBonus:       r.PoneBonus,
+	}
 }
 
 type CreateGameResponse struct {
@@ -36,28 +114,46 @@ type GetGameResponseTeam struct {
 }
 
 type GetGameResponse struct {
-	ID              model.GameID              `json:"id"`
-	Teams           []GetGameResponseTeam     `json:"teams"`
-	Phase           string                    `json:"phase"`
-	CurrentPeg      int                       `json:"current_peg"`
-	BlockingPlayers map[model.PlayerID]string `json:"blocking_players,omitempty"`
-	CurrentDealer   model.PlayerID            `json:"current_dealer"`
-	Hands           map[model.PlayerID][]Card `json:"hands,omitempty"`
-	Crib            []Card                    `json:"crib,omitempty"`
-	CutCard         Card                      `json:"cut_card"`
-	PeggedCards     []PeggedCard              `json:"pegged_cards,omitempty"`
+	ID              model.GameID                 `json:"id"`
+	Teams           []GetGameResponseTeam        `json:"teams"`
+	Phase           string                       `json:"phase"`
+	Status          string                       `json:"status"`
+	CurrentPeg      int                          `json:"current_peg"`
+	BlockingPlayers map[model.PlayerID]string    `json:"blocking_players,omitempty"`
+	CurrentDealer   model.PlayerID               `json:"current_dealer"`
+	Hands           map[model.PlayerID][]Card    `json:"hands,omitempty"`
+	Crib            []Card                       `json:"crib,omitempty"`
+	CutCard         Card                         `json:"cut_card"`
+	PeggedCards     []PeggedCard                 `json:"pegged_cards,omitempty"`
+	Muggins         bool                         `json:"muggins,omitempty"`
+	Rules           *GameRules                   `json:"rules,omitempty"`
+	TurnTimer       *TurnTimer                   `json:"turn_timer,omitempty"`
+	Deadlines       map[model.PlayerID]time.Time `json:"deadlines,omitempty"`
+	Result          *GameResult                  `json:"result,omitempty"`
+	// Seed is only revealed once the game is over, so that it can't be used to
+	// predict the cards
+	Seed *int64 `json:"seed,omitempty"`
 }
 
+// ConvertToGetGameResponse converts the game as a spectator sees it. Cards that haven't
+// been shown to the table are sent as unknown, and the deck is never sent.
 func ConvertToGetGameResponse(g model.Game) GetGameResponse {
 	ggr := GetGameResponse{
 		ID:              g.ID,
 		Teams:           convertToTeams(g),
 		Phase:           convertToPhase(g.Phase),
+		Status:          convertToGameStatus(g.Status),
 		BlockingPlayers: convertToBlockingPlayers(g.BlockingPlayers),
 		CurrentDealer:   g.CurrentDealer,
 		CurrentPeg:      g.CurrentPeg(),
 		CutCard:         convertToCard(g.CutCard),
 		PeggedCards:     convertToPeggedCards(g.PeggedCards),
+		Muggins:         g.Options.Muggins,
+		Rules:           convertToGameRules(g.Options.Rules),
+		TurnTimer:       convertToTurnTimer(g.Options),
+		Deadlines:       g.Deadlines,
+		Result:          convertToGameResultPtr(g),
+		Hands:           convertToRevealedHands(g, model.InvalidPlayerID),
 	}
 
 	if g.Phase >= model.CribCounting {
@@ -68,6 +164,11 @@ func ConvertToGetGameResponse(g model.Game) GetGameResponse {
 		}
 	}
 
+	if g.Seed != 0 && g.IsOver() {
+		seed := g.Seed
+		ggr.Seed = &seed
+	}
+
 	return ggr
 }
 
@@ -92,22 +193,43 @@ func ConvertFromGetGameResponse(g GetGameResponse) model.Game {
 	currentScores, lagScores := convertFromScores(g.Teams)
 	ps, pcs := convertTeamsToPlayersAndPlayerColors(g.Teams)
 	return model.Game{
-		ID:              g.ID,
-		Players:         ps,
-		PlayerColors:    pcs,
+		ID:           g.ID,
+		Players:      ps,
+		PlayerColors: pcs,
+		Options: convertFromTurnTimer(g.TurnTimer, model.GameOptions{
+			Muggins: g.Muggins,
+			Rules:   convertFromGameRules(g.Rules),
+		}),
 		CurrentScores:   currentScores,
 		LagScores:       lagScores,
 		Phase:           convertFromPhase(g.Phase),
+		Status:          convertFromGameStatus(g.Status),
 		BlockingPlayers: convertFromBlockingPlayers(g.BlockingPlayers),
+		Deadlines:       g.Deadlines,
 		CurrentDealer:   g.CurrentDealer,
 		CutCard:         convertFromCard(g.CutCard),
 		Crib:            convertFromCards(g.Crib),
 		Hands:           convertFomRevealedHands(g.Hands),
 		PeggedCards:     convertFromPeggedCards(g.PeggedCards),
+		Result:          convertFromGameResult(g.Result),
 	}
 }
 
+// convertToRevealedHands converts the hands that the player can see. Everybody can see
+// the cards that have been pegged, and every hand once they're being counted, but only
+// the player can see the rest of their own hand. A spectator is model.InvalidPlayerID.
 func convertToRevealedHands(g model.Game, me model.PlayerID) map[model.PlayerID][]Card {
+	if len(g.Hands) == 0 {
+		return nil
+	}
+	if g.Phase >= model.Counting {
+		rev := make(map[model.PlayerID][]Card, len(g.Hands))
+		for pID, h := range g.Hands {
+			rev[pID] = convertToCards(h)
+		}
+		return rev
+	}
+
 	rev := make(map[model.PlayerID][]Card, len(g.Players))
 	for pID, h := range g.Hands {
 		// we don't know how many cards will be revealed, but we know how may are in their hand
@@ -119,7 +241,9 @@ func convertToRevealedHands(g model.Game, me model.PlayerID) map[model.PlayerID]
 		}
 		rev[c.PlayerID] = append(rev[c.PlayerID], convertToCard(c.Card))
 	}
-	rev[me] = convertToCards(g.Hands[me])
+	if me != model.InvalidPlayerID {
+		rev[me] = convertToCards(g.Hands[me])
+	}
 
 	for pID := range rev {
 		for len(rev[pID]) < len(g.Hands[pID]) {
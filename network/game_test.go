@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 )
@@ -201,13 +202,17 @@ func TestConvertToGetGameResponse(t *testing.T) {
 					Name: `bobbette`,
 				}},
 			}},
-			Phase: `CribCounting`,
+			Phase:  `CribCounting`,
+			Status: `active`,
 			BlockingPlayers: map[model.PlayerID]string{
 				bobID: `CountCrib`,
 			},
 			CurrentDealer: bobID,
-			Hands:         nil,
-			Crib:          cardsFromStrings(`5h`, `6h`, `5s`, `6s`),
+			Hands: map[model.PlayerID][]Card{
+				aliceID: cardsFromStrings(`ah`, `2h`, `3h`, `4h`),
+				bobID:   cardsFromStrings(`as`, `2s`, `3s`, `4s`),
+			},
+			Crib: cardsFromStrings(`5h`, `6h`, `5s`, `6s`),
 			CutCard: Card{
 				Suit:  `Clubs`,
 				Value: 5,
@@ -231,6 +236,95 @@ func TestConvertToGetGameResponse(t *testing.T) {
 	}
 }
 
+func TestConvertToGetGameResponseSeed(t *testing.T) {
+	g := model.Game{
+		ID:    model.GameID(123456),
+		Seed:  42,
+		Phase: model.Pegging,
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue: 119,
+			model.Red:  100,
+		},
+	}
+
+	// the seed is hidden while the game is being played
+	resp := ConvertToGetGameResponse(g)
+	assert.Nil(t, resp.Seed)
+
+	g.CurrentScores[model.Blue] = 121
+	resp = ConvertToGetGameResponse(g)
+	require.NotNil(t, resp.Seed)
+	assert.Equal(t, int64(42), *resp.Seed)
+}
+
+func TestConvertToGetGameResponseHidesCards(t *testing.T) {
+	aliceID := model.PlayerID(`alice`)
+	bobID := model.PlayerID(`bob`)
+
+	tests := []struct {
+		desc     string
+		phase    model.Phase
+		pegged   []model.PeggedCard
+		expHands map[model.PlayerID][]Card
+		expCrib  []Card
+	}{{
+		desc:  `nothing is shown before pegging`,
+		phase: model.Cut,
+		expHands: map[model.PlayerID][]Card{
+			aliceID: cardsFromStrings(``, ``, ``, ``),
+			bobID:   cardsFromStrings(``, ``, ``, ``),
+		},
+		expCrib: cardsFromStrings(``, ``, ``, ``),
+	}, {
+		desc:  `pegged cards are shown`,
+		phase: model.Pegging,
+		pegged: []model.PeggedCard{{
+			Card:     model.NewCardFromString(`2h`),
+			PlayerID: aliceID,
+		}},
+		expHands: map[model.PlayerID][]Card{
+			aliceID: cardsFromStrings(`2h`, ``, ``, ``),
+			bobID:   cardsFromStrings(``, ``, ``, ``),
+		},
+		expCrib: cardsFromStrings(``, ``, ``, ``),
+	}, {
+		desc:  `hands are shown while counting`,
+		phase: model.Counting,
+		expHands: map[model.PlayerID][]Card{
+			aliceID: cardsFromStrings(`ah`, `2h`, `3h`, `4h`),
+			bobID:   cardsFromStrings(`as`, `2s`, `3s`, `4s`),
+		},
+		expCrib: cardsFromStrings(``, ``, ``, ``),
+	}, {
+		desc:  `the crib is shown while it's counted`,
+		phase: model.CribCounting,
+		expHands: map[model.PlayerID][]Card{
+			aliceID: cardsFromStrings(`ah`, `2h`, `3h`, `4h`),
+			bobID:   cardsFromStrings(`as`, `2s`, `3s`, `4s`),
+		},
+		expCrib: cardsFromStrings(`5h`, `6h`, `5s`, `6s`),
+	}}
+	for _, tc := range tests {
+		g := model.Game{
+			Phase: tc.phase,
+			Players: []model.Player{
+				{ID: aliceID},
+				{ID: bobID},
+			},
+			Hands: map[model.PlayerID][]model.Card{
+				aliceID: modelCardsFromStrings(`ah`, `2h`, `3h`, `4h`),
+				bobID:   modelCardsFromStrings(`as`, `2s`, `3s`, `4s`),
+			},
+			Crib:        modelCardsFromStrings(`5h`, `6h`, `5s`, `6s`),
+			PeggedCards: tc.pegged,
+		}
+
+		resp := ConvertToGetGameResponse(g)
+		assert.Equal(t, tc.expHands, resp.Hands, tc.desc)
+		assert.Equal(t, tc.expCrib, resp.Crib, tc.desc)
+	}
+}
+
 func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 	aliceID := model.PlayerID(`alice`)
 	bobID := model.PlayerID(`bob`)
@@ -314,7 +408,8 @@ func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 					Name: `bob`,
 				}},
 			}},
-			Phase: `Pegging`,
+			Phase:  `Pegging`,
+			Status: `active`,
 			BlockingPlayers: map[model.PlayerID]string{
 				aliceID: `PegCard`,
 			},
@@ -406,7 +501,8 @@ func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 					Name: `bob`,
 				}},
 			}},
-			Phase: `Pegging`,
+			Phase:  `Pegging`,
+			Status: `active`,
 			BlockingPlayers: map[model.PlayerID]string{
 				aliceID: `PegCard`,
 			},
@@ -522,7 +618,8 @@ func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 					Name: `bryan`,
 				}},
 			}},
-			Phase: `Counting`,
+			Phase:  `Counting`,
+			Status: `active`,
 			BlockingPlayers: map[model.PlayerID]string{
 				aliceID: `CountHand`,
 			},
@@ -644,7 +741,8 @@ func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 					Name: `robert`,
 				}},
 			}},
-			Phase: `CribCounting`,
+			Phase:  `CribCounting`,
+			Status: `active`,
 			BlockingPlayers: map[model.PlayerID]string{
 				bobID: `CountCrib`,
 			},
@@ -768,3 +866,71 @@ func TestConvertToGetGameResponseForPlayer(t *testing.T) {
 		assert.Equal(t, expGame, mg2, tc.desc)
 	}
 }
+
+func TestConvertFromGameRules(t *testing.T) {
+	tests := []struct {
+		desc     string
+		rules    *GameRules
+		expRules model.GameRules
+		expErr   bool
+	}{{
+		desc:     `no rules is the standard game`,
+		rules:    nil,
+		expRules: model.GameRules{},
+	}, {
+		desc: `variant`,
+		rules: &GameRules{
+			Variant: model.FiveCardVariant,
+		},
+		expRules: model.GameRules{
+			Variant:      model.FiveCardVariant,
+			WinningScore: 61,
+			HandSize:     5,
+			PoneBonus:    3,
+		},
+	}, {
+		desc: `variant with overrides`,
+		rules: &GameRules{
+			Variant:      model.ShortVariant,
+			WinningScore: 91,
+			SkunkLine:    61,
+		},
+		expRules: model.GameRules{
+			Variant:      model.ShortVariant,
+			WinningScore: 91,
+			HandSize:     6,
+			SkunkLine:    61,
+		},
+	}, {
+		desc: `unknown variant`,
+		rules: &GameRules{
+			Variant: `nine-card`,
+		},
+		expErr: true,
+	}, {
+		desc: `invalid override`,
+		rules: &GameRules{
+			HandSize: 10,
+		},
+		expErr: true,
+	}}
+	for _, tc := range tests {
+		rules, err := ConvertFromGameRules(tc.rules)
+		if tc.expErr {
+			assert.Error(t, err, tc.desc)
+			continue
+		}
+		assert.NoError(t, err, tc.desc)
+		assert.Equal(t, tc.expRules, rules, tc.desc)
+
+		g := model.Game{
+			Options: model.GameOptions{
+				Muggins: true,
+				Rules:   rules,
+			},
+		}
+		resp := ConvertToGetGameResponse(g)
+		assert.True(t, resp.Muggins, tc.desc)
+		assert.Equal(t, g.Options, ConvertFromGetGameResponse(resp).Options, tc.desc)
+	}
+}
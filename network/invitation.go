@@ -0,0 +1,72 @@
+package network
+
+import (
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// CreateInvitationRequest invites players to a game hosted by whoever sends it.
+// The players sit in the order they're invited, after the host.
+type CreateInvitationRequest struct {
+	PlayerIDs []model.PlayerID `json:"playerIDs"`
+	Muggins   bool             `json:"muggins,omitempty"`
+	Rules     *GameRules       `json:"rules,omitempty"`
+	TurnTimer *TurnTimer       `json:"turn_timer,omitempty"`
+}
+
+// JoinLobbyRequest looks for a game with other players who want the same kind of
+// game, such as a two player game to 121
+type JoinLobbyRequest struct {
+	NumPlayers int        `json:"num_players"`
+	Muggins    bool       `json:"muggins,omitempty"`
+	Rules      *GameRules `json:"rules,omitempty"`
+	TurnTimer  *TurnTimer `json:"turn_timer,omitempty"`
+}
+
+type GetInvitationResponse struct {
+	ID         model.InvitationID `json:"id"`
+	Host       model.PlayerID     `json:"host"`
+	PlayerIDs  []model.PlayerID   `json:"playerIDs"`
+	Accepted   []model.PlayerID   `json:"accepted"`
+	NumPlayers int                `json:"num_players"`
+	Open       bool               `json:"open,omitempty"`
+	Muggins    bool               `json:"muggins,omitempty"`
+	Rules      *GameRules         `json:"rules,omitempty"`
+	TurnTimer  *TurnTimer         `json:"turn_timer,omitempty"`
+	Status     string             `json:"status"`
+	Expires    time.Time          `json:"expires"`
+	// GameID is set once everyone has accepted, and the game has been created
+	GameID model.GameID `json:"gameID,omitempty"`
+}
+
+type GetInvitationsResponse struct {
+	Invitations []GetInvitationResponse `json:"invitations"`
+}
+
+func ConvertToGetInvitationResponse(inv model.Invitation) GetInvitationResponse {
+	return GetInvitationResponse{
+		ID:         inv.ID,
+		Host:       inv.Host,
+		PlayerIDs:  inv.PlayerIDs,
+		Accepted:   inv.Accepted,
+		NumPlayers: inv.NumPlayers,
+		Open:       inv.Open,
+		Muggins:    inv.Options.Muggins,
+		Rules:      convertToGameRules(inv.Options.Rules),
+		TurnTimer:  convertToTurnTimer(inv.Options),
+		Status:     inv.Status.String(),
+		Expires:    inv.Expires,
+		GameID:     inv.GameID,
+	}
+}
+
+func ConvertToGetInvitationsResponse(invs []model.Invitation) GetInvitationsResponse {
+	resp := GetInvitationsResponse{
+		Invitations: make([]GetInvitationResponse, 0, len(invs)),
+	}
+	for _, inv := range invs {
+		resp.Invitations = append(resp.Invitations, ConvertToGetInvitationResponse(inv))
+	}
+	return resp
+}
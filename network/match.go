@@ -0,0 +1,35 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type CreateMatchRequest struct {
+	PlayerIDs []model.PlayerID `json:"playerIDs"`
+	BestOf    int              `json:"best_of"`
+	Muggins   bool             `json:"muggins,omitempty"`
+	Rules     *GameRules       `json:"rules,omitempty"`
+	TurnTimer *TurnTimer       `json:"turn_timer,omitempty"`
+}
+
+type GetMatchResponse struct {
+	ID         model.MatchID          `json:"id"`
+	PlayerIDs  []model.PlayerID       `json:"playerIDs"`
+	BestOf     int                    `json:"best_of"`
+	GameIDs    []model.GameID         `json:"gameIDs"`
+	Wins       map[model.PlayerID]int `json:"wins"`
+	GamePoints map[model.PlayerID]int `json:"game_points"`
+	Winners    []model.PlayerID       `json:"winners,omitempty"`
+}
+
+func ConvertToGetMatchResponse(m model.Match, ms model.MatchStandings) GetMatchResponse {
+	return GetMatchResponse{
+		ID:         m.ID,
+		PlayerIDs:  m.PlayerIDs,
+		BestOf:     m.BestOf,
+		GameIDs:    m.GameIDs,
+		Wins:       ms.Wins,
+		GamePoints: ms.GamePoints,
+		Winners:    ms.Winners,
+	}
+}
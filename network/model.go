@@ -6,4 +6,5 @@ type CreateInteractionRequest struct {
 	PlayerID      model.PlayerID `json:"playerID"`
 	LocalhostPort string         `json:"localhost_port,omitempty"`
 	NPCType       model.PlayerID `json:"npc_type,omitempty"`
+	Events        bool           `json:"events,omitempty"`
 }
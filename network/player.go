@@ -13,11 +13,43 @@ type Player struct {
 }
 
 type CreatePlayerRequest struct {
-	Player Player `json:"player"`
+	Player   Player `json:"player"`
+	Password string `json:"password"`
 }
 
 type CreatePlayerResponse struct {
-	Player Player `json:"player"`
+	Player  Player   `json:"player"`
+	Session *Session `json:"session,omitempty"`
+}
+
+// Session is what a signed in player sends to prove who they are. The token
+// goes in the Authorization header as "Bearer <token>".
+type Session struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+type LoginRequest struct {
+	Username model.PlayerID `json:"username"`
+	Password string         `json:"password"`
+}
+
+type LoginResponse struct {
+	Player  Player  `json:"player"`
+	Session Session `json:"session"`
+}
+
+func ConvertToLoginResponse(p model.Player, token string, expires time.Time) LoginResponse {
+	return LoginResponse{
+		Player: Player{
+			ID:   p.ID,
+			Name: p.Name,
+		},
+		Session: Session{
+			Token:   token,
+			Expires: expires,
+		},
+	}
 }
 
 func ConvertToCreatePlayerResponse(pm model.Player) CreatePlayerResponse {
@@ -51,6 +83,7 @@ type ActiveGamePlayer struct {
 type ActiveGame struct {
 	GameID   model.GameID       `json:"gameID"`
 	Players  []ActiveGamePlayer `json:"players"`
+	Status   string             `json:"status"`
 	Created  time.Time          `json:"created"`
 	LastMove time.Time          `json:"lastMove"`
 }
@@ -74,6 +107,29 @@ func ConvertToGetActiveGamesForPlayerResponse(
 	}
 }
 
+// GetGamesForPlayerResponse is the player's games that have the status
+type GetGamesForPlayerResponse struct {
+	Player Player       `json:"player"`
+	Status string       `json:"status"`
+	Games  []ActiveGame `json:"games"`
+}
+
+func ConvertToGetGamesForPlayerResponse(
+	p model.Player,
+	status model.GameStatus,
+	games map[model.GameID]model.Game,
+) GetGamesForPlayerResponse {
+
+	return GetGamesForPlayerResponse{
+		Player: Player{
+			ID:   p.ID,
+			Name: p.Name,
+		},
+		Status: convertToGameStatus(status),
+		Games:  convertToParticipatingGames(p, games),
+	}
+}
+
 func convertToParticipatingGames(
 	p model.Player,
 	games map[model.GameID]model.Game,
@@ -91,6 +147,7 @@ func convertToParticipatingGames(
 func getActiveGame(mg model.Game) ActiveGame {
 	ag := ActiveGame{
 		GameID: mg.ID,
+		Status: convertToGameStatus(mg.Status),
 	}
 	if len(mg.Actions) > 0 {
 		ag.Created = mg.Actions[0].TimeStamp
@@ -119,11 +176,15 @@ func convertToPlayers(pms []model.Player) []Player {
 func convertTeamsToPlayersAndPlayerColors(
 	ts []GetGameResponseTeam,
 ) ([]model.Player, map[model.PlayerID]model.PlayerColor) {
-	// length of 4 at most
-	players := make([]model.Player, 0, 4)
-	playerColors := make(map[model.PlayerID]model.PlayerColor, 4)
-	for _, t := range ts {
-		for _, p := range t.Players {
+	players := make([]model.Player, 0, model.MaxPlayerGame)
+	playerColors := make(map[model.PlayerID]model.PlayerColor, model.MaxPlayerGame)
+	// partners sit across from each other, so take turns between the teams
+	for i := 0; len(ts) > 0 && i < len(ts[0].Players); i++ {
+		for _, t := range ts {
+			if i >= len(t.Players) {
+				continue
+			}
+			p := t.Players[i]
 			players = append(players, convertFromPlayer(p))
 			playerColors[p.ID] = model.NewPlayerColorFromString(t.Color)
 		}
@@ -159,6 +159,7 @@ func TestConvertToGetActiveGamesForPlayerResponse(t *testing.T) {
 					Name:  `dave`,
 					Color: `blue`,
 				}},
+				Status:   `active`,
 				Created:  t1,
 				LastMove: t2,
 			}, {
@@ -172,6 +173,7 @@ func TestConvertToGetActiveGamesForPlayerResponse(t *testing.T) {
 					Name:  `chelsea`,
 					Color: `blue`,
 				}},
+				Status:   `active`,
 				Created:  t1,
 				LastMove: t1,
 			}, {
@@ -185,6 +187,7 @@ func TestConvertToGetActiveGamesForPlayerResponse(t *testing.T) {
 					Name:  `bob`,
 					Color: `blue`,
 				}},
+				Status:   `active`,
 				Created:  time.Time{},
 				LastMove: time.Time{},
 			}},
@@ -258,6 +261,7 @@ func TestConvertToGetActiveGamesForPlayerResponse(t *testing.T) {
 					Name:  `bob`,
 					Color: `blue`,
 				}},
+				Status:   `active`,
 				Created:  time.Time{},
 				LastMove: time.Time{},
 			}},
@@ -0,0 +1,51 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type LeaderboardEntry struct {
+	Rank       int     `json:"rank"`
+	Player     Player  `json:"player"`
+	NumPlayers int     `json:"num_players"`
+	Variant    string  `json:"variant"`
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Games      int     `json:"games"`
+}
+
+type GetLeaderboardResponse struct {
+	Offset  int                `json:"offset"`
+	Limit   int                `json:"limit"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+func ConvertToGetLeaderboardResponse(
+	offset, limit int,
+	ratings []model.Rating,
+	players map[model.PlayerID]model.Player,
+) GetLeaderboardResponse {
+
+	entries := make([]LeaderboardEntry, len(ratings))
+	for i, r := range ratings {
+		p := players[r.PlayerID]
+		entries[i] = LeaderboardEntry{
+			Rank: offset + i + 1,
+			Player: Player{
+				ID:   p.ID,
+				Name: p.Name,
+			},
+			NumPlayers: r.Pool.NumPlayers,
+			Variant:    r.Pool.Variant,
+			Rating:     r.Rating,
+			Deviation:  r.Deviation,
+			Games:      r.Games,
+		}
+	}
+
+	return GetLeaderboardResponse{
+		Offset:  offset,
+		Limit:   limit,
+		Entries: entries,
+	}
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// GameAction is an action a player took in a game. Which fields are set
+// depends on what the action overcame.
+type GameAction struct {
+	PlayerID  model.PlayerID `json:"player_id"`
+	Overcomes string         `json:"overcomes"`
+	TimeStamp time.Time      `json:"timestamp,omitempty"`
+
+	NumShuffles int     `json:"num_shuffles,omitempty"`
+	Cards       []Card  `json:"cards,omitempty"`
+	Percentage  float64 `json:"percentage,omitempty"`
+	Card        *Card   `json:"card,omitempty"`
+	SayGo       bool    `json:"say_go,omitempty"`
+	Points      *int    `json:"points,omitempty"`
+	Approve     bool    `json:"approve,omitempty"`
+	TimedOut    bool    `json:"timed_out,omitempty"`
+}
+
+// GameSnapshot is the state of a game after its first NumActions actions
+type GameSnapshot struct {
+	NumActions int `json:"num_actions"`
+	// LastAction is the action that led to this state. It's unset for the
+	// state before anyone has acted.
+	LastAction *GameAction     `json:"last_action,omitempty"`
+	Game       GetGameResponse `json:"game"`
+}
+
+type GetGameReplayResponse struct {
+	ID        model.GameID   `json:"id"`
+	Snapshots []GameSnapshot `json:"snapshots"`
+}
+
+// ConvertToGameSnapshot converts the state of a game at some point in its history. If
+// pID is set, then the snapshot is redacted to what that player could see at the time.
+func ConvertToGameSnapshot(g model.Game, pID model.PlayerID) (GameSnapshot, error) {
+	gs := GameSnapshot{
+		NumActions: g.NumActions(),
+	}
+	if n := g.NumActions(); n > 0 {
+		a := convertToGameAction(g.Actions[n-1], pID)
+		gs.LastAction = &a
+	}
+
+	if len(pID) == 0 {
+		gs.Game = ConvertToGetGameResponse(g)
+		return gs, nil
+	}
+
+	resp, err := ConvertToGetGameResponseForPlayer(g, pID)
+	if err != nil {
+		return GameSnapshot{}, err
+	}
+	gs.Game = resp
+
+	return gs, nil
+}
+
+// ConvertToGetGameReplayResponse converts every state of a game, in order. If pID is set,
+// then each snapshot is redacted to what that player could see at the time.
+func ConvertToGetGameReplayResponse(
+	gID model.GameID,
+	states []model.Game,
+	pID model.PlayerID,
+) (GetGameReplayResponse, error) {
+
+	resp := GetGameReplayResponse{
+		ID:        gID,
+		Snapshots: make([]GameSnapshot, 0, len(states)),
+	}
+	for _, g := range states {
+		gs, err := ConvertToGameSnapshot(g, pID)
+		if err != nil {
+			return GetGameReplayResponse{}, err
+		}
+		resp.Snapshots = append(resp.Snapshots, gs)
+	}
+
+	return resp, nil
+}
+
+// convertToGameAction converts the player action. The cards that a player put into
+// the crib are only shown to that player.
+func convertToGameAction(pa model.PlayerAction, viewer model.PlayerID) GameAction {
+	ga := GameAction{
+		PlayerID:  pa.ID,
+		Overcomes: convertToBlocker(pa.Overcomes),
+		TimeStamp: pa.TimeStamp,
+	}
+
+	switch a := pa.Action.(type) {
+	case model.DealAction:
+		ga.NumShuffles = a.NumShuffles
+	case model.BuildCribAction:
+		if pa.ID == viewer {
+			ga.Cards = convertToCards(a.Cards)
+			break
+		}
+		for range a.Cards {
+			ga.Cards = append(ga.Cards, invalidCard)
+		}
+	case model.CutDeckAction:
+		ga.Percentage = a.Percentage
+	case model.PegAction:
+		ga.SayGo = a.SayGo
+		if !a.SayGo {
+			ga.Card = convertToCardPtr(a.Card)
+		}
+	case model.CountHandAction:
+		ga.Points = &a.Pts
+	case model.CountCribAction:
+		ga.Points = &a.Pts
+	case model.CallMugginsAction:
+		ga.Points = &a.Pts
+	case model.UndoAction:
+		ga.Approve = a.Approve
+	case model.ForfeitAction:
+		ga.TimedOut = a.TimedOut
+	}
+
+	return ga
+}
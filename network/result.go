@@ -0,0 +1,70 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type GameResult struct {
+	Winner         string           `json:"winner"`
+	WinningPlayers []model.PlayerID `json:"winning_players,omitempty"`
+	WinnerScore    int              `json:"winner_score"`
+	LoserScore     int              `json:"loser_score"`
+	Margin         int              `json:"margin"`
+	Skunk          string           `json:"skunk"`
+	GamePoints     int              `json:"game_points"`
+	Forfeit        bool             `json:"forfeit,omitempty"`
+}
+
+type GetGameResultResponse struct {
+	GameID model.GameID `json:"gameID"`
+	GameResult
+}
+
+func ConvertToGetGameResultResponse(g model.Game, res model.GameResult) GetGameResultResponse {
+	return GetGameResultResponse{
+		GameID:     g.ID,
+		GameResult: convertToGameResult(g, res),
+	}
+}
+
+func convertToGameResult(g model.Game, res model.GameResult) GameResult {
+	var winners []model.PlayerID
+	for _, p := range g.Players {
+		if g.PlayerColors[p.ID] == res.Winner {
+			winners = append(winners, p.ID)
+		}
+	}
+	return GameResult{
+		Winner:         convertToColor(res.Winner),
+		WinningPlayers: winners,
+		WinnerScore:    res.WinnerScore,
+		LoserScore:     res.LoserScore,
+		Margin:         res.Margin,
+		Skunk:          res.Skunk.String(),
+		GamePoints:     res.GamePoints,
+		Forfeit:        res.Forfeit,
+	}
+}
+
+func convertToGameResultPtr(g model.Game) *GameResult {
+	if g.Result == nil {
+		return nil
+	}
+	res := convertToGameResult(g, *g.Result)
+	return &res
+}
+
+func convertFromGameResult(res *GameResult) *model.GameResult {
+	if res == nil {
+		return nil
+	}
+	return &model.GameResult{
+		Winner:      model.NewPlayerColorFromString(res.Winner),
+		WinnerScore: res.WinnerScore,
+		LoserScore:  res.LoserScore,
+		Margin:      res.Margin,
+		Skunk:       model.NewSkunkLevelFromString(res.Skunk),
+		GamePoints:  res.GamePoints,
+		Forfeit:     res.Forfeit,
+	}
+}
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestConvertToGetGameResultResponse(t *testing.T) {
+	g := model.Game{
+		ID: model.GameID(42),
+		Players: []model.Player{
+			{ID: `alice`},
+			{ID: `bob`},
+			{ID: `charlie`},
+			{ID: `diane`},
+		},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			`alice`:   model.Blue,
+			`bob`:     model.Red,
+			`charlie`: model.Blue,
+			`diane`:   model.Red,
+		},
+	}
+	res := model.GameResult{
+		Winner:      model.Red,
+		WinnerScore: 121,
+		LoserScore:  60,
+		Margin:      61,
+		Skunk:       model.DoubleSkunked,
+		GamePoints:  3,
+	}
+
+	resp := ConvertToGetGameResultResponse(g, res)
+	assert.Equal(t, GetGameResultResponse{
+		GameID: g.ID,
+		GameResult: GameResult{
+			Winner:         `red`,
+			WinningPlayers: []model.PlayerID{`bob`, `diane`},
+			WinnerScore:    121,
+			LoserScore:     60,
+			Margin:         61,
+			Skunk:          `double skunk`,
+			GamePoints:     3,
+		},
+	}, resp)
+
+	g.Result = &res
+	assert.Equal(t, &res, convertFromGameResult(ConvertToGetGameResponse(g).Result))
+}
@@ -0,0 +1,60 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// ScoreItem is one scoring combination in a hand or crib
+type ScoreItem struct {
+	Type        string `json:"type"`
+	Cards       []Card `json:"cards"`
+	Points      int    `json:"points"`
+	Description string `json:"description"`
+}
+
+type GetScoreResponse struct {
+	Hand        []Card      `json:"hand"`
+	CutCard     Card        `json:"cut"`
+	IsCrib      bool        `json:"crib"`
+	Points      int         `json:"points"`
+	Breakdown   []ScoreItem `json:"breakdown"`
+	Description string      `json:"description"`
+}
+
+func ConvertToGetScoreResponse(
+	cut model.Card,
+	hand []model.Card,
+	isCrib bool,
+	items []model.ScoreItem,
+) GetScoreResponse {
+
+	bd := convertToScoreItems(items)
+	if bd == nil {
+		bd = []ScoreItem{}
+	}
+
+	return GetScoreResponse{
+		Hand:        convertToCards(hand),
+		CutCard:     convertToCard(cut),
+		IsCrib:      isCrib,
+		Points:      model.TotalPoints(items),
+		Breakdown:   bd,
+		Description: model.DescribeScoreItems(items),
+	}
+}
+
+func convertToScoreItems(items []model.ScoreItem) []ScoreItem {
+	if items == nil {
+		return nil
+	}
+	sis := make([]ScoreItem, len(items))
+	for i, si := range items {
+		sis[i] = ScoreItem{
+			Type:        si.Type.String(),
+			Cards:       convertToCards(si.Cards),
+			Points:      si.Points,
+			Description: si.String(),
+		}
+	}
+	return sis
+}
@@ -0,0 +1,26 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// InviteSpectatorRequest lets another player watch the game. Only the players in
+// the game can invite spectators.
+type InviteSpectatorRequest struct {
+	PlayerID model.PlayerID `json:"playerID"`
+}
+
+type GetSpectatorsResponse struct {
+	GameID     model.GameID     `json:"gameID"`
+	Spectators []model.PlayerID `json:"spectators"`
+}
+
+func ConvertToGetSpectatorsResponse(gID model.GameID, pIDs []model.PlayerID) GetSpectatorsResponse {
+	if pIDs == nil {
+		pIDs = []model.PlayerID{}
+	}
+	return GetSpectatorsResponse{
+		GameID:     gID,
+		Spectators: pIDs,
+	}
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type GetPlayerStatsResponse struct {
+	Player Player `json:"player"`
+
+	Games       int `json:"games"`
+	Wins        int `json:"wins"`
+	Losses      int `json:"losses"`
+	SkunksGiven int `json:"skunks_given"`
+	SkunksTaken int `json:"skunks_taken"`
+
+	AverageHandPoints float64 `json:"average_hand_points"`
+	AverageCribPoints float64 `json:"average_crib_points"`
+	AveragePegPoints  float64 `json:"average_peg_points"`
+	AveragePointsLeft float64 `json:"average_points_left"`
+
+	BestHand     int          `json:"best_hand"`
+	BestHandGame model.GameID `json:"best_hand_game,omitempty"`
+	TwentyNines  int          `json:"twenty_nines"`
+}
+
+func ConvertToGetPlayerStatsResponse(p model.Player, ps model.PlayerStats) GetPlayerStatsResponse {
+	return GetPlayerStatsResponse{
+		Player: Player{
+			ID:   p.ID,
+			Name: p.Name,
+		},
+		Games:             ps.Games(),
+		Wins:              ps.Wins,
+		Losses:            ps.Losses,
+		SkunksGiven:       ps.SkunksGiven,
+		SkunksTaken:       ps.SkunksTaken,
+		AverageHandPoints: ps.AverageHandPoints(),
+		AverageCribPoints: ps.AverageCribPoints(),
+		AveragePegPoints:  ps.AveragePegPoints(),
+		AveragePointsLeft: ps.AveragePointsLeft(),
+		BestHand:          ps.BestHand,
+		BestHandGame:      ps.BestHandGame,
+		TwentyNines:       ps.TwentyNines,
+	}
+}
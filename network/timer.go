@@ -0,0 +1,53 @@
+package network
+
+import (
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// TurnTimer limits how long each player has to act. The Policy is what happens when
+// they run out of time: "autoplay" (the default), "forfeit", or "nudge".
+type TurnTimer struct {
+	Seconds int    `json:"seconds"`
+	Policy  string `json:"policy,omitempty"`
+}
+
+// ConvertFromTurnTimer returns the options for a game with the turn timer. A nil
+// timer doesn't limit how long the players can take.
+func ConvertFromTurnTimer(tt *TurnTimer, opts model.GameOptions) (model.GameOptions, error) {
+	if tt == nil {
+		return opts, nil
+	}
+
+	tp, err := model.NewTimeoutPolicyFromString(tt.Policy)
+	if err != nil {
+		return model.GameOptions{}, err
+	}
+	opts.TurnTimeLimit = time.Duration(tt.Seconds) * time.Second
+	opts.TimeoutPolicy = tp
+
+	if err := opts.Validate(); err != nil {
+		return model.GameOptions{}, err
+	}
+	return opts, nil
+}
+
+func convertToTurnTimer(opts model.GameOptions) *TurnTimer {
+	if !opts.HasTurnTimer() {
+		return nil
+	}
+	return &TurnTimer{
+		Seconds: int(opts.TurnTimeLimit / time.Second),
+		Policy:  opts.TimeoutPolicy.String(),
+	}
+}
+
+func convertFromTurnTimer(tt *TurnTimer, opts model.GameOptions) model.GameOptions {
+	if tt == nil {
+		return opts
+	}
+	opts.TurnTimeLimit = time.Duration(tt.Seconds) * time.Second
+	opts.TimeoutPolicy, _ = model.NewTimeoutPolicyFromString(tt.Policy)
+	return opts
+}
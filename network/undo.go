@@ -0,0 +1,23 @@
+package network
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// UndoRequest asks to take back the last action the player took. If another
+// player has already asked, then it's this player's answer to that ask.
+type UndoRequest struct {
+	PlayerID model.PlayerID `json:"playerID"`
+	Approve  bool           `json:"approve,omitempty"`
+}
+
+func ConvertFromUndoRequest(gID model.GameID, ur UndoRequest) model.PlayerAction {
+	return model.PlayerAction{
+		GameID:    gID,
+		ID:        ur.PlayerID,
+		Overcomes: model.Undo,
+		Action: model.UndoAction{
+			Approve: ur.Approve,
+		},
+	}
+}
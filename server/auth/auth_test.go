@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestPasswords(t *testing.T) {
+	_, err := HashPassword(`short`)
+	assert.Equal(t, ErrPasswordTooShort, err)
+
+	hash, err := HashPassword(`correct horse`)
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte(`correct horse`), hash)
+
+	assert.NoError(t, CheckPassword(hash, `correct horse`))
+	assert.Equal(t, ErrWrongPassword, CheckPassword(hash, `battery staple`))
+	assert.Equal(t, ErrWrongPassword, CheckPassword(nil, `correct horse`))
+}
+
+func TestNewSigner(t *testing.T) {
+	_, err := NewSigner(nil, time.Hour)
+	assert.Equal(t, ErrMissingKey, err)
+	_, err = NewSigner([]byte(`key`), 0)
+	assert.Equal(t, ErrInvalidSession, err)
+
+	key, err := NewKey()
+	require.NoError(t, err)
+	assert.Len(t, key, KeyLength)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, err := NewSigner([]byte(`the signing key`), time.Hour)
+	require.NoError(t, err)
+
+	token, c, err := s.Sign(model.PlayerID(`alice`), now)
+	require.NoError(t, err)
+	assert.Equal(t, model.PlayerID(`alice`), c.PlayerID)
+	assert.True(t, c.IssuedAt.Equal(now))
+	assert.True(t, c.ExpiresAt.Equal(now.Add(time.Hour)))
+
+	got, err := s.Verify(token, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, model.PlayerID(`alice`), got.PlayerID)
+	assert.True(t, got.IssuedAt.Equal(now))
+
+	_, err = s.Verify(token, now.Add(time.Hour))
+	assert.Equal(t, ErrExpiredToken, err)
+
+	other, err := NewSigner([]byte(`another key`), time.Hour)
+	require.NoError(t, err)
+	_, err = other.Verify(token, now)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	bobToken, _, err := s.Sign(model.PlayerID(`bob`), now)
+	require.NoError(t, err)
+	forged := strings.Split(bobToken, `.`)[0] + `.` + strings.Split(token, `.`)[1]
+
+	for _, tok := range []string{``, `nope`, `a.b.c`, forged} {
+		_, err = s.Verify(tok, now)
+		assert.Equal(t, ErrInvalidToken, err, tok)
+	}
+}
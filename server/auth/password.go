@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MinPasswordLength is the fewest characters a password can have
+const MinPasswordLength = 8
+
+var (
+	ErrPasswordTooShort = errors.New(`password is too short`)
+	ErrWrongPassword    = errors.New(`wrong password`)
+)
+
+// HashPassword returns the hash of the password to store with the account
+func HashPassword(password string) ([]byte, error) {
+	if len(password) < MinPasswordLength {
+		return nil, ErrPasswordTooShort
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+// CheckPassword returns ErrWrongPassword if the password doesn't match the hash. An
+// account without a hash can't be signed into with any password.
+func CheckPassword(hash []byte, password string) error {
+	if len(hash) == 0 {
+		return ErrWrongPassword
+	}
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return ErrWrongPassword
+	}
+	return err
+}
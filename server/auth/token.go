@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// KeyLength is how many bytes a generated signing key has
+const KeyLength = 32
+
+var (
+	ErrInvalidToken   = errors.New(`invalid token`)
+	ErrExpiredToken   = errors.New(`token has expired`)
+	ErrMissingKey     = errors.New(`signing key is required`)
+	ErrInvalidSession = errors.New(`session duration must be positive`)
+)
+
+// Claims are what a session token says about who is holding it
+type Claims struct {
+	PlayerID  model.PlayerID `json:"sub"`
+	IssuedAt  time.Time      `json:"iat"`
+	ExpiresAt time.Time      `json:"exp"`
+}
+
+// Signer issues session tokens, and verifies the ones it issued
+type Signer struct {
+	key      []byte
+	duration time.Duration
+}
+
+// NewSigner returns a signer that issues tokens good for the duration
+func NewSigner(key []byte, duration time.Duration) (*Signer, error) {
+	if len(key) == 0 {
+		return nil, ErrMissingKey
+	}
+	if duration <= 0 {
+		return nil, ErrInvalidSession
+	}
+
+	return &Signer{
+		key:      key,
+		duration: duration,
+	}, nil
+}
+
+// NewKey returns a random signing key. Sessions signed with it won't be valid
+// after the server restarts with a different one.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Sign returns a token saying the holder is the player, along with its claims
+func (s *Signer) Sign(pID model.PlayerID, now time.Time) (string, Claims, error) {
+	c := Claims{
+		PlayerID:  pID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.duration),
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return ``, Claims{}, err
+	}
+
+	enc := base64.RawURLEncoding.EncodeToString(payload)
+	return enc + `.` + base64.RawURLEncoding.EncodeToString(s.mac(enc)), c, nil
+}
+
+// Verify returns the claims of a token that this signer issued, and that hasn't expired
+func (s *Signer) Verify(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, `.`)
+	if len(parts) != 2 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, s.mac(parts[0])) {
+		return Claims{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var c Claims
+	if err = json.Unmarshal(payload, &c); err != nil || len(c.PlayerID) == 0 {
+		return Claims{}, ErrInvalidToken
+	}
+	if !now.Before(c.ExpiresAt) {
+		return Claims{}, ErrExpiredToken
+	}
+
+	return c, nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	m := hmac.New(sha256.New, s.key)
+	_, _ = m.Write([]byte(payload))
+	return m.Sum(nil)
+}
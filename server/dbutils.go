@@ -2,14 +2,27 @@ package server
 
 import (
 	"context"
+	"errors"
 	"log"
+	"time"
 
+	"github.com/joshprzybyszewski/cribbage/logic/rating"
+	"github.com/joshprzybyszewski/cribbage/logic/stats"
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/server/interaction"
 	"github.com/joshprzybyszewski/cribbage/server/persistence"
 	"github.com/joshprzybyszewski/cribbage/server/play"
 )
 
+var (
+	errMatchGameInProgress = errors.New(`the current game in the match is not over`)
+	errMatchOver           = errors.New(`the match has already been decided`)
+	errNotInMatch          = errors.New(`only players in the match can start its games`)
+	errActionNotFound      = errors.New(`the game hasn't had that many actions`)
+	errInviterNotPlaying   = errors.New(`only players in the game can invite spectators`)
+	errSpectatorIsPlaying  = errors.New(`players cannot spectate their own game`)
+)
+
 func commitOrRollback(db persistence.DB, err *error) {
 	var err2 error
 	if *err != nil {
@@ -38,24 +51,422 @@ func handleAction(_ context.Context, db persistence.DB, action model.PlayerActio
 	if err != nil {
 		return err
 	}
-	err = play.HandleAction(&g, action, pAPIs)
+	observers, err := getObservers(db, g.ID)
+	if err != nil {
+		return err
+	}
+	wasOver := g.IsOver()
+	err = play.HandleAction(&g, action, pAPIs, observers...)
+	if err != nil {
+		return err
+	}
+	err = db.SaveGame(g)
+	if err != nil {
+		return err
+	}
+
+	if !wasOver && g.IsOver() {
+		err = recordFinishedGame(db, g, time.Now())
+	}
+	return err
+}
+
+// inviteSpectator lets the invitee watch the game, as long as the inviter is playing
+// in it. It returns everyone who is watching the game afterwards.
+func inviteSpectator(
+	_ context.Context,
+	db persistence.DB,
+	gID model.GameID,
+	inviter, invitee model.PlayerID,
+) ([]model.PlayerID, error) {
+
+	err := db.Start()
+	if err != nil {
+		return nil, err
+	}
+	defer commitOrRollback(db, &err)
+
+	g, err := db.GetGame(gID)
+	if err != nil {
+		return nil, err
+	}
+	if !isPlayerInGame(g, inviter) {
+		err = errInviterNotPlaying
+		return nil, err
+	}
+	if isPlayerInGame(g, invitee) {
+		err = errSpectatorIsPlaying
+		return nil, err
+	}
+	_, err = db.GetPlayer(invitee)
+	if err != nil {
+		return nil, err
+	}
+	err = db.AddSpectator(gID, invitee)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetSpectators(gID)
+}
+
+// isWatchingGame returns true if the player is playing in, or spectating, the game
+func isWatchingGame(_ context.Context, db persistence.DB, gID model.GameID, pID model.PlayerID) (bool, error) {
+	g, err := db.GetGame(gID)
+	if err != nil {
+		return false, err
+	}
+	if isPlayerInGame(g, pID) {
+		return true, nil
+	}
+
+	pIDs, err := db.GetSpectators(gID)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range pIDs {
+		if s == pID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func getSpectators(_ context.Context, db persistence.DB, gID model.GameID) ([]model.PlayerID, error) {
+	_, err := db.GetGame(gID)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetSpectators(gID)
+}
+
+// handleUndo asks to take back the last action, or answers that ask. It returns
+// the game as it is afterwards, which is an earlier state once the undo is settled.
+func handleUndo(_ context.Context, db persistence.DB, action model.PlayerAction) (model.Game, error) {
+	err := db.Start()
+	if err != nil {
+		return model.Game{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	g, err := db.GetGame(action.GameID)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	pAPIs, err := getPlayerAPIs(db, g.Players)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	var numActions uint
+	var rewind bool
+	numActions, rewind, err = play.HandleUndo(&g, action, pAPIs)
+	if err != nil {
+		return model.Game{}, err
+	}
+	if !rewind {
+		err = db.SaveGame(g)
+		return g, err
+	}
+
+	err = db.RewindGame(g.ID, numActions)
+	if err != nil {
+		return model.Game{}, err
+	}
+	g, err = db.GetGame(g.ID)
+	if err != nil || !g.Options.HasTurnTimer() {
+		return g, err
+	}
+
+	// the players shouldn't lose the time that they spent settling the undo
+	play.RestartDeadlines(&g, time.Now())
+	err = db.UpdateGameDeadlines(g.ID, g.Deadlines)
+	return g, err
+}
+
+// handleForfeit ends the game with the forfeiting player's team losing
+func handleForfeit(_ context.Context, db persistence.DB, action model.PlayerAction) error {
+	err := db.Start()
+	if err != nil {
+		return err
+	}
+	defer commitOrRollback(db, &err)
+
+	g, err := db.GetGame(action.GameID)
+	if err != nil {
+		return err
+	}
+
+	wasOver := g.IsOver()
+	err = play.HandleForfeit(&g, action)
+	if err != nil {
+		return err
+	}
+	err = db.SaveGame(g)
+	if err != nil {
+		return err
+	}
+
+	if !wasOver && g.IsOver() {
+		err = recordFinishedGame(db, g, time.Now())
+	}
+	return err
+}
+
+// recordFinishedGame keeps the stats and ratings of everyone in the game that just finished
+func recordFinishedGame(db persistence.DB, g model.Game, now time.Time) error {
+	err := recordStats(db, g)
+	if err != nil {
+		return err
+	}
+	return recordRatings(db, g, now)
+}
+
+// recordStats adds the game that just finished into the stats of everyone who played it
+func recordStats(db persistence.DB, g model.Game) error {
+	for _, p := range g.Players {
+		ps, err := db.GetPlayerStats(p.ID)
+		if err != nil {
+			if err != persistence.ErrStatsNotFound {
+				return err
+			}
+			// the finished game has already been saved, so it's tallied with the rest
+			ps, err = tallyStats(db, p.ID)
+			if err != nil {
+				return err
+			}
+		} else {
+			var gs model.PlayerStats
+			gs, err = stats.ForGame(g, p.ID)
+			if err != nil {
+				return err
+			}
+			ps.Add(gs)
+		}
+
+		err = db.SavePlayerStats(ps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordRatings rates everyone in the game that was just won, and keeps how their
+// ratings changed
+func recordRatings(db persistence.DB, g model.Game, now time.Time) error {
+	if g.Result == nil {
+		// nobody won an abandoned game, so it isn't rated
+		return nil
+	}
+
+	pool, ok := model.NewRatingPool(g)
+	if !ok {
+		// the game was played with custom rules, so it isn't rated
+		return nil
+	}
+
+	before := make(map[model.PlayerID]model.Rating, len(g.Players))
+	for _, p := range g.Players {
+		r, err := getRating(db, p.ID, pool)
+		if err != nil {
+			return err
+		}
+		before[p.ID] = r
+	}
+
+	after, err := rating.ForGame(g, before, now, *ratingPeriod)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range g.Players {
+		r := after[p.ID]
+		err = db.SaveRating(r)
+		if err != nil {
+			return err
+		}
+		err = db.AddRatingChange(model.RatingChange{
+			PlayerID:  p.ID,
+			Pool:      pool,
+			GameID:    g.ID,
+			Before:    before[p.ID].Rating,
+			After:     r.Rating,
+			Deviation: r.Deviation,
+			At:        now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRating returns the player's rating in the pool, or a new one if they've never played in it
+func getRating(db persistence.DB, pID model.PlayerID, pool model.RatingPool) (model.Rating, error) {
+	r, err := db.GetRating(pID, pool)
+	if err == persistence.ErrRatingNotFound {
+		return rating.New(pID, pool), nil
+	}
+	return r, err
+}
+
+// getLeaderboard returns a page of the leaderboard, with the players who hold each rating
+func getLeaderboard(
+	_ context.Context,
+	db persistence.DB,
+	f persistence.LeaderboardFilter,
+) ([]model.Rating, map[model.PlayerID]model.Player, error) {
+
+	ratings, err := db.GetLeaderboard(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	players := make(map[model.PlayerID]model.Player, len(ratings))
+	for _, r := range ratings {
+		if _, ok := players[r.PlayerID]; ok {
+			continue
+		}
+		var p model.Player
+		p, err = db.GetPlayer(r.PlayerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		players[r.PlayerID] = p
+	}
+
+	return ratings, players, nil
+}
+
+// getPlayerStats returns the player's stats. Players who finished games before
+// stats were kept have them tallied from those games the first time they're asked for.
+func getPlayerStats(
+	_ context.Context,
+	db persistence.DB,
+	pID model.PlayerID,
+) (model.Player, model.PlayerStats, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Player{}, model.PlayerStats{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	p, err := db.GetPlayer(pID)
+	if err != nil {
+		return model.Player{}, model.PlayerStats{}, err
+	}
+
+	ps, err := db.GetPlayerStats(pID)
+	if err != persistence.ErrStatsNotFound {
+		if err != nil {
+			return model.Player{}, model.PlayerStats{}, err
+		}
+		return p, ps, nil
+	}
+
+	ps, err = tallyStats(db, pID)
+	if err != nil {
+		return model.Player{}, model.PlayerStats{}, err
+	}
+	err = db.SavePlayerStats(ps)
+	if err != nil {
+		return model.Player{}, model.PlayerStats{}, err
+	}
+	return p, ps, nil
+}
+
+// tallyStats adds up the player's stats from every game they've finished
+func tallyStats(db persistence.DB, pID model.PlayerID) (model.PlayerStats, error) {
+	var games []model.Game
+	// abandoned games aren't tallied, since nobody played them
+	for _, status := range []model.GameStatus{
+		model.StatusCompleted,
+		model.StatusResigned,
+		model.StatusExpired,
+	} {
+		gIDs, err := db.GetPlayerGamesWithStatus(pID, status)
+		if err != nil {
+			return model.PlayerStats{}, err
+		}
+		for _, gID := range gIDs {
+			var g model.Game
+			g, err = db.GetGame(gID)
+			if err != nil {
+				return model.PlayerStats{}, err
+			}
+			games = append(games, g)
+		}
+	}
+
+	return stats.ForGames(games, pID)
+}
+
+// nudgePlayer reminds the player that the game is waiting on them, and
+// gives them more time to act
+func nudgePlayer(
+	_ context.Context,
+	db persistence.DB,
+	gID model.GameID,
+	pID model.PlayerID,
+	now time.Time,
+) error {
+
+	err := db.Start()
+	if err != nil {
+		return err
+	}
+	defer commitOrRollback(db, &err)
+
+	g, err := db.GetGame(gID)
+	if err != nil {
+		return err
+	}
+
+	pAPIs, err := getPlayerAPIs(db, g.Players)
+	if err != nil {
+		return err
+	}
+	err = play.Nudge(&g, pID, pAPIs, now)
 	if err != nil {
 		return err
 	}
-	return db.SaveGame(g)
+	return db.UpdateGameDeadlines(g.ID, g.Deadlines)
 }
 
-func createGame(_ context.Context, db persistence.DB, pIDs []model.PlayerID) (model.Game, error) {
+func createGame(
+	_ context.Context,
+	db persistence.DB,
+	pIDs []model.PlayerID,
+	opts model.GameOptions,
+) (model.Game, error) {
+
 	err := db.Start()
 	if err != nil {
 		return model.Game{}, err
 	}
 	defer commitOrRollback(db, &err)
 
-	var p model.Player
+	mg, err := startGame(db, pIDs, opts)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	return mg, nil
+}
+
+// startGame creates a new game within the db's current transaction
+func startGame(
+	db persistence.DB,
+	pIDs []model.PlayerID,
+	opts model.GameOptions,
+) (model.Game, error) {
+
 	players := make([]model.Player, len(pIDs))
 	for i, id := range pIDs {
-		p, err = db.GetPlayer(id)
+		p, err := db.GetPlayer(id)
 		if err != nil {
 			return model.Game{}, err
 		}
@@ -67,7 +478,7 @@ func createGame(_ context.Context, db persistence.DB, pIDs []model.PlayerID) (mo
 		return model.Game{}, err
 	}
 
-	mg, err := play.CreateGame(players, pAPIs)
+	mg, err := play.CreateGameWithOptions(players, opts, pAPIs)
 	if err != nil {
 		return model.Game{}, err
 	}
@@ -88,6 +499,35 @@ func getPlayer(_ context.Context, db persistence.DB, pID model.PlayerID) (model.
 	return db.GetPlayer(pID)
 }
 
+// getPlayerGamesWithStatus returns the player, and those of their games that have the status
+func getPlayerGamesWithStatus(
+	_ context.Context,
+	db persistence.DB,
+	pID model.PlayerID,
+	status model.GameStatus,
+) (model.Player, map[model.GameID]model.Game, error) {
+
+	p, err := db.GetPlayer(pID)
+	if err != nil {
+		return model.Player{}, nil, err
+	}
+
+	gIDs, err := db.GetPlayerGamesWithStatus(pID, status)
+	if err != nil {
+		return model.Player{}, nil, err
+	}
+
+	games := make(map[model.GameID]model.Game, len(gIDs))
+	for _, gID := range gIDs {
+		games[gID], err = db.GetGame(gID)
+		if err != nil {
+			return model.Player{}, nil, err
+		}
+	}
+
+	return p, games, nil
+}
+
 func saveInteraction(_ context.Context, db persistence.DB, pm interaction.PlayerMeans) error {
 	err := db.Start()
 	if err != nil {
@@ -98,12 +538,478 @@ func saveInteraction(_ context.Context, db persistence.DB, pm interaction.Player
 	return db.SaveInteraction(pm)
 }
 
-func createPlayer(_ context.Context, db persistence.DB, p model.Player) error {
+// createPlayer creates the player along with the account they sign in with
+func createPlayer(_ context.Context, db persistence.DB, p model.Player, a model.Account) error {
 	err := db.Start()
 	if err != nil {
 		return err
 	}
 	defer commitOrRollback(db, &err)
 
-	return db.CreatePlayer(p)
+	err = db.CreatePlayer(p)
+	if err != nil {
+		return err
+	}
+	err = db.CreateAccount(a)
+	return err
+}
+
+func getPlayerAccount(_ context.Context, db persistence.DB, pID model.PlayerID) (model.Player, model.Account, error) {
+	p, err := db.GetPlayer(pID)
+	if err != nil {
+		return model.Player{}, model.Account{}, err
+	}
+	a, err := db.GetAccount(pID)
+	if err != nil {
+		return model.Player{}, model.Account{}, err
+	}
+	return p, a, nil
+}
+
+// signOut ends every session the player started before now
+func signOut(_ context.Context, db persistence.DB, pID model.PlayerID, now time.Time) error {
+	err := db.Start()
+	if err != nil {
+		return err
+	}
+	defer commitOrRollback(db, &err)
+
+	a, err := db.GetAccount(pID)
+	if err != nil {
+		return err
+	}
+	a.SignedOutAt = now
+	err = db.SaveAccount(a)
+	return err
+}
+
+func getGameResult(_ context.Context, db persistence.DB, gID model.GameID) (model.Game, model.GameResult, error) {
+	g, err := db.GetGame(gID)
+	if err != nil {
+		return model.Game{}, model.GameResult{}, err
+	}
+	if g.Result != nil {
+		return g, *g.Result, nil
+	}
+
+	// games that finished before results were stored need theirs calculated
+	res, err := model.NewGameResult(&g)
+	if err != nil {
+		return model.Game{}, model.GameResult{}, err
+	}
+	return g, res, nil
+}
+
+// getGameAt returns the game as it was after its first numActions actions
+func getGameAt(_ context.Context, db persistence.DB, gID model.GameID, numActions uint) (model.Game, error) {
+	latest, err := db.GetGame(gID)
+	if err != nil {
+		return model.Game{}, err
+	}
+	if int(numActions) > latest.NumActions() {
+		return model.Game{}, errActionNotFound
+	}
+	if int(numActions) == latest.NumActions() {
+		return latest, nil
+	}
+
+	return db.GetGameAction(gID, numActions)
+}
+
+// getGameReplay returns every state the game has been in, starting with
+// the state before any actions were taken
+func getGameReplay(_ context.Context, db persistence.DB, gID model.GameID) ([]model.Game, error) {
+	latest, err := db.GetGame(gID)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]model.Game, 0, latest.NumActions()+1)
+	var g model.Game
+	for n := 0; n < latest.NumActions(); n++ {
+		g, err = db.GetGameAction(gID, uint(n))
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, g)
+	}
+
+	return append(states, latest), nil
+}
+
+func createMatch(
+	_ context.Context,
+	db persistence.DB,
+	pIDs []model.PlayerID,
+	bestOf int,
+	opts model.GameOptions,
+) (model.Match, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Match{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	g, err := startGame(db, pIDs, opts)
+	if err != nil {
+		return model.Match{}, err
+	}
+
+	m := model.Match{
+		ID:        model.NewMatchID(),
+		PlayerIDs: pIDs,
+		Options:   opts,
+		BestOf:    bestOf,
+		GameIDs:   []model.GameID{g.ID},
+	}
+	err = db.CreateMatch(m)
+	if err != nil {
+		return model.Match{}, err
+	}
+
+	return m, nil
+}
+
+func getMatch(
+	_ context.Context,
+	db persistence.DB,
+	mID model.MatchID,
+) (model.Match, model.MatchStandings, error) {
+
+	m, err := db.GetMatch(mID)
+	if err != nil {
+		return model.Match{}, model.MatchStandings{}, err
+	}
+
+	ms, err := getMatchStandings(db, m)
+	if err != nil {
+		return model.Match{}, model.MatchStandings{}, err
+	}
+
+	return m, ms, nil
+}
+
+func getMatchStandings(db persistence.DB, m model.Match) (model.MatchStandings, error) {
+	games := make([]model.Game, len(m.GameIDs))
+	for i, gID := range m.GameIDs {
+		g, err := db.GetGame(gID)
+		if err != nil {
+			return model.MatchStandings{}, err
+		}
+		games[i] = g
+	}
+
+	return m.Standings(games)
+}
+
+// startNextMatchGame begins the next game in the match for one of its players, so
+// long as the previous one is over and nobody has won the match yet
+func startNextMatchGame(
+	_ context.Context,
+	db persistence.DB,
+	mID model.MatchID,
+	pID model.PlayerID,
+) (model.Game, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Game{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	m, err := db.GetMatch(mID)
+	if err != nil {
+		return model.Game{}, err
+	}
+	if !isPlayerInMatch(m, pID) {
+		err = errNotInMatch
+		return model.Game{}, err
+	}
+
+	err = checkMatchCanContinue(db, m)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	g, err := startGame(db, m.PlayerIDs, m.Options)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	m.GameIDs = append(m.GameIDs, g.ID)
+	err = db.SaveMatch(m)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	return g, nil
+}
+
+func checkMatchCanContinue(db persistence.DB, m model.Match) error {
+	if len(m.GameIDs) > 0 {
+		last, err := db.GetGame(m.GameIDs[len(m.GameIDs)-1])
+		if err != nil {
+			return err
+		}
+		if !last.IsOver() {
+			return errMatchGameInProgress
+		}
+	}
+
+	ms, err := getMatchStandings(db, m)
+	if err != nil {
+		return err
+	}
+	if len(ms.Winners) > 0 {
+		return errMatchOver
+	}
+
+	return nil
+}
+
+// createInvitation invites the players to a game hosted by the host. The game isn't
+// created until every player has accepted.
+func createInvitation(
+	_ context.Context,
+	db persistence.DB,
+	host model.PlayerID,
+	invitees []model.PlayerID,
+	opts model.GameOptions,
+	expires time.Time,
+) (model.Invitation, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	for _, pID := range invitees {
+		_, err = db.GetPlayer(pID)
+		if err != nil {
+			return model.Invitation{}, err
+		}
+	}
+
+	inv, err := model.NewInvitation(host, invitees, opts, expires)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	err = db.CreateInvitation(inv)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	return inv, nil
+}
+
+func getInvitation(
+	_ context.Context,
+	db persistence.DB,
+	iID model.InvitationID,
+	now time.Time,
+) (model.Invitation, error) {
+
+	inv, err := db.GetInvitation(iID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	inv.Expire(now)
+	return inv, nil
+}
+
+// respondToInvitation has the player accept or decline the invitation. Once everyone
+// has accepted, the game is created.
+func respondToInvitation(
+	_ context.Context,
+	db persistence.DB,
+	iID model.InvitationID,
+	pID model.PlayerID,
+	accept bool,
+	now time.Time,
+) (model.Invitation, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	inv, err := db.GetInvitation(iID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	var respErr error
+	if accept {
+		respErr = inv.Accept(pID, now)
+	} else {
+		respErr = inv.Decline(pID, now)
+	}
+	if respErr == model.ErrInvitationExpired {
+		// the invitation is expired now, so that's saved even though the player
+		// couldn't respond to it
+		err = db.SaveInvitation(inv)
+		if err != nil {
+			return model.Invitation{}, err
+		}
+		return model.Invitation{}, respErr
+	}
+	if respErr != nil {
+		err = respErr
+		return model.Invitation{}, err
+	}
+
+	err = saveInvitation(db, &inv)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	return inv, nil
+}
+
+// saveInvitation saves the invitation within the db's current transaction, first
+// creating its game if everyone has accepted
+func saveInvitation(db persistence.DB, inv *model.Invitation) error {
+	if inv.IsReady() {
+		g, err := startGame(db, inv.PlayerIDs, inv.Options)
+		if err != nil {
+			return err
+		}
+		inv.Status = model.InvitationAccepted
+		inv.GameID = g.ID
+	}
+	return db.SaveInvitation(*inv)
+}
+
+// getPendingInvitations returns the invitations that are waiting on the player, or
+// on the players they've invited. Any that have run out of time are expired.
+func getPendingInvitations(
+	_ context.Context,
+	db persistence.DB,
+	pID model.PlayerID,
+	now time.Time,
+) ([]model.Invitation, error) {
+
+	err := db.Start()
+	if err != nil {
+		return nil, err
+	}
+	defer commitOrRollback(db, &err)
+
+	invs, err := db.GetPendingInvitations(pID)
+	if err != nil {
+		return nil, err
+	}
+	return expireInvitations(db, invs, now)
+}
+
+// getLobby returns the open invitations that are still waiting for players. Any
+// that have run out of time are expired.
+func getLobby(_ context.Context, db persistence.DB, now time.Time) ([]model.Invitation, error) {
+	err := db.Start()
+	if err != nil {
+		return nil, err
+	}
+	defer commitOrRollback(db, &err)
+
+	invs, err := db.GetOpenInvitations()
+	if err != nil {
+		return nil, err
+	}
+	return expireInvitations(db, invs, now)
+}
+
+// joinLobby seats the player at the first open invitation for the same kind of
+// game. If there isn't one, then they post a new one for others to join.
+func joinLobby(
+	_ context.Context,
+	db persistence.DB,
+	pID model.PlayerID,
+	numPlayers int,
+	opts model.GameOptions,
+	now, expires time.Time,
+) (model.Invitation, error) {
+
+	err := db.Start()
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	defer commitOrRollback(db, &err)
+
+	invs, err := db.GetOpenInvitations()
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	invs, err = expireInvitations(db, invs, now)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	inv, ok := findLobbyInvitation(invs, pID, numPlayers, opts)
+	if !ok {
+		inv, err = model.NewOpenInvitation(pID, numPlayers, opts, expires)
+		if err != nil {
+			return model.Invitation{}, err
+		}
+		err = db.CreateInvitation(inv)
+		if err != nil {
+			return model.Invitation{}, err
+		}
+		return inv, nil
+	}
+	if inv.HasPlayer(pID) {
+		// they're already waiting for this game
+		return inv, nil
+	}
+
+	err = inv.Accept(pID, now)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	err = saveInvitation(db, &inv)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	return inv, nil
+}
+
+// findLobbyInvitation returns the invitation to the same kind of game that the player
+// is already waiting at, or else the first one that they can join
+func findLobbyInvitation(
+	invs []model.Invitation,
+	pID model.PlayerID,
+	numPlayers int,
+	opts model.GameOptions,
+) (model.Invitation, bool) {
+
+	found := false
+	var first model.Invitation
+	for _, inv := range invs {
+		if inv.NumPlayers != numPlayers || !inv.Options.IsEquivalent(opts) {
+			continue
+		}
+		if inv.HasPlayer(pID) {
+			return inv, true
+		}
+		if !found {
+			first, found = inv, true
+		}
+	}
+	return first, found
+}
+
+// expireInvitations saves any of the invitations that have expired by now, and
+// returns the rest
+func expireInvitations(db persistence.DB, invs []model.Invitation, now time.Time) ([]model.Invitation, error) {
+	pending := make([]model.Invitation, 0, len(invs))
+	for _, inv := range invs {
+		if !inv.Expire(now) {
+			pending = append(pending, inv)
+			continue
+		}
+		if err := db.SaveInvitation(inv); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
 }
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+)
+
+const (
+	// how many events a subscriber can fall behind before we start dropping them
+	subscriberBufferSize = 32
+)
+
+var gameEvents = newEventHub()
+
+var _ interaction.EventPublisher = (*eventHub)(nil)
+
+// eventHub fans out the notifications for players in a game to everyone
+// subscribed to that player's events
+type eventHub struct {
+	lock sync.Mutex
+
+	subscribers map[model.GameID]map[*eventSubscriber]struct{}
+}
+
+type eventSubscriber struct {
+	pID    model.PlayerID
+	events chan interaction.Event
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscribers: map[model.GameID]map[*eventSubscriber]struct{}{},
+	}
+}
+
+func (h *eventHub) Publish(e interaction.Event) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for s := range h.subscribers[e.GameID] {
+		if s.pID != e.PlayerID {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			// a slow subscriber shouldn't hold up the game
+		}
+	}
+}
+
+func (h *eventHub) subscribe(gID model.GameID, pID model.PlayerID) *eventSubscriber {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	s := &eventSubscriber{
+		pID:    pID,
+		events: make(chan interaction.Event, subscriberBufferSize),
+	}
+	if _, ok := h.subscribers[gID]; !ok {
+		h.subscribers[gID] = map[*eventSubscriber]struct{}{}
+	}
+	h.subscribers[gID][s] = struct{}{}
+
+	return s
+}
+
+func (h *eventHub) unsubscribe(gID model.GameID, s *eventSubscriber) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.subscribers[gID], s)
+	if len(h.subscribers[gID]) == 0 {
+		delete(h.subscribers, gID)
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+)
+
+func TestEventHub(t *testing.T) {
+	h := newEventHub()
+	gID := model.GameID(1)
+
+	alice := h.subscribe(gID, `alice`)
+	bob := h.subscribe(gID, `bob`)
+	otherGame := h.subscribe(model.GameID(2), `alice`)
+
+	e := interaction.Event{
+		Type:     interaction.GameEvent,
+		GameID:   gID,
+		PlayerID: `alice`,
+	}
+	h.Publish(e)
+
+	require.Len(t, alice.events, 1)
+	assert.Equal(t, e, <-alice.events)
+	assert.Empty(t, bob.events)
+	assert.Empty(t, otherGame.events)
+
+	// a subscriber who falls behind doesn't block publishing
+	for i := 0; i < 2*subscriberBufferSize; i++ {
+		h.Publish(e)
+	}
+	assert.Len(t, alice.events, subscriberBufferSize)
+
+	h.unsubscribe(gID, alice)
+	h.unsubscribe(gID, bob)
+	assert.NotContains(t, h.subscribers, gID)
+	assert.Contains(t, h.subscribers, model.GameID(2))
+}
+
+func TestGinGetGameEvents(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 3)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	g, err := createGame(ctx, db, pIDs[:2], model.GameOptions{})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		url     string
+		authAs  model.PlayerID
+		expCode int
+		expErr  string
+	}{{
+		url:     fmt.Sprintf(`/game/%d/events`, g.ID),
+		authAs:  `p1`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Requires player`,
+	}, {
+		url:     `/game/123/events?player=p1`,
+		authAs:  `p1`,
+		expCode: http.StatusNotFound,
+		expErr:  `Game not found`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/events?player=p3`, g.ID),
+		authAs:  `p3`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Player not in game`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/events?player=p1`, g.ID),
+		authAs:  `p2`,
+		expCode: http.StatusForbidden,
+		expErr:  `Cannot view another player's hand`,
+	}} {
+		w := performAuthedRequest(t, cs, router, tc.authAs, `GET`, tc.url, nil)
+		assert.Equal(t, tc.expCode, w.Code, tc.url)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.url)
+	}
+
+	w, err := performRequest(router, `GET`, fmt.Sprintf(`/game/%d/events?player=p1`, g.ID), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	req, err := http.NewRequest(`GET`, fmt.Sprintf(`%s/game/%d/events?player=%s`, srv.URL, g.ID, pIDs[0]), nil)
+	require.NoError(t, err)
+	token, _, err := cs.signer.Sign(pIDs[0], time.Now())
+	require.NoError(t, err)
+	req.Header.Set(`Authorization`, bearerPrefix+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		gameEvents.lock.Lock()
+		defer gameEvents.lock.Unlock()
+		return len(gameEvents.subscribers[g.ID]) > 0
+	}, time.Second, time.Millisecond)
+
+	p, err := interaction.FromPlayerMeans(interaction.PlayerMeans{
+		PlayerID:      pIDs[0],
+		PreferredMode: interaction.ServerSentEvents,
+		Interactions: []interaction.Means{{
+			Mode: interaction.ServerSentEvents,
+			Info: gameEvents,
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.NotifyEvent(g, model.NewGameEvent(0, model.GoSaidEvent{
+		PlayerID: pIDs[1],
+	})))
+
+	r := bufio.NewReader(resp.Body)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event:event\n", line)
+	line, err = r.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, `data:{"type":"event"`), line)
+	assert.Contains(t, line, `"type":"GoSaid"`)
+}
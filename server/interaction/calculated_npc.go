@@ -9,8 +9,8 @@ var _ npc = (*calculatedNPC)(nil)
 
 type calculatedNPC struct{}
 
-func (npc *calculatedNPC) getBuildCribAction(hand []model.Card, isDealer bool) (model.BuildCribAction, error) {
-	return cribActionHelper(hand, Calc, isDealer)
+func (npc *calculatedNPC) getBuildCribAction(desired int, hand []model.Card, isDealer bool) (model.BuildCribAction, error) {
+	return cribActionHelper(desired, hand, Calc, isDealer)
 }
 
 func (npc *calculatedNPC) getPegAction(unpegged []model.Card, prevPegs []model.PeggedCard, curPeg int) model.PegAction {
@@ -8,10 +8,9 @@ var _ npc = (*dumbNPC)(nil)
 
 type dumbNPC struct{}
 
-func (npc *dumbNPC) getBuildCribAction(hand []model.Card, _ bool) (model.BuildCribAction, error) {
-	n := len(hand) - 4
+func (npc *dumbNPC) getBuildCribAction(desired int, hand []model.Card, _ bool) (model.BuildCribAction, error) {
 	return model.BuildCribAction{
-		Cards: hand[0:n],
+		Cards: hand[0:desired],
 	}, nil
 }
 
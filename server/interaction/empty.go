@@ -25,9 +25,6 @@ func (e *empty) ID() model.PlayerID {
 func (e *empty) NotifyBlocking(b model.Blocker, g model.Game, s string) error {
 	return nil
 }
-func (e *empty) NotifyMessage(g model.Game, s string) error {
-	return nil
-}
-func (e *empty) NotifyScoreUpdate(g model.Game, msgs ...string) error {
+func (e *empty) NotifyEvent(g model.Game, ge model.GameEvent) error {
 	return nil
 }
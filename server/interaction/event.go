@@ -0,0 +1,50 @@
+package interaction
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+type EventType string
+
+const (
+	BlockingEvent EventType = `blocking`
+	GameEvent     EventType = `event`
+)
+
+// Event is a notification for a player, serialized so that any client can consume it
+type Event struct {
+	Type     EventType      `json:"type"`
+	GameID   model.GameID   `json:"gameID"`
+	PlayerID model.PlayerID `json:"playerID"`
+
+	// Blocker and Message are only set for blocking events
+	Blocker string `json:"blocker,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	// Event is only set for game events
+	Event *network.GameEvent `json:"event,omitempty"`
+
+	// The state of the game when the event happened
+	Phase  string         `json:"phase"`
+	Scores map[string]int `json:"scores,omitempty"`
+}
+
+type EventPublisher interface {
+	Publish(Event)
+}
+
+func newEvent(t EventType, pID model.PlayerID, g model.Game) Event {
+	scores := make(map[string]int, len(g.CurrentScores))
+	for c, s := range g.CurrentScores {
+		scores[c.String()] = s
+	}
+
+	return Event{
+		Type:     t,
+		GameID:   g.ID,
+		PlayerID: pID,
+		Phase:    g.Phase.String(),
+		Scores:   scores,
+	}
+}
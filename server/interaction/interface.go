@@ -10,8 +10,19 @@ type Player interface {
 	ID() model.PlayerID
 
 	NotifyBlocking(model.Blocker, model.Game, string) error
-	NotifyMessage(model.Game, string) error
-	NotifyScoreUpdate(g model.Game, msgs ...string) error
+	NotifyEvent(model.Game, model.GameEvent) error
+}
+
+// Observer is told about the public events of a game that it's watching, but not playing
+type Observer interface {
+	ID() model.PlayerID
+
+	NotifyEvent(model.Game, model.GameEvent) error
+}
+
+// NewObserver returns an observer whose events are published for the spectator
+func NewObserver(pID model.PlayerID, pub EventPublisher) Observer {
+	return newSSEPlayer(pID, pub)
 }
 
 func New(pID model.PlayerID, m Means) PlayerMeans {
@@ -35,6 +46,12 @@ func FromPlayerMeans(pm PlayerMeans) (Player, error) {
 			return nil, errors.New(`player means info should contain an action handler, but it doesn't`)
 		}
 		return NewNPCPlayer(pID, ah)
+	case ServerSentEvents:
+		pub, ok := means.Info.(EventPublisher)
+		if !ok {
+			return nil, errors.New(`player means info should contain an event publisher, but it doesn't`)
+		}
+		return newSSEPlayer(pID, pub), nil
 	default:
 		return newUnimplemented(pID), nil
 	}
@@ -1,6 +1,8 @@
 package interaction
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
 )
 
 var _ Player = (*localhostPlayer)(nil)
@@ -41,17 +44,12 @@ func (lhp *localhostPlayer) NotifyBlocking(b model.Blocker, g model.Game, s stri
 	return lhp.notify(fmt.Sprintf(`blocking/%d`, g.ID), ioutil.NopCloser(strings.NewReader(s)))
 }
 
-func (lhp *localhostPlayer) NotifyMessage(g model.Game, msg string) error {
-	return lhp.notify(fmt.Sprintf(`message/%d`, g.ID), ioutil.NopCloser(strings.NewReader(msg)))
-}
-
-func (lhp *localhostPlayer) NotifyScoreUpdate(g model.Game, msgs ...string) error {
-	all := ``
-	for _, m := range msgs {
-		all += m
+func (lhp *localhostPlayer) NotifyEvent(g model.Game, ge model.GameEvent) error {
+	b, err := json.Marshal(network.ConvertToGameEvent(ge))
+	if err != nil {
+		return err
 	}
-	rc := ioutil.NopCloser(strings.NewReader(all))
-	return lhp.notify(fmt.Sprintf(`score/%d`, g.ID), rc)
+	return lhp.notify(fmt.Sprintf(`event/%d`, g.ID), bytes.NewReader(b))
 }
 
 func (lhp *localhostPlayer) notify(endpoint string, data io.Reader) error {
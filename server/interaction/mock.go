@@ -22,11 +22,7 @@ func (m *Mock) NotifyBlocking(b model.Blocker, g model.Game, s string) error {
 	args := m.Called(b, g, s)
 	return args.Error(0)
 }
-func (m *Mock) NotifyMessage(g model.Game, s string) error {
-	args := m.Called(g, s)
-	return args.Error(0)
-}
-func (m *Mock) NotifyScoreUpdate(g model.Game, msgs ...string) error {
-	args := m.Called(g, msgs)
+func (m *Mock) NotifyEvent(g model.Game, ge model.GameEvent) error {
+	args := m.Called(g, ge)
 	return args.Error(0)
 }
@@ -12,6 +12,9 @@ const (
 	Localhost Mode = 1
 	NPC       Mode = 2
 	Unknown   Mode = 3
+
+	// ServerSentEvents publishes the player's notifications as a stream of events
+	ServerSentEvents Mode = 4
 )
 
 type Mode int
@@ -34,6 +37,10 @@ func (m *Means) AddSerializedInfo(serInfo []byte) error {
 		// serInfo should represent an action handler for the NPC.
 		// It should be overwritten elsewhere to npcActionHandler
 		return nil
+	case ServerSentEvents:
+		// serInfo should represent the publisher for the events.
+		// It should be overwritten elsewhere to the server's event hub
+		return nil
 	default:
 		return fmt.Errorf(`unsupported Mode: %v`, m.Mode)
 
@@ -56,6 +63,9 @@ func (m *Means) GetSerializedInfo() ([]byte, error) {
 		// It should be a pointer to a struct that implements this interface
 		// so we can't serialize it.
 		return nil, nil
+	case ServerSentEvents:
+		// Info should be the EventPublisher, which we can't serialize either
+		return nil, nil
 	default:
 		return nil, fmt.Errorf(`unsupported Mode: %v`, m.Mode)
 	}
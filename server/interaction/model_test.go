@@ -33,6 +33,11 @@ func TestMeansSerializedInfo(t *testing.T) {
 		input: &Means{
 			Mode: NPC,
 		},
+	}, {
+		inputMode: ServerSentEvents,
+		input: &Means{
+			Mode: ServerSentEvents,
+		},
 	}}
 
 	for _, tc := range testCases {
@@ -0,0 +1,77 @@
+package interaction
+
+import (
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+var _ npc = (*monteCarloNPC)(nil)
+var _ gamePegger = (*monteCarloNPC)(nil)
+var _ gameCribBuilder = (*monteCarloNPC)(nil)
+
+// monteCarloNPC discards what it expects to score the most with, between its hand
+// and the crib, and searches through how the rest of the pegging could go before
+// it pegs each card
+type monteCarloNPC struct {
+	budget strategy.SearchBudget
+}
+
+// SetMonteCarloBudget limits how long the monte carlo NPC thinks about each card
+func SetMonteCarloBudget(b strategy.SearchBudget) {
+	npcs[MonteCarlo] = &monteCarloNPC{
+		budget: b,
+	}
+}
+
+func (npc *monteCarloNPC) getBuildCribAction(desired int, hand []model.Card, isDealer bool) (model.BuildCribAction, error) {
+	// without the rest of the game, we don't know how many players there are,
+	// so the crib points are worked out instead of looked up
+	return highestExpectedValueCribAction(desired, hand, 0, isDealer)
+}
+
+func (npc *monteCarloNPC) getGameBuildCribAction(
+	desired int,
+	g model.Game,
+	pID model.PlayerID,
+) (model.BuildCribAction, error) {
+
+	return highestExpectedValueCribAction(desired, g.Hands[pID], len(g.Players), g.CurrentDealer == pID)
+}
+
+func highestExpectedValueCribAction(
+	desired int,
+	hand []model.Card,
+	numPlayers int,
+	isDealer bool,
+) (model.BuildCribAction, error) {
+
+	getCribCards := strategy.PoneHighestExpectedValue
+	if isDealer {
+		getCribCards = strategy.DealerHighestExpectedValue
+	}
+
+	cards, err := getCribCards(desired, hand, numPlayers)
+	if err != nil {
+		return model.BuildCribAction{}, err
+	}
+	return model.BuildCribAction{
+		Cards: cards,
+	}, nil
+}
+
+func (npc *monteCarloNPC) getPegAction(unpegged []model.Card, prevPegs []model.PeggedCard, curPeg int) model.PegAction {
+	// without the rest of the game, the best we can do is what the calculated NPC does
+	card, sayGo := strategy.PegHighestCardNow(unpegged, prevPegs, curPeg)
+	return model.PegAction{
+		Card:  card,
+		SayGo: sayGo,
+	}
+}
+
+func (npc *monteCarloNPC) getGamePegAction(g model.Game, pID model.PlayerID) model.PegAction {
+	card, sayGo := strategy.PegMonteCarlo(g, pID, npc.budget)
+	return model.PegAction{
+		Card:  card,
+		SayGo: sayGo,
+	}
+}
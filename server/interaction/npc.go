@@ -6,14 +6,16 @@ import (
 	"time"
 
 	"github.com/joshprzybyszewski/cribbage/logic/scorer"
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/utils/rand"
 )
 
 const (
-	Dumb   model.PlayerID = `DumbNPC`
-	Simple model.PlayerID = `SimpleNPC`
-	Calc   model.PlayerID = `CalculatedNPC`
+	Dumb       model.PlayerID = `DumbNPC`
+	Simple     model.PlayerID = `SimpleNPC`
+	Calc       model.PlayerID = `CalculatedNPC`
+	MonteCarlo model.PlayerID = `MonteCarloNPC`
 )
 
 var (
@@ -24,6 +26,9 @@ var npcs = map[model.PlayerID]npc{
 	Dumb:   &dumbNPC{},
 	Simple: &simpleNPC{},
 	Calc:   &calculatedNPC{},
+	MonteCarlo: &monteCarloNPC{
+		budget: strategy.DefaultSearchBudget,
+	},
 }
 
 var _ Player = (*NPCPlayer)(nil)
@@ -70,11 +75,8 @@ func (npc *NPCPlayer) NotifyBlocking(b model.Blocker, g model.Game, s string) er
 	return nil
 }
 
-// The NPC doesn't care about messages or score updates
-func (npc *NPCPlayer) NotifyMessage(g model.Game, s string) error {
-	return nil
-}
-func (npc *NPCPlayer) NotifyScoreUpdate(g model.Game, msgs ...string) error {
+// The NPC doesn't care about what happens in the game
+func (npc *NPCPlayer) NotifyEvent(g model.Game, ge model.GameEvent) error {
 	return nil
 }
 
@@ -94,19 +96,33 @@ func getUnpeggedCards(hand []model.Card, pc []model.PeggedCard) []model.Card {
 }
 
 func (npc *NPCPlayer) buildAction(b model.Blocker, g model.Game) (model.PlayerAction, error) {
+	return buildNPCAction(npc.player, npc.ID(), b, g)
+}
+
+// AutoPlayAction returns the action that the calculated NPC would take
+// in place of the player, for whatever they're blocking the game on
+func AutoPlayAction(pID model.PlayerID, g model.Game) (model.PlayerAction, error) {
+	b, ok := g.BlockingPlayers[pID]
+	if !ok {
+		return model.PlayerAction{}, errors.New(`player is not blocking the game`)
+	}
+	return buildNPCAction(npcs[Calc], pID, b, g)
+}
+
+func buildNPCAction(p npc, pID model.PlayerID, b model.Blocker, g model.Game) (model.PlayerAction, error) {
 	pa := model.PlayerAction{
 		GameID:    g.ID,
-		ID:        npc.ID(),
+		ID:        pID,
 		Overcomes: b,
 	}
-	myHand := g.Hands[npc.ID()]
+	myHand := g.Hands[pID]
 	switch b {
 	case model.DealCards:
 		pa.Action = model.DealAction{
 			NumShuffles: rand.Intn(10) + 1,
 		}
 	case model.CribCard:
-		bca, err := npc.player.getBuildCribAction(myHand, g.CurrentDealer == npc.ID())
+		bca, err := buildNPCCribAction(p, pID, g)
 		if err != nil {
 			return model.PlayerAction{}, err
 		}
@@ -116,8 +132,12 @@ func (npc *NPCPlayer) buildAction(b model.Blocker, g model.Game) (model.PlayerAc
 			Percentage: rand.Float64(),
 		}
 	case model.PegCard:
+		if gp, ok := p.(gamePegger); ok {
+			pa.Action = gp.getGamePegAction(g, pID)
+			break
+		}
 		cardsLeft := getUnpeggedCards(myHand, g.PeggedCards)
-		pa.Action = npc.player.getPegAction(cardsLeft, g.PeggedCards, g.CurrentPeg())
+		pa.Action = p.getPegAction(cardsLeft, g.PeggedCards, g.CurrentPeg())
 	case model.CountHand:
 		pa.Action = model.CountHandAction{
 			Pts: scorer.HandPoints(g.CutCard, myHand),
@@ -126,6 +146,22 @@ func (npc *NPCPlayer) buildAction(b model.Blocker, g model.Game) (model.PlayerAc
 		pa.Action = model.CountCribAction{
 			Pts: scorer.CribPoints(g.CutCard, g.Crib),
 		}
+	case model.CallMuggins:
+		// the count we'd be calling muggins on hasn't been added to the game's
+		// actions yet when we're notified, so the NPC always passes
+		pa.Action = model.CallMugginsAction{}
+	case model.Undo:
+		// the NPC can't know whether the action was a misclick, so it denies the undo
+		pa.Action = model.UndoAction{}
 	}
 	return pa, nil
 }
+
+func buildNPCCribAction(p npc, pID model.PlayerID, g model.Game) (model.BuildCribAction, error) {
+	myHand := g.Hands[pID]
+	desired := len(myHand) - g.Options.Rules.KeptHandSize()
+	if cb, ok := p.(gameCribBuilder); ok {
+		return cb.getGameBuildCribAction(desired, g, pID)
+	}
+	return p.getBuildCribAction(desired, myHand, g.CurrentDealer == pID)
+}
@@ -7,15 +7,24 @@ import (
 )
 
 type npc interface {
-	getBuildCribAction(hand []model.Card, isDealer bool) (model.BuildCribAction, error)
+	getBuildCribAction(desired int, hand []model.Card, isDealer bool) (model.BuildCribAction, error)
 	getPegAction(unpegged []model.Card, prevPegs []model.PeggedCard, curPeg int) model.PegAction
 }
 
+// gamePegger is an npc that looks at the whole game to decide what to peg
+type gamePegger interface {
+	getGamePegAction(g model.Game, pID model.PlayerID) model.PegAction
+}
+
+// gameCribBuilder is an npc that looks at the whole game to decide what to give the crib
+type gameCribBuilder interface {
+	getGameBuildCribAction(desired int, g model.Game, pID model.PlayerID) (model.BuildCribAction, error)
+}
+
 type getCribCards func(desired int, hand []model.Card) ([]model.Card, error)
 
-func cribActionHelper(hand []model.Card, npc model.PlayerID, isDealer bool) (model.BuildCribAction, error) {
+func cribActionHelper(desired int, hand []model.Card, npc model.PlayerID, isDealer bool) (model.BuildCribAction, error) {
 	var cards []model.Card
-	n := len(hand) - 4
 	stratMap := map[model.PlayerID]map[bool][]getCribCards{
 		Simple: {
 			false: []getCribCards{
@@ -40,7 +49,7 @@ func cribActionHelper(hand []model.Card, npc model.PlayerID, isDealer bool) (mod
 	}
 	strats := stratMap[npc][isDealer]
 	idx := rand.Intn(len(strats))
-	cards, err := strats[idx](n, hand)
+	cards, err := strats[idx](desired, hand)
 	if err != nil {
 		return model.BuildCribAction{}, err
 	}
@@ -86,6 +86,9 @@ func TestDealAction(t *testing.T) {
 	}, {
 		desc: `test calculated npc`,
 		npc:  Calc,
+	}, {
+		desc: `test monte carlo npc`,
+		npc:  MonteCarlo,
 	}}
 	for _, tc := range tests {
 		p := createPlayer(t, tc.npc)
@@ -113,6 +116,9 @@ func TestCutAction(t *testing.T) {
 	}, {
 		desc: `test calculated npc`,
 		npc:  Calc,
+	}, {
+		desc: `test monte carlo npc`,
+		npc:  MonteCarlo,
 	}}
 	for _, tc := range tests {
 		p := createPlayer(t, tc.npc)
@@ -266,6 +272,11 @@ func TestPegTwice(t *testing.T) {
 		npc:   Calc,
 		g:     newGame(Calc, 2, make([]model.Card, 0)),
 		expGo: false,
+	}, {
+		desc:  `test monte carlo npc`,
+		npc:   MonteCarlo,
+		g:     newGame(MonteCarlo, 2, make([]model.Card, 0)),
+		expGo: false,
 	}}
 	for _, tc := range tests {
 		p := createPlayer(t, tc.npc)
@@ -317,6 +328,11 @@ func TestPegAction(t *testing.T) {
 		npc:   Calc,
 		g:     newGame(Calc, 2, make([]model.Card, 0)),
 		expGo: false,
+	}, {
+		desc:  `test monte carlo npc`,
+		npc:   MonteCarlo,
+		g:     newGame(MonteCarlo, 2, make([]model.Card, 0)),
+		expGo: false,
 	}, {
 		desc: `test dumb go`,
 		npc:  Dumb,
@@ -344,6 +360,15 @@ func TestPegAction(t *testing.T) {
 			model.NewCardFromString(`10h`),
 		}),
 		expGo: true,
+	}, {
+		desc: `test monte carlo go`,
+		npc:  MonteCarlo,
+		g: newGame(MonteCarlo, 2, []model.Card{
+			model.NewCardFromString(`10c`),
+			model.NewCardFromString(`10s`),
+			model.NewCardFromString(`10h`),
+		}),
+		expGo: true,
 	}}
 	for _, tc := range tests {
 		p := createPlayer(t, tc.npc)
@@ -401,6 +426,18 @@ func TestBuildCribAction(t *testing.T) {
 		isDealer:  true,
 		g:         newGame(Calc, 2, make([]model.Card, 0)),
 		expNCards: 2,
+	}, {
+		desc:      `test monte carlo npc, not dealer`,
+		npc:       MonteCarlo,
+		isDealer:  false,
+		g:         newGame(MonteCarlo, 2, make([]model.Card, 0)),
+		expNCards: 2,
+	}, {
+		desc:      `test monte carlo npc, dealer`,
+		npc:       MonteCarlo,
+		isDealer:  true,
+		g:         newGame(MonteCarlo, 2, make([]model.Card, 0)),
+		expNCards: 2,
 	}, {
 		desc:      `test 3 player game`,
 		npc:       Dumb,
@@ -413,6 +450,18 @@ func TestBuildCribAction(t *testing.T) {
 		isDealer:  false,
 		g:         newGame(Dumb, 4, make([]model.Card, 0)),
 		expNCards: 1,
+	}, {
+		desc:      `test monte carlo npc, 3 player game`,
+		npc:       MonteCarlo,
+		isDealer:  true,
+		g:         newGame(MonteCarlo, 3, make([]model.Card, 0)),
+		expNCards: 1,
+	}, {
+		desc:      `test monte carlo npc, 4 player game`,
+		npc:       MonteCarlo,
+		isDealer:  false,
+		g:         newGame(MonteCarlo, 4, make([]model.Card, 0)),
+		expNCards: 1,
 	}}
 	for _, tc := range tests {
 		p := createPlayer(t, tc.npc)
@@ -463,29 +512,7 @@ func TestNotifyBlocking(t *testing.T) {
 		assert.Nil(t, err)
 	}
 }
-func TestNotifyMessage(t *testing.T) {
-	tests := []struct {
-		desc string
-		npc  model.PlayerID
-	}{{
-		desc: `test dumb NPC`,
-		npc:  Dumb,
-	}, {
-		desc: `test simple NPC`,
-		npc:  Simple,
-	}, {
-		desc: `test calculated NPC`,
-		npc:  Calc,
-	}}
-
-	for _, tc := range tests {
-		p, err := NewNPCPlayer(tc.npc, NewNilHandler())
-		require.Nil(t, err)
-		err = p.NotifyMessage(model.Game{}, ``)
-		assert.Nil(t, err)
-	}
-}
-func TestNotifyScoreUpdate(t *testing.T) {
+func TestNotifyEvent(t *testing.T) {
 	tests := []struct {
 		desc string
 		npc  model.PlayerID
@@ -503,7 +530,7 @@ func TestNotifyScoreUpdate(t *testing.T) {
 	for _, tc := range tests {
 		p, err := NewNPCPlayer(tc.npc, NewNilHandler())
 		require.Nil(t, err)
-		err = p.NotifyScoreUpdate(model.Game{}, ``)
+		err = p.NotifyEvent(model.Game{}, model.GameEvent{})
 		assert.Nil(t, err)
 	}
 }
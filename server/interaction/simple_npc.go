@@ -10,8 +10,8 @@ var _ npc = (*simpleNPC)(nil)
 
 type simpleNPC struct{}
 
-func (npc *simpleNPC) getBuildCribAction(hand []model.Card, isDealer bool) (model.BuildCribAction, error) {
-	return cribActionHelper(hand, Simple, isDealer)
+func (npc *simpleNPC) getBuildCribAction(desired int, hand []model.Card, isDealer bool) (model.BuildCribAction, error) {
+	return cribActionHelper(desired, hand, Simple, isDealer)
 }
 
 func (npc *simpleNPC) getPegAction(unpegged []model.Card, prevPegs []model.PeggedCard, curPeg int) model.PegAction {
@@ -0,0 +1,40 @@
+package interaction
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+var _ Player = (*ssePlayer)(nil)
+
+type ssePlayer struct {
+	pID model.PlayerID
+	pub EventPublisher
+}
+
+func newSSEPlayer(pID model.PlayerID, pub EventPublisher) *ssePlayer {
+	return &ssePlayer{
+		pID: pID,
+		pub: pub,
+	}
+}
+
+func (sp *ssePlayer) ID() model.PlayerID {
+	return sp.pID
+}
+
+func (sp *ssePlayer) NotifyBlocking(b model.Blocker, g model.Game, s string) error {
+	e := newEvent(BlockingEvent, sp.pID, g)
+	e.Blocker = b.String()
+	e.Message = s
+	sp.pub.Publish(e)
+	return nil
+}
+
+func (sp *ssePlayer) NotifyEvent(g model.Game, ge model.GameEvent) error {
+	e := newEvent(GameEvent, sp.pID, g)
+	ne := network.ConvertToGameEvent(ge)
+	e.Event = &ne
+	sp.pub.Publish(e)
+	return nil
+}
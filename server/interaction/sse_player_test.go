@@ -0,0 +1,68 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+type testPublisher struct {
+	events []Event
+}
+
+func (tp *testPublisher) Publish(e Event) {
+	tp.events = append(tp.events, e)
+}
+
+func TestSSEPlayer(t *testing.T) {
+	pub := &testPublisher{}
+	p, err := FromPlayerMeans(PlayerMeans{
+		PlayerID:      `alice`,
+		PreferredMode: ServerSentEvents,
+		Interactions: []Means{{
+			Mode: ServerSentEvents,
+			Info: pub,
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.PlayerID(`alice`), p.ID())
+
+	g := model.Game{
+		ID:            model.GameID(7),
+		Phase:         model.Pegging,
+		CurrentScores: map[model.PlayerColor]int{model.Blue: 12, model.Red: 9},
+	}
+	scores := map[string]int{`blue`: 12, `red`: 9}
+
+	assert.NoError(t, p.NotifyBlocking(model.PegCard, g, `your turn`))
+	assert.NoError(t, p.NotifyEvent(g, model.NewGameEvent(4, model.GoSaidEvent{PlayerID: `bob`})))
+
+	assert.Equal(t, []Event{{
+		Type:     BlockingEvent,
+		GameID:   g.ID,
+		PlayerID: `alice`,
+		Blocker:  model.PegCard.String(),
+		Message:  `your turn`,
+		Phase:    `Pegging`,
+		Scores:   scores,
+	}, {
+		Type:     GameEvent,
+		GameID:   g.ID,
+		PlayerID: `alice`,
+		Event: &network.GameEvent{
+			Type:        `GoSaid`,
+			Action:      4,
+			Description: `bob said go`,
+			PlayerID:    `bob`,
+		},
+		Phase:  `Pegging`,
+		Scores: scores,
+	}}, pub.events)
+
+	_, err = FromPlayerMeans(New(`bob`, Means{Mode: ServerSentEvents}))
+	assert.EqualError(t, err, `player means info should contain an event publisher, but it doesn't`)
+}
@@ -22,10 +22,6 @@ func (u unimplemented) NotifyBlocking(model.Blocker, model.Game, string) error {
 	return nil
 }
 
-func (u unimplemented) NotifyMessage(model.Game, string) error {
-	return nil
-}
-
-func (u unimplemented) NotifyScoreUpdate(g model.Game, msgs ...string) error {
+func (u unimplemented) NotifyEvent(model.Game, model.GameEvent) error {
 	return nil
 }
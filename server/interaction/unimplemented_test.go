@@ -14,6 +14,5 @@ func TestUnimplemented(t *testing.T) {
 
 	assert.Equal(t, myID, p.ID())
 	assert.Nil(t, p.NotifyBlocking(model.DealCards, model.Game{}, ``))
-	assert.Nil(t, p.NotifyMessage(model.Game{}, ``))
-	assert.Nil(t, p.NotifyScoreUpdate(model.Game{}, ``, ``))
+	assert.Nil(t, p.NotifyEvent(model.Game{}, model.GameEvent{}))
 }
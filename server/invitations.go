@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var (
+	invitationDuration = flag.Duration(`invitation_duration`, 24*time.Hour, `How long players have to accept an invitation`) //nolint:lll
+)
+
+// POST /create/invitation
+func (cs *cribbageServer) ginPostCreateInvitation(c *gin.Context) {
+	var ir network.CreateInvitationRequest
+	err := c.ShouldBindJSON(&ir)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+	for i, pID := range ir.PlayerIDs {
+		if pID == model.InvalidPlayerID {
+			c.String(http.StatusBadRequest, `Invalid player ID at index %d`, i)
+			return
+		}
+	}
+	opts, ok := bindGameOptions(c, ir.Muggins, ir.Rules, ir.TurnTimer)
+	if !ok {
+		return
+	}
+	host, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	inv, err := createInvitation(ctx, db, host, ir.PlayerIDs, opts, time.Now().Add(*invitationDuration))
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationResponse(inv))
+}
+
+// GET /invitation/:invitationID
+func (cs *cribbageServer) ginGetInvitation(c *gin.Context) {
+	iID, err := getInvitationIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid InvitationID: %v`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	inv, err := getInvitation(ctx, db, iID, time.Now())
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationResponse(inv))
+}
+
+// POST /invitation/:invitationID/accept
+func (cs *cribbageServer) ginPostAcceptInvitation(c *gin.Context) {
+	cs.respondToInvitation(c, true)
+}
+
+// POST /invitation/:invitationID/decline
+func (cs *cribbageServer) ginPostDeclineInvitation(c *gin.Context) {
+	cs.respondToInvitation(c, false)
+}
+
+func (cs *cribbageServer) respondToInvitation(c *gin.Context, accept bool) {
+	iID, err := getInvitationIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid InvitationID: %v`, err)
+		return
+	}
+	pID, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	inv, err := respondToInvitation(ctx, db, iID, pID, accept, time.Now())
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationResponse(inv))
+}
+
+// GET /invitations
+// returns the invitations that are waiting on the signed in player, or on the
+// players they've invited
+func (cs *cribbageServer) ginGetPendingInvitations(c *gin.Context) {
+	pID, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	invs, err := getPendingInvitations(ctx, db, pID, time.Now())
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationsResponse(invs))
+}
+
+// GET /lobby
+func (cs *cribbageServer) ginGetLobby(c *gin.Context) {
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	invs, err := getLobby(ctx, db, time.Now())
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationsResponse(invs))
+}
+
+// POST /lobby
+// seats the signed in player at a game with others who want the same kind of game
+func (cs *cribbageServer) ginPostLobby(c *gin.Context) {
+	var lr network.JoinLobbyRequest
+	err := c.ShouldBindJSON(&lr)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+	if lr.NumPlayers < model.MinPlayerGame || lr.NumPlayers > model.MaxPlayerGame {
+		c.String(http.StatusBadRequest, `Invalid num players: %d`, lr.NumPlayers)
+		return
+	}
+	opts, ok := bindGameOptions(c, lr.Muggins, lr.Rules, lr.TurnTimer)
+	if !ok {
+		return
+	}
+	pID, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	now := time.Now()
+	inv, err := joinLobby(ctx, db, pID, lr.NumPlayers, opts, now, now.Add(*invitationDuration))
+	if err != nil {
+		writeInvitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetInvitationResponse(inv))
+}
+
+// bindGameOptions returns the options for a game with these settings. It returns
+// false if they aren't valid, after it has written the error response.
+func bindGameOptions(
+	c *gin.Context,
+	muggins bool,
+	r *network.GameRules,
+	tt *network.TurnTimer,
+) (model.GameOptions, bool) {
+
+	rules, err := network.ConvertFromGameRules(r)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid rules: %s`, err)
+		return model.GameOptions{}, false
+	}
+	opts, err := network.ConvertFromTurnTimer(tt, model.GameOptions{
+		Muggins: muggins,
+		Rules:   rules,
+	})
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid turn timer: %s`, err)
+		return model.GameOptions{}, false
+	}
+	return opts, true
+}
+
+func writeInvitationError(c *gin.Context, err error) {
+	switch err {
+	case persistence.ErrInvitationNotFound:
+		c.String(http.StatusNotFound, `Invitation not found`)
+	case persistence.ErrPlayerNotFound:
+		c.String(http.StatusNotFound, `Player not found`)
+	case model.ErrNotInvited:
+		c.String(http.StatusForbidden, `Player was not invited`)
+	case model.ErrInvalidNumPlayers,
+		model.ErrDuplicateInvitee,
+		model.ErrInvitationExpired,
+		model.ErrInvitationNotPending,
+		model.ErrInvitationFull:
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+	default:
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+	}
+}
+
+func getInvitationIDFromContext(c *gin.Context) (model.InvitationID, error) {
+	iIDStr := c.Param(`invitationID`)
+	n, err := strconv.ParseUint(iIDStr, 10, 32)
+	if err != nil {
+		return model.InvalidInvitationID, err
+	}
+	return model.InvitationID(n), nil
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+func TestGinPostCreateInvitation(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	seedPlayers(t, cs.dbFactory, 3)
+
+	w, err := performRequest(router, `POST`, `/create/invitation`, prepareBody(t, network.CreateInvitationRequest{
+		PlayerIDs: []model.PlayerID{`p2`},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	for _, tc := range []struct {
+		msg     string
+		req     network.CreateInvitationRequest
+		expCode int
+		expErr  string
+	}{{
+		msg:     `nobody invited`,
+		req:     network.CreateInvitationRequest{},
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: invalid number of players`,
+	}, {
+		msg: `invitee doesn't exist`,
+		req: network.CreateInvitationRequest{
+			PlayerIDs: []model.PlayerID{`p9`},
+		},
+		expCode: http.StatusNotFound,
+		expErr:  `Player not found`,
+	}, {
+		msg: `invited twice`,
+		req: network.CreateInvitationRequest{
+			PlayerIDs: []model.PlayerID{`p2`, `p2`},
+		},
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: players can only be invited once`,
+	}, {
+		msg: `invalid rules`,
+		req: network.CreateInvitationRequest{
+			PlayerIDs: []model.PlayerID{`p2`},
+			Rules:     &network.GameRules{Variant: `other`},
+		},
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid rules: unknown game variant`,
+	}} {
+		w := performAuthedRequest(t, cs, router, `p1`, `POST`, `/create/invitation`, prepareBody(t, tc.req))
+		assert.Equal(t, tc.expCode, w.Code, tc.msg)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+	}
+
+	body := prepareBody(t, network.CreateInvitationRequest{
+		PlayerIDs: []model.PlayerID{`p2`, `p3`},
+		Muggins:   true,
+	})
+	w = performAuthedRequest(t, cs, router, `p1`, `POST`, `/create/invitation`, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	var inv network.GetInvitationResponse
+	readBody(t, w.Body, &inv)
+	assert.Equal(t, model.PlayerID(`p1`), inv.Host)
+	assert.Equal(t, []model.PlayerID{`p1`, `p2`, `p3`}, inv.PlayerIDs)
+	assert.Equal(t, []model.PlayerID{`p1`}, inv.Accepted)
+	assert.Equal(t, 3, inv.NumPlayers)
+	assert.True(t, inv.Muggins)
+	assert.Equal(t, `pending`, inv.Status)
+	assert.Equal(t, model.InvalidGameID, inv.GameID)
+
+	w, err = performRequest(router, `GET`, fmt.Sprintf(`/invitation/%d`, inv.ID), nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	var got network.GetInvitationResponse
+	readBody(t, w.Body, &got)
+	assert.Equal(t, inv.ID, got.ID)
+
+	w, err = performRequest(router, `GET`, `/invitation/123`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `Invitation not found`, readError(t, w))
+}
+
+func TestInvitationResponses(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	seedPlayers(t, cs.dbFactory, 4)
+
+	invite := func(invitees ...model.PlayerID) network.GetInvitationResponse {
+		body := prepareBody(t, network.CreateInvitationRequest{PlayerIDs: invitees})
+		w := performAuthedRequest(t, cs, router, `p1`, `POST`, `/create/invitation`, body)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp network.GetInvitationResponse
+		readBody(t, w.Body, &resp)
+		return resp
+	}
+	respond := func(pID model.PlayerID, iID model.InvitationID, response string) *httptest.ResponseRecorder {
+		url := fmt.Sprintf(`/invitation/%d/%s`, iID, response)
+		return performAuthedRequest(t, cs, router, pID, `POST`, url, nil)
+	}
+	pending := func(pID model.PlayerID) []model.InvitationID {
+		w := performAuthedRequest(t, cs, router, pID, `GET`, `/invitations`, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp network.GetInvitationsResponse
+		readBody(t, w.Body, &resp)
+		var iIDs []model.InvitationID
+		for _, inv := range resp.Invitations {
+			iIDs = append(iIDs, inv.ID)
+		}
+		return iIDs
+	}
+
+	accepted := invite(`p2`, `p3`)
+	declined := invite(`p2`)
+	assert.ElementsMatch(t, []model.InvitationID{accepted.ID, declined.ID}, pending(`p2`))
+	assert.Equal(t, []model.InvitationID{accepted.ID}, pending(`p3`))
+	assert.Empty(t, pending(`p4`))
+
+	w, err := performRequest(router, `POST`, fmt.Sprintf(`/invitation/%d/accept`, accepted.ID), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = respond(`p4`, accepted.ID, `accept`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `Player was not invited`, readError(t, w))
+
+	// the game isn't created until everyone accepts
+	w = respond(`p2`, accepted.ID, `accept`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var inv network.GetInvitationResponse
+	readBody(t, w.Body, &inv)
+	assert.Equal(t, `pending`, inv.Status)
+	assert.Equal(t, model.InvalidGameID, inv.GameID)
+
+	w = respond(`p3`, accepted.ID, `accept`)
+	require.Equal(t, http.StatusOK, w.Code)
+	readBody(t, w.Body, &inv)
+	assert.Equal(t, `accepted`, inv.Status)
+	require.NotEqual(t, model.InvalidGameID, inv.GameID)
+	assert.Empty(t, pending(`p3`))
+
+	db, err := cs.dbFactory.New(context.Background())
+	require.NoError(t, err)
+	defer db.Close()
+	g, err := db.GetGame(inv.GameID)
+	require.NoError(t, err)
+	require.Len(t, g.Players, 3)
+	for i, pID := range []model.PlayerID{`p1`, `p2`, `p3`} {
+		assert.Equal(t, pID, g.Players[i].ID)
+	}
+
+	w = respond(`p2`, accepted.ID, `decline`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Error: invitation is no longer pending`, readError(t, w))
+
+	w = respond(`p2`, declined.ID, `decline`)
+	require.Equal(t, http.StatusOK, w.Code)
+	readBody(t, w.Body, &inv)
+	assert.Equal(t, `declined`, inv.Status)
+	assert.Empty(t, pending(`p2`))
+}
+
+func TestExpiredInvitation(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	accepted, err := createInvitation(ctx, db, pIDs[0], pIDs[1:], model.GameOptions{}, time.Now())
+	require.NoError(t, err)
+	declined, err := createInvitation(ctx, db, pIDs[0], pIDs[1:], model.GameOptions{}, time.Now())
+	require.NoError(t, err)
+
+	// responding to an expired invitation saves that it expired
+	for _, tc := range []struct {
+		id       model.InvitationID
+		response string
+	}{{
+		id:       accepted.ID,
+		response: `accept`,
+	}, {
+		id:       declined.ID,
+		response: `decline`,
+	}} {
+		w := performAuthedRequest(t, cs, router, pIDs[1], `POST`, fmt.Sprintf(`/invitation/%d/%s`, tc.id, tc.response), nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.response)
+		assert.Equal(t, `Error: invitation has expired`, readError(t, w), tc.response)
+
+		actual, err := db.GetInvitation(tc.id)
+		require.NoError(t, err)
+		assert.Equal(t, model.InvitationExpired, actual.Status, tc.response)
+	}
+
+	w := performAuthedRequest(t, cs, router, pIDs[1], `GET`, `/invitations`, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var invs network.GetInvitationsResponse
+	readBody(t, w.Body, &invs)
+	assert.Empty(t, invs.Invitations)
+}
+
+func TestLobby(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	seedPlayers(t, cs.dbFactory, 3)
+
+	join := func(pID model.PlayerID, req network.JoinLobbyRequest) network.GetInvitationResponse {
+		w := performAuthedRequest(t, cs, router, pID, `POST`, `/lobby`, prepareBody(t, req))
+		require.Equal(t, http.StatusOK, w.Code, pID)
+		var resp network.GetInvitationResponse
+		readBody(t, w.Body, &resp)
+		return resp
+	}
+	lobby := func() []network.GetInvitationResponse {
+		w, err := performRequest(router, `GET`, `/lobby`, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp network.GetInvitationsResponse
+		readBody(t, w.Body, &resp)
+		return resp.Invitations
+	}
+
+	w, err := performRequest(router, `POST`, `/lobby`, prepareBody(t, network.JoinLobbyRequest{NumPlayers: 2}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	body := prepareBody(t, network.JoinLobbyRequest{NumPlayers: 7})
+	w = performAuthedRequest(t, cs, router, `p1`, `POST`, `/lobby`, body)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Invalid num players: 7`, readError(t, w))
+
+	assert.Empty(t, lobby())
+
+	first := join(`p1`, network.JoinLobbyRequest{NumPlayers: 2})
+	assert.True(t, first.Open)
+	assert.Equal(t, []model.PlayerID{`p1`}, first.PlayerIDs)
+	assert.Equal(t, `pending`, first.Status)
+
+	// asking again doesn't post another game
+	again := join(`p1`, network.JoinLobbyRequest{NumPlayers: 2})
+	assert.Equal(t, first.ID, again.ID)
+
+	// someone who wants a different game waits for their own
+	short := join(`p2`, network.JoinLobbyRequest{
+		NumPlayers: 2,
+		Rules:      &network.GameRules{Variant: model.ShortVariant},
+	})
+	assert.NotEqual(t, first.ID, short.ID)
+	assert.Len(t, lobby(), 2)
+
+	// a two player game to 121 is the same as the standard game
+	matched := join(`p3`, network.JoinLobbyRequest{
+		NumPlayers: 2,
+		Rules:      &network.GameRules{WinningScore: 121},
+	})
+	assert.Equal(t, first.ID, matched.ID)
+	assert.Equal(t, []model.PlayerID{`p1`, `p3`}, matched.PlayerIDs)
+	assert.Equal(t, `accepted`, matched.Status)
+	assert.NotEqual(t, model.InvalidGameID, matched.GameID)
+
+	remaining := lobby()
+	require.Len(t, remaining, 1)
+	assert.Equal(t, short.ID, remaining[0].ID)
+}
@@ -31,7 +31,7 @@ func CreateGame(ctx context.Context, pIDs []model.PlayerID) (model.Game, error)
 	}
 	defer db.Close()
 
-	return createGame(ctx, db, pIDs)
+	return createGame(ctx, db, pIDs, model.GameOptions{})
 }
 
 func GetGame(ctx context.Context, gID model.GameID) (model.Game, error) {
@@ -19,4 +19,22 @@ var (
 
 	ErrInteractionNotFound      error = errors.New(`interaction not found`)
 	ErrInteractionAlreadyExists error = errors.New(`interaction already exists`)
+
+	ErrAccountNotFound      error = errors.New(`account not found`)
+	ErrAccountAlreadyExists error = errors.New(`account already exists`)
+
+	ErrAlreadySpectating error = errors.New(`player is already spectating`)
+
+	ErrStatsNotFound error = errors.New(`player stats not found`)
+
+	ErrRatingNotFound           error = errors.New(`rating not found`)
+	ErrInvalidLeaderboardFilter error = errors.New(`leaderboard filter invalid`)
+
+	ErrInvalidInvitationID     error = errors.New(`invitation id invalid`)
+	ErrInvitationNotFound      error = errors.New(`invitation not found`)
+	ErrInvitationAlreadyExists error = errors.New(`invitation already exists`)
+
+	ErrInvalidMatchID     error = errors.New(`match id invalid`)
+	ErrMatchNotFound      error = errors.New(`match not found`)
+	ErrMatchAlreadyExists error = errors.New(`match already exists`)
 )
@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/server/interaction"
@@ -39,22 +40,75 @@ type ServicesWrapper interface {
 	GetGame(id model.GameID) (model.Game, error)
 	GetGameAction(id model.GameID, numActions uint) (model.Game, error)
 	SaveGame(g model.Game) error
+	RewindGame(id model.GameID, numActions uint) error
+	UpdateGameDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error
+	GetOverdueGames(now time.Time) ([]model.GameID, error)
+	GetPlayerGamesWithStatus(pID model.PlayerID, status model.GameStatus) ([]model.GameID, error)
 
 	GetInteraction(id model.PlayerID) (interaction.PlayerMeans, error)
 	SaveInteraction(pm interaction.PlayerMeans) error
+
+	CreateMatch(m model.Match) error
+	GetMatch(id model.MatchID) (model.Match, error)
+	SaveMatch(m model.Match) error
+
+	CreateAccount(a model.Account) error
+	GetAccount(id model.PlayerID) (model.Account, error)
+	SaveAccount(a model.Account) error
+
+	GetSpectators(id model.GameID) ([]model.PlayerID, error)
+	AddSpectator(id model.GameID, pID model.PlayerID) error
+
+	CreateInvitation(inv model.Invitation) error
+	GetInvitation(id model.InvitationID) (model.Invitation, error)
+	GetPendingInvitations(pID model.PlayerID) ([]model.Invitation, error)
+	GetOpenInvitations() ([]model.Invitation, error)
+	SaveInvitation(inv model.Invitation) error
+
+	GetPlayerStats(id model.PlayerID) (model.PlayerStats, error)
+	SavePlayerStats(ps model.PlayerStats) error
+
+	GetRating(id model.PlayerID, pool model.RatingPool) (model.Rating, error)
+	SaveRating(r model.Rating) error
+	AddRatingChange(rc model.RatingChange) error
+	GetRatingHistory(id model.PlayerID) ([]model.RatingChange, error)
+	GetLeaderboard(f LeaderboardFilter) ([]model.Rating, error)
 }
 
 type services struct {
 	games        GameService
 	players      PlayerService
 	interactions InteractionService
+	matches      MatchService
+	accounts     AccountService
+	spectators   SpectatorService
+	invitations  InvitationService
+	stats        StatsService
+	ratings      RatingService
 }
 
-func NewServicesWrapper(gs GameService, ps PlayerService, is InteractionService) ServicesWrapper {
+func NewServicesWrapper(
+	gs GameService,
+	ps PlayerService,
+	is InteractionService,
+	ms MatchService,
+	as AccountService,
+	ss SpectatorService,
+	ivs InvitationService,
+	sts StatsService,
+	rs RatingService,
+) ServicesWrapper {
+
 	return &services{
 		games:        gs,
 		players:      ps,
 		interactions: is,
+		matches:      ms,
+		accounts:     as,
+		spectators:   ss,
+		invitations:  ivs,
+		stats:        sts,
+		ratings:      rs,
 	}
 }
 
@@ -156,6 +210,35 @@ func (d *services) SaveGame(g model.Game) error {
 	return d.games.Save(g)
 }
 
+func (d *services) RewindGame(id model.GameID, numActions uint) error {
+	return d.games.Rewind(id, numActions)
+}
+
+func (d *services) UpdateGameDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error {
+	return d.games.UpdateDeadlines(id, deadlines)
+}
+
+func (d *services) GetOverdueGames(now time.Time) ([]model.GameID, error) {
+	return d.games.GetOverdue(now)
+}
+
+func (d *services) GetPlayerGamesWithStatus(pID model.PlayerID, status model.GameStatus) ([]model.GameID, error) {
+	p, err := d.players.Get(pID)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Games) == 0 {
+		return nil, nil
+	}
+
+	gIDs := make([]model.GameID, 0, len(p.Games))
+	for gID := range p.Games {
+		gIDs = append(gIDs, gID)
+	}
+
+	return d.games.GetWithStatus(gIDs, status)
+}
+
 func (d *services) GetInteraction(id model.PlayerID) (interaction.PlayerMeans, error) {
 	return d.interactions.Get(id)
 }
@@ -163,3 +246,107 @@ func (d *services) GetInteraction(id model.PlayerID) (interaction.PlayerMeans, e
 func (d *services) SaveInteraction(pm interaction.PlayerMeans) error {
 	return d.interactions.Update(pm)
 }
+
+func (d *services) CreateMatch(m model.Match) error {
+	if m.ID == model.InvalidMatchID {
+		return ErrInvalidMatchID
+	}
+	return d.matches.Create(m)
+}
+
+func (d *services) GetMatch(id model.MatchID) (model.Match, error) {
+	return d.matches.Get(id)
+}
+
+func (d *services) SaveMatch(m model.Match) error {
+	return d.matches.Save(m)
+}
+
+func (d *services) CreateAccount(a model.Account) error {
+	if !model.IsValidPlayerID(a.PlayerID) {
+		return ErrInvalidPlayerID
+	}
+	return d.accounts.Create(a)
+}
+
+func (d *services) GetAccount(id model.PlayerID) (model.Account, error) {
+	return d.accounts.Get(id)
+}
+
+func (d *services) SaveAccount(a model.Account) error {
+	return d.accounts.Save(a)
+}
+
+func (d *services) GetSpectators(id model.GameID) ([]model.PlayerID, error) {
+	return d.spectators.Get(id)
+}
+
+func (d *services) AddSpectator(id model.GameID, pID model.PlayerID) error {
+	if !model.IsValidPlayerID(pID) {
+		return ErrInvalidPlayerID
+	}
+	return d.spectators.Add(id, pID)
+}
+
+func (d *services) CreateInvitation(inv model.Invitation) error {
+	if inv.ID == model.InvalidInvitationID {
+		return ErrInvalidInvitationID
+	}
+	return d.invitations.Create(inv)
+}
+
+func (d *services) GetInvitation(id model.InvitationID) (model.Invitation, error) {
+	return d.invitations.Get(id)
+}
+
+func (d *services) GetPendingInvitations(pID model.PlayerID) ([]model.Invitation, error) {
+	return d.invitations.GetPending(pID)
+}
+
+func (d *services) GetOpenInvitations() ([]model.Invitation, error) {
+	return d.invitations.GetOpen()
+}
+
+func (d *services) SaveInvitation(inv model.Invitation) error {
+	return d.invitations.Save(inv)
+}
+
+func (d *services) GetPlayerStats(id model.PlayerID) (model.PlayerStats, error) {
+	return d.stats.Get(id)
+}
+
+func (d *services) SavePlayerStats(ps model.PlayerStats) error {
+	if !model.IsValidPlayerID(ps.PlayerID) {
+		return ErrInvalidPlayerID
+	}
+	return d.stats.Save(ps)
+}
+
+func (d *services) GetRating(id model.PlayerID, pool model.RatingPool) (model.Rating, error) {
+	return d.ratings.Get(id, pool)
+}
+
+func (d *services) SaveRating(r model.Rating) error {
+	if !model.IsValidPlayerID(r.PlayerID) {
+		return ErrInvalidPlayerID
+	}
+	return d.ratings.Save(r)
+}
+
+func (d *services) AddRatingChange(rc model.RatingChange) error {
+	if !model.IsValidPlayerID(rc.PlayerID) {
+		return ErrInvalidPlayerID
+	}
+	return d.ratings.AddChange(rc)
+}
+
+func (d *services) GetRatingHistory(id model.PlayerID) ([]model.RatingChange, error) {
+	return d.ratings.GetHistory(id)
+}
+
+func (d *services) GetLeaderboard(f LeaderboardFilter) ([]model.Rating, error) {
+	if f.Offset < 0 || f.Limit <= 0 || f.NumPlayers < 0 {
+		return nil, ErrInvalidLeaderboardFilter
+	}
+	return d.ratings.GetLeaderboard(f)
+}
@@ -40,6 +40,12 @@ func (dbf memDBF) New(context.Context) (persistence.DB, error) {
 		getGameService(),
 		getPlayerService(),
 		getInteractionService(),
+		getMatchService(),
+		getAccountService(),
+		getSpectatorService(),
+		getInvitationService(),
+		getStatsService(),
+		getRatingService(),
 	)
 
 	dbf.db = &memDB{
@@ -79,4 +85,10 @@ func Clear() {
 	gservice = nil
 	pservice = nil
 	iservice = nil
+	mservice = nil
+	aservice = nil
+	sservice = nil
+	ivservice = nil
+	stservice = nil
+	rservice = nil
 }
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var aservice *accountService
+var _ persistence.AccountService = (*accountService)(nil)
+
+type accountService struct {
+	lock sync.Mutex
+
+	accounts map[model.PlayerID]model.Account
+}
+
+func getAccountService() persistence.AccountService {
+	if aservice == nil {
+		aservice = &accountService{
+			accounts: map[model.PlayerID]model.Account{},
+		}
+	}
+	return aservice
+}
+
+func (as *accountService) Get(id model.PlayerID) (model.Account, error) {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	if a, ok := as.accounts[id]; ok {
+		return copyAccount(a), nil
+	}
+	return model.Account{}, persistence.ErrAccountNotFound
+}
+
+func (as *accountService) Create(a model.Account) error {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	if _, ok := as.accounts[a.PlayerID]; ok {
+		return persistence.ErrAccountAlreadyExists
+	}
+
+	as.accounts[a.PlayerID] = copyAccount(a)
+	return nil
+}
+
+func (as *accountService) Save(a model.Account) error {
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	if _, ok := as.accounts[a.PlayerID]; !ok {
+		return persistence.ErrAccountNotFound
+	}
+
+	as.accounts[a.PlayerID] = copyAccount(a)
+	return nil
+}
+
+func copyAccount(a model.Account) model.Account {
+	// don't let the caller modify what we've stored
+	a.PasswordHash = append([]byte(nil), a.PasswordHash...)
+	return a
+}
@@ -2,7 +2,9 @@ package memory
 
 import (
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/server/persistence"
@@ -32,7 +34,7 @@ func (gs *gameService) Get(id model.GameID) (model.Game, error) {
 
 	if games, ok := gs.games[id]; ok {
 		g := games[len(games)-1]
-		return g, nil
+		return copyGame(g), nil
 	}
 	return model.Game{}, persistence.ErrGameNotFound
 }
@@ -46,7 +48,7 @@ func (gs *gameService) GetAt(id model.GameID, numActions uint) (model.Game, erro
 			return model.Game{}, persistence.ErrGameNotFound
 		}
 		g := games[numActions]
-		return g, nil
+		return copyGame(g), nil
 	}
 	return model.Game{}, persistence.ErrGameNotFound
 }
@@ -94,11 +96,149 @@ func (gs *gameService) Save(g model.Game) error {
 		return err
 	}
 
-	gs.games[id] = append(gs.games[id], g)
+	gs.games[id] = append(gs.games[id], copyGame(g))
 
 	return nil
 }
 
+func (gs *gameService) Rewind(id model.GameID, numActions uint) error {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	games, ok := gs.games[id]
+	if !ok || int(numActions) >= len(games) {
+		return persistence.ErrGameNotFound
+	}
+	gs.games[id] = games[:numActions+1]
+
+	return nil
+}
+
+func (gs *gameService) UpdateDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	games, ok := gs.games[id]
+	if !ok {
+		return persistence.ErrGameNotFound
+	}
+	games[len(games)-1].Deadlines = copyDeadlines(deadlines)
+
+	return nil
+}
+
+func (gs *gameService) GetOverdue(now time.Time) ([]model.GameID, error) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	var gIDs []model.GameID
+	for id, games := range gs.games {
+		latest := games[len(games)-1]
+		if dl, ok := latest.NextDeadline(); ok && !dl.After(now) {
+			gIDs = append(gIDs, id)
+		}
+	}
+	sort.Slice(gIDs, func(i, j int) bool {
+		return gIDs[i] < gIDs[j]
+	})
+
+	return gIDs, nil
+}
+
+func (gs *gameService) GetWithStatus(ids []model.GameID, status model.GameStatus) ([]model.GameID, error) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	var gIDs []model.GameID
+	for _, id := range ids {
+		games, ok := gs.games[id]
+		if ok && games[len(games)-1].Status == status {
+			gIDs = append(gIDs, id)
+		}
+	}
+	sort.Slice(gIDs, func(i, j int) bool {
+		return gIDs[i] < gIDs[j]
+	})
+
+	return gIDs, nil
+}
+
+func copyGame(g model.Game) model.Game {
+	// don't let the caller modify what we've stored, so that
+	// every state of the game stays the way it was saved
+	if g.Players != nil {
+		g.Players = append(make([]model.Player, 0, len(g.Players)), g.Players...)
+	}
+	g.PlayerColors = copyPlayerColors(g.PlayerColors)
+	g.CurrentScores = copyScores(g.CurrentScores)
+	g.LagScores = copyScores(g.LagScores)
+	if g.BlockingPlayers != nil {
+		bps := make(map[model.PlayerID]model.Blocker, len(g.BlockingPlayers))
+		for pID, b := range g.BlockingPlayers {
+			bps[pID] = b
+		}
+		g.BlockingPlayers = bps
+	}
+	g.Deadlines = copyDeadlines(g.Deadlines)
+	if g.Hands != nil {
+		hands := make(map[model.PlayerID][]model.Card, len(g.Hands))
+		for pID, h := range g.Hands {
+			hands[pID] = append(make([]model.Card, 0, len(h)), h...)
+		}
+		g.Hands = hands
+	}
+	if g.Crib != nil {
+		g.Crib = append(make([]model.Card, 0, len(g.Crib)), g.Crib...)
+	}
+	if g.PeggedCards != nil {
+		g.PeggedCards = append(make([]model.PeggedCard, 0, len(g.PeggedCards)), g.PeggedCards...)
+	}
+	if g.Actions != nil {
+		g.Actions = append(make([]model.PlayerAction, 0, len(g.Actions)), g.Actions...)
+	}
+	if g.Events != nil {
+		g.Events = append(make([]model.GameEvent, 0, len(g.Events)), g.Events...)
+	}
+	if g.Result != nil {
+		res := *g.Result
+		g.Result = &res
+	}
+	return g
+}
+
+func copyPlayerColors(pcs map[model.PlayerID]model.PlayerColor) map[model.PlayerID]model.PlayerColor {
+	if pcs == nil {
+		return nil
+	}
+	cp := make(map[model.PlayerID]model.PlayerColor, len(pcs))
+	for pID, c := range pcs {
+		cp[pID] = c
+	}
+	return cp
+}
+
+func copyDeadlines(dls map[model.PlayerID]time.Time) map[model.PlayerID]time.Time {
+	if dls == nil {
+		return nil
+	}
+	cp := make(map[model.PlayerID]time.Time, len(dls))
+	for pID, dl := range dls {
+		cp[pID] = dl
+	}
+	return cp
+}
+
+func copyScores(scores map[model.PlayerColor]int) map[model.PlayerColor]int {
+	if scores == nil {
+		return nil
+	}
+	cp := make(map[model.PlayerColor]int, len(scores))
+	for c, s := range scores {
+		cp[c] = s
+	}
+	return cp
+}
+
 func validateGameState(savedGames []model.Game, newGameState model.Game) error {
 	if len(savedGames) != newGameState.NumActions() {
 		return persistence.ErrGameActionsOutOfOrder
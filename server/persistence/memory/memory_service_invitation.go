@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var ivservice *invitationService
+var _ persistence.InvitationService = (*invitationService)(nil)
+
+type invitationService struct {
+	lock sync.Mutex
+
+	invitations map[model.InvitationID]model.Invitation
+}
+
+func getInvitationService() persistence.InvitationService {
+	if ivservice == nil {
+		ivservice = &invitationService{
+			invitations: map[model.InvitationID]model.Invitation{},
+		}
+	}
+	return ivservice
+}
+
+func (is *invitationService) Get(id model.InvitationID) (model.Invitation, error) {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+
+	if inv, ok := is.invitations[id]; ok {
+		return copyInvitation(inv), nil
+	}
+	return model.Invitation{}, persistence.ErrInvitationNotFound
+}
+
+func (is *invitationService) GetPending(pID model.PlayerID) ([]model.Invitation, error) {
+	return is.getPending(func(inv model.Invitation) bool {
+		return inv.HasPlayer(pID)
+	}), nil
+}
+
+func (is *invitationService) GetOpen() ([]model.Invitation, error) {
+	return is.getPending(func(inv model.Invitation) bool {
+		return inv.Open
+	}), nil
+}
+
+func (is *invitationService) getPending(include func(model.Invitation) bool) []model.Invitation {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+
+	var invs []model.Invitation
+	for _, inv := range is.invitations {
+		if inv.Status == model.InvitationPending && include(inv) {
+			invs = append(invs, copyInvitation(inv))
+		}
+	}
+	// the oldest invitations expire first
+	sort.Slice(invs, func(i, j int) bool {
+		if invs[i].Expires.Equal(invs[j].Expires) {
+			return invs[i].ID < invs[j].ID
+		}
+		return invs[i].Expires.Before(invs[j].Expires)
+	})
+	return invs
+}
+
+func (is *invitationService) Create(inv model.Invitation) error {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+
+	if _, ok := is.invitations[inv.ID]; ok {
+		return persistence.ErrInvitationAlreadyExists
+	}
+
+	is.invitations[inv.ID] = copyInvitation(inv)
+	return nil
+}
+
+func (is *invitationService) Save(inv model.Invitation) error {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+
+	if _, ok := is.invitations[inv.ID]; !ok {
+		return persistence.ErrInvitationNotFound
+	}
+
+	is.invitations[inv.ID] = copyInvitation(inv)
+	return nil
+}
+
+func copyInvitation(inv model.Invitation) model.Invitation {
+	// don't let the caller modify what we've stored
+	inv.PlayerIDs = append([]model.PlayerID(nil), inv.PlayerIDs...)
+	inv.Accepted = append([]model.PlayerID(nil), inv.Accepted...)
+	return inv
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var mservice *matchService
+var _ persistence.MatchService = (*matchService)(nil)
+
+type matchService struct {
+	lock sync.Mutex
+
+	matches map[model.MatchID]model.Match
+}
+
+func getMatchService() persistence.MatchService {
+	if mservice == nil {
+		mservice = &matchService{
+			matches: map[model.MatchID]model.Match{},
+		}
+	}
+	return mservice
+}
+
+func (ms *matchService) Get(id model.MatchID) (model.Match, error) {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	if m, ok := ms.matches[id]; ok {
+		return copyMatch(m), nil
+	}
+	return model.Match{}, persistence.ErrMatchNotFound
+}
+
+func (ms *matchService) Create(m model.Match) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	if _, ok := ms.matches[m.ID]; ok {
+		return persistence.ErrMatchAlreadyExists
+	}
+
+	ms.matches[m.ID] = copyMatch(m)
+	return nil
+}
+
+func (ms *matchService) Save(m model.Match) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	if _, ok := ms.matches[m.ID]; !ok {
+		return persistence.ErrMatchNotFound
+	}
+
+	ms.matches[m.ID] = copyMatch(m)
+	return nil
+}
+
+func copyMatch(m model.Match) model.Match {
+	// don't let the caller modify what we've stored
+	m.PlayerIDs = append([]model.PlayerID(nil), m.PlayerIDs...)
+	m.GameIDs = append([]model.GameID(nil), m.GameIDs...)
+	return m
+}
@@ -51,6 +51,23 @@ func (ps *playerService) Create(p model.Player) error {
 }
 
 func (ps *playerService) BeginGame(gID model.GameID, players []model.Player) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	for _, p := range players {
+		pCopy, ok := ps.players[p.ID]
+		if !ok {
+			return persistence.ErrPlayerNotFound
+		}
+		if pCopy.Games == nil {
+			pCopy.Games = map[model.GameID]model.PlayerColor{}
+		}
+		if _, hasGame := pCopy.Games[gID]; !hasGame {
+			// the color gets set later
+			pCopy.Games[gID] = model.UnsetColor
+		}
+		ps.players[p.ID] = pCopy
+	}
 	return nil
 }
 
@@ -68,7 +85,7 @@ func (ps *playerService) UpdateGameColor(pID model.PlayerID, gID model.GameID, c
 		pCopy.Games = map[model.GameID]model.PlayerColor{}
 	}
 
-	if c, ok := pCopy.Games[gID]; !ok {
+	if c, ok := pCopy.Games[gID]; !ok || c == model.UnsetColor {
 		pCopy.Games[gID] = color
 		ps.players[pID] = pCopy
 	} else if c != color {
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var rservice *ratingService
+var _ persistence.RatingService = (*ratingService)(nil)
+
+type ratingService struct {
+	lock sync.Mutex
+
+	ratings map[model.PlayerID]map[model.RatingPool]model.Rating
+	history map[model.PlayerID][]model.RatingChange
+}
+
+func getRatingService() persistence.RatingService {
+	if rservice == nil {
+		rservice = &ratingService{
+			ratings: map[model.PlayerID]map[model.RatingPool]model.Rating{},
+			history: map[model.PlayerID][]model.RatingChange{},
+		}
+	}
+	return rservice
+}
+
+func (rs *ratingService) Get(id model.PlayerID, pool model.RatingPool) (model.Rating, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	if r, ok := rs.ratings[id][pool]; ok {
+		return r, nil
+	}
+	return model.Rating{}, persistence.ErrRatingNotFound
+}
+
+func (rs *ratingService) Save(r model.Rating) error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	if _, ok := rs.ratings[r.PlayerID]; !ok {
+		rs.ratings[r.PlayerID] = map[model.RatingPool]model.Rating{}
+	}
+	rs.ratings[r.PlayerID][r.Pool] = r
+	return nil
+}
+
+func (rs *ratingService) AddChange(rc model.RatingChange) error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.history[rc.PlayerID] = append(rs.history[rc.PlayerID], rc)
+	return nil
+}
+
+func (rs *ratingService) GetHistory(id model.PlayerID) ([]model.RatingChange, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	h := make([]model.RatingChange, len(rs.history[id]))
+	copy(h, rs.history[id])
+	return h, nil
+}
+
+func (rs *ratingService) GetLeaderboard(f persistence.LeaderboardFilter) ([]model.Rating, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	var all []model.Rating
+	for _, pools := range rs.ratings {
+		for pool, r := range pools {
+			if f.NumPlayers != 0 && pool.NumPlayers != f.NumPlayers {
+				continue
+			}
+			if f.Variant != `` && pool.Variant != f.Variant {
+				continue
+			}
+			all = append(all, r)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Rating != all[j].Rating {
+			return all[i].Rating > all[j].Rating
+		}
+		if all[i].PlayerID != all[j].PlayerID {
+			return all[i].PlayerID < all[j].PlayerID
+		}
+		if all[i].Pool.NumPlayers != all[j].Pool.NumPlayers {
+			return all[i].Pool.NumPlayers < all[j].Pool.NumPlayers
+		}
+		return all[i].Pool.Variant < all[j].Pool.Variant
+	})
+
+	if f.Offset >= len(all) {
+		return nil, nil
+	}
+	all = all[f.Offset:]
+	if len(all) > f.Limit {
+		all = all[:f.Limit]
+	}
+	return all, nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var sservice *spectatorService
+var _ persistence.SpectatorService = (*spectatorService)(nil)
+
+type spectatorService struct {
+	lock sync.Mutex
+
+	spectators map[model.GameID][]model.PlayerID
+}
+
+func getSpectatorService() persistence.SpectatorService {
+	if sservice == nil {
+		sservice = &spectatorService{
+			spectators: map[model.GameID][]model.PlayerID{},
+		}
+	}
+	return sservice
+}
+
+func (ss *spectatorService) Get(id model.GameID) ([]model.PlayerID, error) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	// don't let the caller modify what we've stored
+	return append([]model.PlayerID(nil), ss.spectators[id]...), nil
+}
+
+func (ss *spectatorService) Add(id model.GameID, pID model.PlayerID) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	for _, s := range ss.spectators[id] {
+		if s == pID {
+			return persistence.ErrAlreadySpectating
+		}
+	}
+
+	ss.spectators[id] = append(ss.spectators[id], pID)
+	return nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var stservice *statsService
+var _ persistence.StatsService = (*statsService)(nil)
+
+type statsService struct {
+	lock sync.Mutex
+
+	stats map[model.PlayerID]model.PlayerStats
+}
+
+func getStatsService() persistence.StatsService {
+	if stservice == nil {
+		stservice = &statsService{
+			stats: map[model.PlayerID]model.PlayerStats{},
+		}
+	}
+	return stservice
+}
+
+func (ss *statsService) Get(id model.PlayerID) (model.PlayerStats, error) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	if ps, ok := ss.stats[id]; ok {
+		return ps, nil
+	}
+	return model.PlayerStats{}, persistence.ErrStatsNotFound
+}
+
+func (ss *statsService) Save(ps model.PlayerStats) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	ss.stats[ps.PlayerID] = ps
+	return nil
+}
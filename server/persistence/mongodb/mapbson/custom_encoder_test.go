@@ -186,8 +186,10 @@ func TestCustomRegistryModelGame(t *testing.T) {
 		err = bson.UnmarshalWithRegistry(registry, data1, &actOutput)
 		require.NoError(t, err, tc.msg)
 		actOutput.Actions = nil
+		actOutput.Events = nil
 		expOutputNilled := tc.expOutput
 		expOutputNilled.Actions = nil
+		expOutputNilled.Events = nil
 		assert.Equal(t, expOutputNilled, actOutput, tc.msg)
 
 		// Test deserialize from DB-BSON into model.Game
@@ -16,10 +16,17 @@ import (
 )
 
 const (
-	dbName                     string = `cribbage`
-	gamesCollectionName        string = `games`
-	playersCollectionName      string = `players`
-	interactionsCollectionName string = `interactions`
+	dbName                      string = `cribbage`
+	gamesCollectionName         string = `games`
+	playersCollectionName       string = `players`
+	interactionsCollectionName  string = `interactions`
+	matchesCollectionName       string = `matches`
+	accountsCollectionName      string = `accounts`
+	spectatorsCollectionName    string = `spectators`
+	invitationsCollectionName   string = `invitations`
+	statsCollectionName         string = `stats`
+	ratingsCollectionName       string = `ratings`
+	ratingHistoryCollectionName string = `ratingHistory`
 )
 
 const (
@@ -73,11 +80,41 @@ func (mf mongoFactory) New(ctx context.Context) (persistence.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	ms, err := getMatchService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
+	as, err := getAccountService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := getSpectatorService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
+	ivs, err := getInvitationService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
+	sts, err := getStatsService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := getRatingService(ctx, sess, mdb, customRegistry)
+	if err != nil {
+		return nil, err
+	}
 
 	sw := persistence.NewServicesWrapper(
 		gs,
 		ps,
 		is,
+		ms,
+		as,
+		ss,
+		ivs,
+		sts,
+		rs,
 	)
 
 	mw := mongoWrapper{
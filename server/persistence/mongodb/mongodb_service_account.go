@@ -0,0 +1,110 @@
+//nolint:dupl
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	accountCollectionIndex string = `pID`
+)
+
+var _ persistence.AccountService = (*accountService)(nil)
+
+type accountService struct {
+	ctx     context.Context
+	session mongo.Session
+	col     *mongo.Collection
+}
+
+func getAccountService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.AccountService, error) {
+
+	col := mdb.Collection(accountsCollectionName, &options.CollectionOptions{
+		Registry: r,
+	})
+
+	idxs := col.Indexes()
+	hasIndex, err := hasCollectionIndex(ctx, idxs, accountCollectionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createCollectionIndex(ctx, idxs, accountCollectionIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &accountService{
+		ctx:     ctx,
+		session: session,
+		col:     col,
+	}, nil
+}
+
+func bsonAccountFilter(id model.PlayerID) interface{} {
+	return bson.M{accountCollectionIndex: id} // model.Account.PlayerID
+}
+
+func (as *accountService) Get(id model.PlayerID) (model.Account, error) {
+	result := model.Account{}
+	filter := bsonAccountFilter(id)
+	err := mongo.WithSession(as.ctx, as.session, func(sc mongo.SessionContext) error {
+		return as.col.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Account{}, persistence.ErrAccountNotFound
+		}
+		return model.Account{}, err
+	}
+	return result, nil
+}
+
+func (as *accountService) Create(a model.Account) error {
+	_, err := as.Get(a.PlayerID)
+	if err == nil {
+		return persistence.ErrAccountAlreadyExists
+	} else if err != persistence.ErrAccountNotFound {
+		return err
+	}
+
+	return mongo.WithSession(as.ctx, as.session, func(sc mongo.SessionContext) error {
+		ior, err := as.col.InsertOne(sc, a)
+		if err != nil {
+			return err
+		}
+		if ior.InsertedID == nil {
+			// not sure if this is the right thing to check
+			return errors.New(`account not saved`)
+		}
+
+		return nil
+	})
+}
+
+func (as *accountService) Save(a model.Account) error {
+	filter := bsonAccountFilter(a.PlayerID)
+	return mongo.WithSession(as.ctx, as.session, func(sc mongo.SessionContext) error {
+		sr := as.col.FindOneAndReplace(sc, filter, a)
+		if sr.Err() == mongo.ErrNoDocuments {
+			return persistence.ErrAccountNotFound
+		}
+		return sr.Err()
+	})
+}
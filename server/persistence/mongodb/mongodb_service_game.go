@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/joshprzybyszewski/cribbage/jsonutils"
 	"github.com/joshprzybyszewski/cribbage/model"
@@ -16,17 +17,79 @@ import (
 )
 
 const (
-	gameCollectionIndex string = `gameID`
+	gameCollectionIndex   string = `gameID`
+	nextDeadlineFieldName string = `nextDeadline`
+	statusFieldName       string = `status`
 )
 
 type gameList struct {
 	GameID model.GameID `bson:"gameID"`
 	Games  []model.Game `bson:"games,omitempty"`
+	// Events are every event in the latest state of the game. They're kept here
+	// instead of with each of the Games, which would each have a copy of all of
+	// the events before them.
+	Events []model.GameEvent `bson:"events,omitempty"`
+	// NextDeadline is the earliest deadline in the latest state of the
+	// game, so that overdue games can be found without decoding them
+	NextDeadline *time.Time `bson:"nextDeadline,omitempty"`
+	// Status is the status of the latest state of the game, so that
+	// games can be found by it without decoding them
+	Status model.GameStatus `bson:"status"`
+}
+
+// newGameList makes the list of each of the game's states
+func newGameList(id model.GameID, games []model.Game) gameList {
+	gl := gameList{
+		GameID: id,
+		Games:  make([]model.Game, 0, len(games)),
+	}
+	for _, g := range games {
+		gl.addGame(g)
+	}
+	return gl
+}
+
+// addGame adds the next state of the game, keeping any events it added in the list
+func (gl *gameList) addGame(g model.Game) {
+	if len(g.Events) > len(gl.Events) {
+		gl.Events = append(gl.Events, g.Events[len(gl.Events):]...)
+	}
+	g.Events = nil
+	gl.Games = append(gl.Games, g)
+}
+
+// eventsAt returns the events that a state of the game with the given number of
+// actions has, which are the ones that happened before any more actions were taken
+func eventsAt(evs []model.GameEvent, numActions int) []model.GameEvent {
+	n := 0
+	for n < len(evs) && evs[n].Action <= numActions {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	return append(make([]model.GameEvent, 0, n), evs[:n]...)
+}
+
+// withLatestFields sets the NextDeadline and Status from the latest state of the game
+func (gl gameList) withLatestFields() gameList {
+	gl.NextDeadline = nil
+	gl.Status = model.StatusActive
+	if len(gl.Games) == 0 {
+		return gl
+	}
+	latest := gl.Games[len(gl.Games)-1]
+	if dl, ok := latest.NextDeadline(); ok {
+		gl.NextDeadline = &dl
+	}
+	gl.Status = latest.Status
+	return gl
 }
 
 type persistedGameList struct {
-	GameID    model.GameID `bson:"gameID"`
-	TempGames []bson.M     `bson:"games,omitempty"`
+	GameID     model.GameID `bson:"gameID"`
+	TempGames  []bson.M     `bson:"games,omitempty"`
+	TempEvents []bson.M     `bson:"events,omitempty"`
 }
 
 func bsonGameIDFilter(id model.GameID) interface{} {
@@ -73,6 +136,18 @@ func getGameService(
 		}
 	}
 
+	// the timers look up games by their deadlines
+	hasIndex, err = hasCollectionIndex(ctx, idxs, nextDeadlineFieldName)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createCollectionIndex(ctx, idxs, nextDeadlineFieldName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &gameService{
 		ctx:     ctx,
 		session: session,
@@ -137,6 +212,11 @@ func (gs *gameService) getGameStates(id model.GameID, opts getGameOptions) ([]mo
 		opts.actions[len(pgl.TempGames)-1] = struct{}{}
 	}
 
+	evs, err := getGameEvents(pgl.TempEvents)
+	if err != nil {
+		return nil, err
+	}
+
 	gl := gameList{
 		GameID: id,
 		Games:  make([]model.Game, 0, len(pgl.TempGames)),
@@ -156,6 +236,10 @@ func (gs *gameService) getGameStates(id model.GameID, opts getGameOptions) ([]mo
 		if err != nil {
 			return nil, err
 		}
+		if len(g.Events) == 0 {
+			// games saved before the events were kept in the list have their own
+			g.Events = eventsAt(evs, g.NumActions())
+		}
 
 		gl.Games = append(gl.Games, g)
 	}
@@ -163,6 +247,25 @@ func (gs *gameService) getGameStates(id model.GameID, opts getGameOptions) ([]mo
 	return gl.Games, nil
 }
 
+func getGameEvents(tempEvents []bson.M) ([]model.GameEvent, error) {
+	evs := make([]model.GameEvent, 0, len(tempEvents))
+	for _, tempEvent := range tempEvents {
+		obj, err := json.Marshal(tempEvent)
+		if err != nil {
+			return nil, err
+		}
+
+		// each event is typed by its GameEventType, so we have to unmarshal
+		// them one at a time
+		ge, err := jsonutils.UnmarshalGameEvent(obj)
+		if err != nil {
+			return nil, err
+		}
+		evs = append(evs, ge)
+	}
+	return evs, nil
+}
+
 func (gs *gameService) UpdatePlayerColor(gID model.GameID, pID model.PlayerID, color model.PlayerColor) error {
 	g, err := gs.Get(gID)
 	if err != nil {
@@ -192,12 +295,8 @@ func (gs *gameService) UpdatePlayerColor(gID model.GameID, pID model.PlayerID, c
 	recentGame.PlayerColors[pID] = color
 
 	games[len(games)-1] = recentGame
-	newGameList := gameList{
-		GameID: gID,
-		Games:  games,
-	}
 
-	return gs.saveGameList(newGameList)
+	return gs.saveGameList(newGameList(gID, games))
 }
 
 func (gs *gameService) Begin(g model.Game) error {
@@ -223,12 +322,11 @@ func (gs *gameService) Save(g model.Game) error {
 			return persistence.ErrGameInitialSave
 		}
 
-		saved.GameID = g.ID
-		saved.Games = []model.Game{g}
+		saved = newGameList(g.ID, []model.Game{g})
 
 		return mongo.WithSession(gs.ctx, gs.session, func(sc mongo.SessionContext) error {
 			var ior *mongo.InsertOneResult
-			ior, err = gs.col.InsertOne(sc, saved)
+			ior, err = gs.col.InsertOne(sc, saved.withLatestFields())
 			if err != nil {
 				return err
 			}
@@ -254,11 +352,106 @@ func (gs *gameService) Save(g model.Game) error {
 		return err
 	}
 
-	saved.Games = append(saved.Games, g)
+	saved.addGame(g)
 
 	return gs.saveGameList(saved)
 }
 
+func (gs *gameService) Rewind(id model.GameID, numActions uint) error {
+	games, err := gs.getGameStates(id, getGameOptions{
+		all: true,
+	})
+	if err != nil {
+		return err
+	}
+	if int(numActions) >= len(games) {
+		return persistence.ErrGameNotFound
+	}
+
+	return gs.saveGameList(newGameList(id, games[:numActions+1]))
+}
+
+func (gs *gameService) UpdateDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error {
+	games, err := gs.getGameStates(id, getGameOptions{
+		all: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	games[len(games)-1].Deadlines = deadlines
+
+	return gs.saveGameList(newGameList(id, games))
+}
+
+func (gs *gameService) GetOverdue(now time.Time) ([]model.GameID, error) {
+	filter := bson.M{nextDeadlineFieldName: bson.M{`$lte`: now}}
+	opts := options.Find().SetProjection(bson.M{gameCollectionIndex: 1})
+
+	var gIDs []model.GameID
+	err := mongo.WithSession(gs.ctx, gs.session, func(sc mongo.SessionContext) error {
+		cur, err := gs.col.Find(sc, filter, opts)
+		if err != nil {
+			return err
+		}
+		defer cur.Close(sc)
+
+		for cur.Next(sc) {
+			gl := gameList{}
+			err = cur.Decode(&gl)
+			if err != nil {
+				return err
+			}
+			gIDs = append(gIDs, gl.GameID)
+		}
+		return cur.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gIDs, nil
+}
+
+func (gs *gameService) GetWithStatus(ids []model.GameID, status model.GameStatus) ([]model.GameID, error) {
+	var statusFilter interface{} = status
+	if status == model.StatusActive {
+		// games saved before statuses existed don't have one, and they're still active
+		statusFilter = bson.M{`$in`: bson.A{status, nil}}
+	}
+	filter := bson.M{
+		gameCollectionIndex: bson.M{`$in`: ids},
+		statusFieldName:     statusFilter,
+	}
+	opts := options.Find().
+		SetProjection(bson.M{gameCollectionIndex: 1}).
+		SetSort(bson.M{gameCollectionIndex: 1})
+
+	var gIDs []model.GameID
+	err := mongo.WithSession(gs.ctx, gs.session, func(sc mongo.SessionContext) error {
+		cur, err := gs.col.Find(sc, filter, opts)
+		if err != nil {
+			return err
+		}
+		defer cur.Close(sc)
+
+		for cur.Next(sc) {
+			gl := gameList{}
+			err = cur.Decode(&gl)
+			if err != nil {
+				return err
+			}
+			gIDs = append(gIDs, gl.GameID)
+		}
+		return cur.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gIDs, nil
+}
+
 func validateGameState(savedGames []model.Game, newGameState model.Game) error {
 	if len(savedGames) != len(newGameState.Actions) {
 		return persistence.ErrGameActionsOutOfOrder
@@ -282,7 +475,7 @@ func validateGameState(savedGames []model.Game, newGameState model.Game) error {
 func (gs *gameService) saveGameList(saved gameList) error {
 	filter := bsonGameIDFilter(saved.GameID)
 	return mongo.WithSession(gs.ctx, gs.session, func(sc mongo.SessionContext) error {
-		ur, err := gs.col.ReplaceOne(sc, filter, saved)
+		ur, err := gs.col.ReplaceOne(sc, filter, saved.withLatestFields())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestGameListEvents(t *testing.T) {
+	g := model.Game{
+		ID: model.NewGameID(),
+	}
+	g.AddEvent(model.CardDealtEvent{PlayerID: `alice`})
+	g0 := g
+
+	g.AddAction(model.PlayerAction{ID: `alice`, Action: model.DealAction{NumShuffles: 1}})
+	g.AddEvent(model.CutRevealedEvent{Card: model.NewCardFromString(`5h`)})
+	g.AddEvent(model.ScoreEvent{PlayerID: `alice`, Reason: model.NibsScore, Points: 2})
+	g1 := g
+
+	g.AddAction(model.PlayerAction{ID: `bob`, Action: model.CutDeckAction{Percentage: 0.5}})
+	g2 := g
+
+	gl := newGameList(g.ID, []model.Game{g0, g1, g2})
+	assert.Equal(t, g2.Events, gl.Events)
+	for _, sg := range gl.Games {
+		// each state doesn't keep its own copy of the events
+		assert.Nil(t, sg.Events)
+	}
+
+	assert.Equal(t, g0.Events, eventsAt(gl.Events, g0.NumActions()))
+	assert.Equal(t, g1.Events, eventsAt(gl.Events, g1.NumActions()))
+	assert.Equal(t, g2.Events, eventsAt(gl.Events, g2.NumActions()))
+	assert.Nil(t, eventsAt(nil, 0))
+
+	// a rewound game only keeps the events of the states it's rewound to
+	rewound := newGameList(g.ID, []model.Game{g0})
+	assert.Equal(t, g0.Events, rewound.Events)
+}
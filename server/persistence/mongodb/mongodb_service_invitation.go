@@ -0,0 +1,162 @@
+//nolint:dupl
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	invitationCollectionIndex string = `id`
+
+	// these are the bson names of the fields on model.Invitation
+	invitationPlayersFieldName string = `pIDs`
+	invitationOpenFieldName    string = `o`
+	invitationStatusFieldName  string = `s`
+	invitationExpiresFieldName string = `exp`
+)
+
+var _ persistence.InvitationService = (*invitationService)(nil)
+
+type invitationService struct {
+	ctx     context.Context
+	session mongo.Session
+	col     *mongo.Collection
+}
+
+func getInvitationService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.InvitationService, error) {
+
+	col := mdb.Collection(invitationsCollectionName, &options.CollectionOptions{
+		Registry: r,
+	})
+
+	idxs := col.Indexes()
+	hasIndex, err := hasCollectionIndex(ctx, idxs, invitationCollectionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createCollectionIndex(ctx, idxs, invitationCollectionIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &invitationService{
+		ctx:     ctx,
+		session: session,
+		col:     col,
+	}, nil
+}
+
+func bsonInvitationIDFilter(id model.InvitationID) interface{} {
+	return bson.M{invitationCollectionIndex: id} // model.Invitation.ID
+}
+
+func (is *invitationService) Get(id model.InvitationID) (model.Invitation, error) {
+	result := model.Invitation{}
+	filter := bsonInvitationIDFilter(id)
+	err := mongo.WithSession(is.ctx, is.session, func(sc mongo.SessionContext) error {
+		return is.col.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Invitation{}, persistence.ErrInvitationNotFound
+		}
+		return model.Invitation{}, err
+	}
+	return result, nil
+}
+
+func (is *invitationService) GetPending(pID model.PlayerID) ([]model.Invitation, error) {
+	return is.find(bson.M{
+		invitationPlayersFieldName: pID,
+		invitationStatusFieldName:  model.InvitationPending,
+	})
+}
+
+func (is *invitationService) GetOpen() ([]model.Invitation, error) {
+	return is.find(bson.M{
+		invitationOpenFieldName:   true,
+		invitationStatusFieldName: model.InvitationPending,
+	})
+}
+
+func (is *invitationService) find(filter interface{}) ([]model.Invitation, error) {
+	// the oldest invitations expire first
+	opts := options.Find().SetSort(bson.D{
+		{Key: invitationExpiresFieldName, Value: 1},
+		{Key: invitationCollectionIndex, Value: 1},
+	})
+
+	var invs []model.Invitation
+	err := mongo.WithSession(is.ctx, is.session, func(sc mongo.SessionContext) error {
+		cur, err := is.col.Find(sc, filter, opts)
+		if err != nil {
+			return err
+		}
+		defer cur.Close(sc)
+
+		for cur.Next(sc) {
+			inv := model.Invitation{}
+			err = cur.Decode(&inv)
+			if err != nil {
+				return err
+			}
+			invs = append(invs, inv)
+		}
+		return cur.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invs, nil
+}
+
+func (is *invitationService) Create(inv model.Invitation) error {
+	_, err := is.Get(inv.ID)
+	if err == nil {
+		return persistence.ErrInvitationAlreadyExists
+	} else if err != persistence.ErrInvitationNotFound {
+		return err
+	}
+
+	return mongo.WithSession(is.ctx, is.session, func(sc mongo.SessionContext) error {
+		ior, err := is.col.InsertOne(sc, inv)
+		if err != nil {
+			return err
+		}
+		if ior.InsertedID == nil {
+			// not sure if this is the right thing to check
+			return errors.New(`invitation not saved`)
+		}
+
+		return nil
+	})
+}
+
+func (is *invitationService) Save(inv model.Invitation) error {
+	filter := bsonInvitationIDFilter(inv.ID)
+	return mongo.WithSession(is.ctx, is.session, func(sc mongo.SessionContext) error {
+		sr := is.col.FindOneAndReplace(sc, filter, inv)
+		if sr.Err() == mongo.ErrNoDocuments {
+			return persistence.ErrInvitationNotFound
+		}
+		return sr.Err()
+	})
+}
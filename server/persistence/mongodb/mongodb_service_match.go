@@ -0,0 +1,118 @@
+//nolint:dupl
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	matchCollectionIndex string = `id`
+)
+
+var _ persistence.MatchService = (*matchService)(nil)
+
+type matchService struct {
+	ctx     context.Context
+	session mongo.Session
+	col     *mongo.Collection
+}
+
+func getMatchService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.MatchService, error) {
+
+	col := mdb.Collection(matchesCollectionName, &options.CollectionOptions{
+		Registry: r,
+	})
+
+	idxs := col.Indexes()
+	hasIndex, err := hasMatchCollectionIndex(ctx, idxs)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createMatchCollectionIndex(ctx, idxs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &matchService{
+		ctx:     ctx,
+		session: session,
+		col:     col,
+	}, nil
+}
+
+func hasMatchCollectionIndex(ctx context.Context, idxs mongo.IndexView) (bool, error) {
+	return hasCollectionIndex(ctx, idxs, matchCollectionIndex)
+}
+
+func createMatchCollectionIndex(ctx context.Context, idxs mongo.IndexView) error {
+	return createCollectionIndex(ctx, idxs, matchCollectionIndex)
+}
+
+func bsonMatchIDFilter(id model.MatchID) interface{} {
+	return bson.M{matchCollectionIndex: id} // model.Match.ID
+}
+
+func (ms *matchService) Get(id model.MatchID) (model.Match, error) {
+	result := model.Match{}
+	filter := bsonMatchIDFilter(id)
+	err := mongo.WithSession(ms.ctx, ms.session, func(sc mongo.SessionContext) error {
+		return ms.col.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Match{}, persistence.ErrMatchNotFound
+		}
+		return model.Match{}, err
+	}
+	return result, nil
+}
+
+func (ms *matchService) Create(m model.Match) error {
+	_, err := ms.Get(m.ID)
+	if err == nil {
+		return persistence.ErrMatchAlreadyExists
+	} else if err != persistence.ErrMatchNotFound {
+		return err
+	}
+
+	return mongo.WithSession(ms.ctx, ms.session, func(sc mongo.SessionContext) error {
+		ior, err := ms.col.InsertOne(sc, m)
+		if err != nil {
+			return err
+		}
+		if ior.InsertedID == nil {
+			// not sure if this is the right thing to check
+			return errors.New(`match not saved`)
+		}
+
+		return nil
+	})
+}
+
+func (ms *matchService) Save(m model.Match) error {
+	filter := bsonMatchIDFilter(m.ID)
+	return mongo.WithSession(ms.ctx, ms.session, func(sc mongo.SessionContext) error {
+		sr := ms.col.FindOneAndReplace(sc, filter, m)
+		if sr.Err() == mongo.ErrNoDocuments {
+			return persistence.ErrMatchNotFound
+		}
+		return sr.Err()
+	})
+}
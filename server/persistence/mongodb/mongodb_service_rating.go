@@ -0,0 +1,180 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	ratingCollectionIndex string = `pID`
+
+	// these are the bson names of the fields on model.Rating and model.RatingChange
+	ratingNumPlayersFieldName string = `p.np`
+	ratingVariantFieldName    string = `p.v`
+	ratingRatingFieldName     string = `r`
+	ratingChangeAtFieldName   string = `at`
+)
+
+var _ persistence.RatingService = (*ratingService)(nil)
+
+type ratingService struct {
+	ctx     context.Context
+	session mongo.Session
+	ratings *mongo.Collection
+	history *mongo.Collection
+}
+
+func getRatingService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.RatingService, error) {
+
+	colOpts := &options.CollectionOptions{
+		Registry: r,
+	}
+	ratings := mdb.Collection(ratingsCollectionName, colOpts)
+	history := mdb.Collection(ratingHistoryCollectionName, colOpts)
+
+	for _, col := range []*mongo.Collection{ratings, history} {
+		idxs := col.Indexes()
+		hasIndex, err := hasCollectionIndex(ctx, idxs, ratingCollectionIndex)
+		if err != nil {
+			return nil, err
+		}
+		if !hasIndex {
+			err = createCollectionIndex(ctx, idxs, ratingCollectionIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &ratingService{
+		ctx:     ctx,
+		session: session,
+		ratings: ratings,
+		history: history,
+	}, nil
+}
+
+func bsonRatingFilter(id model.PlayerID, pool model.RatingPool) interface{} {
+	return bson.M{
+		ratingCollectionIndex:     id, // model.Rating.PlayerID
+		ratingNumPlayersFieldName: pool.NumPlayers,
+		ratingVariantFieldName:    pool.Variant,
+	}
+}
+
+func (rs *ratingService) Get(id model.PlayerID, pool model.RatingPool) (model.Rating, error) {
+	result := model.Rating{}
+	filter := bsonRatingFilter(id, pool)
+	err := mongo.WithSession(rs.ctx, rs.session, func(sc mongo.SessionContext) error {
+		return rs.ratings.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.Rating{}, persistence.ErrRatingNotFound
+		}
+		return model.Rating{}, err
+	}
+	return result, nil
+}
+
+func (rs *ratingService) Save(r model.Rating) error {
+	opt := &options.ReplaceOptions{}
+	opt.SetUpsert(true)
+	filter := bsonRatingFilter(r.PlayerID, r.Pool)
+
+	return mongo.WithSession(rs.ctx, rs.session, func(sc mongo.SessionContext) error {
+		_, err := rs.ratings.ReplaceOne(sc, filter, r, opt)
+		return err
+	})
+}
+
+func (rs *ratingService) AddChange(rc model.RatingChange) error {
+	return mongo.WithSession(rs.ctx, rs.session, func(sc mongo.SessionContext) error {
+		_, err := rs.history.InsertOne(sc, rc)
+		return err
+	})
+}
+
+func (rs *ratingService) GetHistory(id model.PlayerID) ([]model.RatingChange, error) {
+	filter := bson.M{ratingCollectionIndex: id} // model.RatingChange.PlayerID
+	opts := options.Find().SetSort(bson.D{
+		{Key: ratingChangeAtFieldName, Value: 1},
+	})
+
+	var h []model.RatingChange
+	err := mongo.WithSession(rs.ctx, rs.session, func(sc mongo.SessionContext) error {
+		cur, err := rs.history.Find(sc, filter, opts)
+		if err != nil {
+			return err
+		}
+		defer cur.Close(sc)
+
+		for cur.Next(sc) {
+			rc := model.RatingChange{}
+			err = cur.Decode(&rc)
+			if err != nil {
+				return err
+			}
+			h = append(h, rc)
+		}
+		return cur.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (rs *ratingService) GetLeaderboard(f persistence.LeaderboardFilter) ([]model.Rating, error) {
+	filter := bson.M{}
+	if f.NumPlayers != 0 {
+		filter[ratingNumPlayersFieldName] = f.NumPlayers
+	}
+	if f.Variant != `` {
+		filter[ratingVariantFieldName] = f.Variant
+	}
+	opts := options.Find().SetSort(bson.D{
+		{Key: ratingRatingFieldName, Value: -1},
+		{Key: ratingCollectionIndex, Value: 1},
+		{Key: ratingNumPlayersFieldName, Value: 1},
+		{Key: ratingVariantFieldName, Value: 1},
+	}).SetSkip(int64(f.Offset)).SetLimit(int64(f.Limit))
+
+	var ratings []model.Rating
+	err := mongo.WithSession(rs.ctx, rs.session, func(sc mongo.SessionContext) error {
+		cur, err := rs.ratings.Find(sc, filter, opts)
+		if err != nil {
+			return err
+		}
+		defer cur.Close(sc)
+
+		for cur.Next(sc) {
+			r := model.Rating{}
+			err = cur.Decode(&r)
+			if err != nil {
+				return err
+			}
+			ratings = append(ratings, r)
+		}
+		return cur.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	spectatorCollectionIndex string = `gameID`
+)
+
+var _ persistence.SpectatorService = (*spectatorService)(nil)
+
+// gameSpectators is everyone watching a game
+type gameSpectators struct {
+	GameID    model.GameID     `bson:"gameID"`
+	PlayerIDs []model.PlayerID `bson:"pIDs"`
+}
+
+type spectatorService struct {
+	ctx     context.Context
+	session mongo.Session
+	col     *mongo.Collection
+}
+
+func getSpectatorService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.SpectatorService, error) {
+
+	col := mdb.Collection(spectatorsCollectionName, &options.CollectionOptions{
+		Registry: r,
+	})
+
+	idxs := col.Indexes()
+	hasIndex, err := hasCollectionIndex(ctx, idxs, spectatorCollectionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createCollectionIndex(ctx, idxs, spectatorCollectionIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &spectatorService{
+		ctx:     ctx,
+		session: session,
+		col:     col,
+	}, nil
+}
+
+func bsonSpectatorsFilter(id model.GameID) interface{} {
+	return bson.M{spectatorCollectionIndex: id} // gameSpectators.GameID
+}
+
+func (ss *spectatorService) Get(id model.GameID) ([]model.PlayerID, error) {
+	result := gameSpectators{}
+	filter := bsonSpectatorsFilter(id)
+	err := mongo.WithSession(ss.ctx, ss.session, func(sc mongo.SessionContext) error {
+		return ss.col.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// nobody has started watching yet
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result.PlayerIDs, nil
+}
+
+func (ss *spectatorService) Add(id model.GameID, pID model.PlayerID) error {
+	pIDs, err := ss.Get(id)
+	if err != nil {
+		return err
+	}
+	for _, s := range pIDs {
+		if s == pID {
+			return persistence.ErrAlreadySpectating
+		}
+	}
+
+	opt := &options.UpdateOptions{}
+	opt.SetUpsert(true)
+	filter := bsonSpectatorsFilter(id)
+	update := bson.M{`$push`: bson.M{`pIDs`: pID}}
+
+	return mongo.WithSession(ss.ctx, ss.session, func(sc mongo.SessionContext) error {
+		_, err := ss.col.UpdateOne(sc, filter, update, opt)
+		return err
+	})
+}
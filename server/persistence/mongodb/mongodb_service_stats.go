@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	statsCollectionIndex string = `pID`
+)
+
+var _ persistence.StatsService = (*statsService)(nil)
+
+type statsService struct {
+	ctx     context.Context
+	session mongo.Session
+	col     *mongo.Collection
+}
+
+func getStatsService(
+	ctx context.Context,
+	session mongo.Session,
+	mdb *mongo.Database,
+	r *bsoncodec.Registry,
+) (persistence.StatsService, error) {
+
+	col := mdb.Collection(statsCollectionName, &options.CollectionOptions{
+		Registry: r,
+	})
+
+	idxs := col.Indexes()
+	hasIndex, err := hasCollectionIndex(ctx, idxs, statsCollectionIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hasIndex {
+		err = createCollectionIndex(ctx, idxs, statsCollectionIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &statsService{
+		ctx:     ctx,
+		session: session,
+		col:     col,
+	}, nil
+}
+
+func bsonStatsFilter(id model.PlayerID) interface{} {
+	return bson.M{statsCollectionIndex: id} // model.PlayerStats.PlayerID
+}
+
+func (ss *statsService) Get(id model.PlayerID) (model.PlayerStats, error) {
+	result := model.PlayerStats{}
+	filter := bsonStatsFilter(id)
+	err := mongo.WithSession(ss.ctx, ss.session, func(sc mongo.SessionContext) error {
+		return ss.col.FindOne(sc, filter).Decode(&result)
+	})
+
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return model.PlayerStats{}, persistence.ErrStatsNotFound
+		}
+		return model.PlayerStats{}, err
+	}
+	return result, nil
+}
+
+func (ss *statsService) Save(ps model.PlayerStats) error {
+	opt := &options.ReplaceOptions{}
+	opt.SetUpsert(true)
+	filter := bsonStatsFilter(ps.PlayerID)
+
+	return mongo.WithSession(ss.ctx, ss.session, func(sc mongo.SessionContext) error {
+		_, err := ss.col.ReplaceOne(sc, filter, ps, opt)
+		return err
+	})
+}
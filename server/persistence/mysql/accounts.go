@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// Accounts stores the credentials a player signs in with.
+	// The columns act as follows:
+	// PlayerID is the player that the account belongs to
+	// PasswordHash is the bcrypt hash of the player's password
+	// SignedOutAt is the last time the player signed out, which is NULL if they never have
+	createAccountsTable = `CREATE TABLE IF NOT EXISTS Accounts (
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) NOT NULL,
+		PasswordHash BLOB,
+		SignedOutAt TIMESTAMP(6) NULL,
+		PRIMARY KEY (PlayerID)
+	) ENGINE = INNODB;`
+
+	queryAccount = `SELECT
+		PasswordHash, SignedOutAt
+	FROM Accounts
+		WHERE PlayerID = ?
+	;`
+
+	createAccount = `INSERT INTO Accounts
+		(PlayerID, PasswordHash, SignedOutAt)
+	VALUES
+		(?, ?, ?)
+	;`
+
+	updateAccount = `UPDATE Accounts
+	SET
+		PasswordHash = ?,
+		SignedOutAt = ?
+	WHERE
+		PlayerID = ?
+	;`
+)
+
+var (
+	accountsCreateStmts = []string{
+		createAccountsTable,
+	}
+)
+
+var _ persistence.AccountService = (*accountService)(nil)
+
+type accountService struct {
+	db *txWrapper
+}
+
+func getAccountService(
+	db *txWrapper,
+) persistence.AccountService {
+
+	return &accountService{
+		db: db,
+	}
+}
+
+func (as *accountService) Get(id model.PlayerID) (model.Account, error) {
+	a := model.Account{
+		PlayerID: id,
+	}
+	var signedOutAt sql.NullTime
+
+	r := as.db.QueryRow(queryAccount, id)
+	err := r.Scan(
+		&a.PasswordHash,
+		&signedOutAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Account{}, persistence.ErrAccountNotFound
+		}
+		return model.Account{}, err
+	}
+	if signedOutAt.Valid {
+		a.SignedOutAt = signedOutAt.Time
+	}
+
+	return a, nil
+}
+
+func (as *accountService) Create(a model.Account) error {
+	_, err := as.db.Exec(
+		createAccount,
+		a.PlayerID,
+		a.PasswordHash,
+		signedOutAt(a),
+	)
+	err = convertMysqlError(err)
+	if err != nil {
+		if err == errDuplicateEntry {
+			return persistence.ErrAccountAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (as *accountService) Save(a model.Account) error {
+	_, err := as.Get(a.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = as.db.Exec(
+		updateAccount,
+		a.PasswordHash,
+		signedOutAt(a),
+		a.PlayerID,
+	)
+	return convertMysqlError(err)
+}
+
+// signedOutAt is what's stored in the SignedOutAt column for the account
+func signedOutAt(a model.Account) sql.NullTime {
+	return sql.NullTime{
+		Time:  a.SignedOutAt,
+		Valid: !a.SignedOutAt.IsZero(),
+	}
+}
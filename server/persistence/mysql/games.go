@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/joshprzybyszewski/cribbage/jsonutils"
@@ -17,8 +19,9 @@ const (
 	// The columns act as follows:
 	// GameID is a UUID to identify a game
 	// NumActions is how many actions have occurred in the game before this one
-	// ScoreBlue, ScoreRed, and ScoreGreen are the scores for each color
-	// ScoreBlueLag, ScoreRedLag, and ScoreGreenLag are the previous scores for each color
+	// ScoreBlue, ScoreRed, ScoreGreen, ScoreYellow, and ScorePurple are the scores for each color
+	// ScoreBlueLag, ScoreRedLag, ScoreGreenLag, ScoreYellowLag, and ScorePurpleLag are the
+	//   previous scores for each color
 	// Phase is the model.Phase that the game is currently in
 	// CutCard is a number representation of the card that's been cut
 	// Crib is a 4-byte int of the (up to 4) cards in the crib where every byte is each crib card.
@@ -28,6 +31,12 @@ const (
 	// Hands is a json encoded map of slices for player hands
 	// PeggedCards is the json-encoded slice of previously pegged cards
 	// Action is the json encoded model.PlayerAction
+	// Options is the json encoded model.GameOptions
+	// Result is the json encoded model.GameResult, which is NULL until the game is over
+	// Seed is the seed that the game's decks are shuffled from, which is NULL for older games
+	// Deadlines is the json encoded map of when each blocking player needs to act by
+	// NextDeadline is the earliest of the Deadlines, which is NULL when nobody has one
+	// Status is the model.GameStatus, which is whether the game is still being played
 	createGameTable = `CREATE TABLE IF NOT EXISTS Games (
 		GameID INT UNSIGNED,
 		NumActions INT UNSIGNED,
@@ -35,9 +44,13 @@ const (
 		ScoreBlue TINYINT UNSIGNED,
 		ScoreRed TINYINT UNSIGNED,
 		ScoreGreen TINYINT UNSIGNED,
+		ScoreYellow TINYINT UNSIGNED,
+		ScorePurple TINYINT UNSIGNED,
 		ScoreBlueLag TINYINT UNSIGNED,
 		ScoreRedLag TINYINT UNSIGNED,
 		ScoreGreenLag TINYINT UNSIGNED,
+		ScoreYellowLag TINYINT UNSIGNED,
+		ScorePurpleLag TINYINT UNSIGNED,
 		Phase TINYINT UNSIGNED,
 		CutCard SMALLINT,
 		Crib INT,
@@ -46,47 +59,118 @@ const (
 		Hands BLOB,
 		PeggedCards BLOB,
 		Action BLOB,
-		PRIMARY KEY (GameID, NumActions)
+		Options BLOB,
+		Result BLOB,
+		Seed BIGINT,
+		Deadlines BLOB,
+		NextDeadline TIMESTAMP NULL,
+		Status TINYINT UNSIGNED DEFAULT 0,
+		PRIMARY KEY (GameID, NumActions),
+		INDEX (NextDeadline)
 	) ENGINE = INNODB;`
 
+	// GamePlayers stores who is playing in a game, with one row per player.
+	//   Seat is the order that they sit in, starting at zero. Partners sit evenly
+	//   around the table, so in a four player game seats 0 and 2 are a team, as
+	//   are seats 1 and 3.
 	createGamePlayersTable = `CREATE TABLE IF NOT EXISTS GamePlayers (
 		GameID INT UNSIGNED,
-		Player1ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
-		Player2ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
-		Player3ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs,
-		Player4ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs,
-		PRIMARY KEY (GameID)
+		Seat TINYINT UNSIGNED,
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		PRIMARY KEY (GameID, Seat)
 	) ENGINE = INNODB;`
 
 	queryLatestGame = `SELECT 
-		gp.Player1ID, gp.Player2ID, gp.Player3ID, gp.Player4ID,
-		g.ScoreBlue, g.ScoreRed, g.ScoreGreen,
-		g.ScoreBlueLag, g.ScoreRedLag, g.ScoreGreenLag,
-		g.Phase, g.BlockingPlayers, g.CurrentDealer,
-		g.Hands, g.Crib, g.CutCard,
-		g.PeggedCards,
-		g.NumActions, g.Action
-	FROM Games g
-	INNER JOIN GamePlayers gp
-		ON g.GameID = gp.GameID
-		WHERE g.GameID = ? 
+		ScoreBlue, ScoreRed, ScoreGreen, ScoreYellow, ScorePurple,
+		ScoreBlueLag, ScoreRedLag, ScoreGreenLag, ScoreYellowLag, ScorePurpleLag,
+		Phase, BlockingPlayers, CurrentDealer,
+		Hands, Crib, CutCard,
+		PeggedCards,
+		NumActions, Action,
+		Options, Result,
+		Seed,
+		Deadlines, Status
+	FROM Games
+	WHERE GameID = ? 
 	ORDER BY
 		NumActions DESC
 	LIMIT 1;`
 
 	queryGameAtNumActions = `SELECT 
-		gp.Player1ID, gp.Player2ID, gp.Player3ID, gp.Player4ID,
-		g.ScoreBlue, g.ScoreRed, g.ScoreGreen,
-		g.ScoreBlueLag, g.ScoreRedLag, g.ScoreGreenLag,
-		g.Phase, g.BlockingPlayers, g.CurrentDealer,
-		g.Hands, g.Crib, g.CutCard,
-		g.PeggedCards,
-		g.NumActions, g.Action
+		ScoreBlue, ScoreRed, ScoreGreen, ScoreYellow, ScorePurple,
+		ScoreBlueLag, ScoreRedLag, ScoreGreenLag, ScoreYellowLag, ScorePurpleLag,
+		Phase, BlockingPlayers, CurrentDealer,
+		Hands, Crib, CutCard,
+		PeggedCards,
+		NumActions, Action,
+		Options, Result,
+		Seed,
+		Deadlines, Status
+	FROM Games
+	WHERE GameID = ? AND
+		NumActions = ?
+	;`
+
+	queryGamePlayers = `SELECT 
+		PlayerID
+	FROM GamePlayers
+	WHERE GameID = ?
+	ORDER BY
+		Seat
+	;`
+
+	queryOverdueGames = `SELECT 
+		g.GameID
+	FROM Games g
+	INNER JOIN (
+		SELECT GameID, MAX(NumActions) AS NumActions
+		FROM Games
+		GROUP BY GameID
+	) latest
+		ON g.GameID = latest.GameID AND
+		g.NumActions = latest.NumActions
+	WHERE g.NextDeadline <= ?
+	ORDER BY
+		g.GameID
+	;`
+
+	// queryGamesWithStatusFmt needs a placeholder for each of the GameIDs
+	queryGamesWithStatusFmt = `SELECT 
+		g.GameID
 	FROM Games g
-	INNER JOIN GamePlayers gp
-		ON g.GameID = gp.GameID
-	WHERE g.GameID = ? AND
-		g.NumActions = ?
+	INNER JOIN (
+		SELECT GameID, MAX(NumActions) AS NumActions
+		FROM Games
+		WHERE GameID IN (%s)
+		GROUP BY GameID
+	) latest
+		ON g.GameID = latest.GameID AND
+		g.NumActions = latest.NumActions
+	WHERE g.Status = ?
+	ORDER BY
+		g.GameID
+	;`
+
+	updateLatestDeadlines = `UPDATE Games
+	SET
+		Deadlines = ?,
+		NextDeadline = ?
+	WHERE GameID = ?
+	ORDER BY
+		NumActions DESC
+	LIMIT 1
+	;`
+
+	queryHasGameAtNumActions = `SELECT 
+		COUNT(*)
+	FROM Games
+	WHERE GameID = ? AND
+		NumActions = ?
+	;`
+
+	deleteGamesAfter = `DELETE FROM Games
+	WHERE GameID = ? AND
+		NumActions > ?
 	;`
 
 	queryPlayerActionsBefore = `SELECT 
@@ -96,43 +180,105 @@ const (
 		NumActions <= ?
 	;`
 
-	addPlayersToGamePlayers = `INSERT INTO GamePlayers
+	addPlayerToGamePlayers = `INSERT INTO GamePlayers
 		(
-			GameID, 
-			Player1ID, Player2ID, Player3ID, Player4ID
+			GameID, Seat, PlayerID
 		)
 	VALUES
 		(
-			?,
-			?, ?, ?, ?
+			?, ?, ?
 		)
 	;`
 
 	insertGameAt = `INSERT INTO Games
 		(
 			GameID, NumActions, 
-			ScoreBlue, ScoreRed, ScoreGreen,
-			ScoreBlueLag, ScoreRedLag, ScoreGreenLag,
+			ScoreBlue, ScoreRed, ScoreGreen, ScoreYellow, ScorePurple,
+			ScoreBlueLag, ScoreRedLag, ScoreGreenLag, ScoreYellowLag, ScorePurpleLag,
 			Phase, CutCard, Crib,
 			CurrentDealer,
-			BlockingPlayers, Hands, PeggedCards, Action
+			BlockingPlayers, Hands, PeggedCards, Action,
+			Options, Result,
+			Seed,
+			Deadlines, NextDeadline,
+			Status
 		)
 	VALUES
 		(
 			?, ?,
+			?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?,
 			?, ?, ?,
-			?, ?, ?,
-			?, ?, ?,
 			?,
+			?, ?, ?, ?,
+			?, ?,
+			?,
+			?, ?,
+			?
+		)
+	;`
+
+	// GameEvents stores the events that have happened in a game, with one row per event.
+	//   Each event is only stored once, instead of with every state of the game after it.
+	// The columns act as follows:
+	// GameID is a UUID to identify a game
+	// EventIndex is the event's position in the game's events
+	// NumActions is the NumActions of the Games row that the event was saved with. Each
+	//   state of a game has the events that were saved with it, or before it.
+	// Event is the json encoded model.GameEvent
+	createGameEventsTable = `CREATE TABLE IF NOT EXISTS GameEvents (
+		GameID INT UNSIGNED,
+		EventIndex INT UNSIGNED,
+		NumActions INT UNSIGNED,
+		Event BLOB,
+		PRIMARY KEY (GameID, EventIndex)
+	) ENGINE = INNODB;`
+
+	queryGameEventsAt = `SELECT 
+		Event
+	FROM GameEvents
+	WHERE GameID = ? AND
+		NumActions <= ?
+	ORDER BY
+		EventIndex
+	;`
+
+	queryNumGameEvents = `SELECT 
+		COUNT(*)
+	FROM GameEvents
+	WHERE GameID = ?
+	;`
+
+	insertGameEvent = `INSERT INTO GameEvents
+		(
+			GameID, EventIndex, NumActions, Event
+		)
+	VALUES
+		(
 			?, ?, ?, ?
 		)
 	;`
+
+	deleteGameEventsAfter = `DELETE FROM GameEvents
+	WHERE GameID = ? AND
+		NumActions > ?
+	;`
 )
 
 var (
 	gamesCreateStmts = []string{
 		createGameTable,
 		createGamePlayersTable,
+		createGameEventsTable,
+	}
+
+	// scoredColors are the colors that have score columns, in the order of the columns
+	scoredColors = [...]model.PlayerColor{
+		model.Blue,
+		model.Red,
+		model.Green,
+		model.Yellow,
+		model.Purple,
 	}
 )
 
@@ -166,24 +312,25 @@ func (g *gameService) populateGameFromRow(
 	r *sql.Row,
 ) (model.Game, error) {
 
-	var p1ID, p2ID model.PlayerID
-	var p3ID, p4ID *model.PlayerID
 	var curDealerID model.PlayerID
-	var scoreBlue, scoreRed, scoreGreen,
-		lagScoreBlue, lagScoreRed, lagScoreGreen uint8
+	var scores, lagScores [len(scoredColors)]uint8
 	var phase model.Phase
 	var cribCardInts int32
 	var cutCardInt int8
-	var blockingPlayers, hands, peggedCards, action []byte
+	var blockingPlayers, hands, peggedCards, action, options, result, deadlines []byte
 	var numActions uint32
+	var seed sql.NullInt64
+	var status model.GameStatus
 	err := r.Scan(
-		&p1ID, &p2ID, &p3ID, &p4ID,
-		&scoreBlue, &scoreRed, &scoreGreen,
-		&lagScoreBlue, &lagScoreRed, &lagScoreGreen,
+		&scores[0], &scores[1], &scores[2], &scores[3], &scores[4],
+		&lagScores[0], &lagScores[1], &lagScores[2], &lagScores[3], &lagScores[4],
 		&phase, &blockingPlayers, &curDealerID,
 		&hands, &cribCardInts, &cutCardInt,
 		&peggedCards,
 		&numActions, &action,
+		&options, &result,
+		&seed,
+		&deadlines, &status,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -192,12 +339,9 @@ func (g *gameService) populateGameFromRow(
 		return model.Game{}, err
 	}
 
-	curScores, lagScores := populateScores(
-		scoreBlue, scoreRed, scoreGreen,
-		lagScoreBlue, lagScoreRed, lagScoreGreen,
-	)
+	curScores, lagScoresByColor := populateScores(scores, lagScores)
 
-	players, err := g.getPlayersForGame(p1ID, p2ID, p3ID, p4ID)
+	players, err := g.getPlayersForGame(gID)
 	if err != nil {
 		return model.Game{}, err
 	}
@@ -206,7 +350,7 @@ func (g *gameService) populateGameFromRow(
 	if err != nil {
 		return model.Game{}, err
 	}
-	addInPopulatedColor(curScores, lagScores, pc)
+	addInPopulatedColor(curScores, lagScoresByColor, pc)
 
 	cutCard, err := model.NewCardFromTinyInt(cutCardInt)
 	if err != nil {
@@ -237,45 +381,66 @@ func (g *gameService) populateGameFromRow(
 		return model.Game{}, err
 	}
 
+	opts, err := getGameOptions(options)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	res, err := getGameResult(result)
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	evs, err := g.getEvents(gID, int(numActions))
+	if err != nil {
+		return model.Game{}, err
+	}
+
+	dls, err := getDeadlines(deadlines)
+	if err != nil {
+		return model.Game{}, err
+	}
+
 	game := model.Game{
 		ID:              gID,
 		CurrentScores:   curScores,
-		LagScores:       lagScores,
+		LagScores:       lagScoresByColor,
 		Players:         players,
 		PlayerColors:    pc,
+		Options:         opts,
+		Seed:            seed.Int64,
 		Phase:           phase,
 		CurrentDealer:   curDealerID,
 		CutCard:         cutCard,
 		Crib:            cribCards,
 		BlockingPlayers: bp,
+		Deadlines:       dls,
 		Hands:           h,
 		PeggedCards:     p,
 		Actions:         pas,
+		Status:          status,
+		Result:          res,
+		Events:          evs,
 	}
 
 	return game, nil
 }
 
+// populateScores converts the score columns, which are in the order of scoredColors,
+// into maps. Colors without any points are left out.
 func populateScores(
-	scoreBlue, scoreRed, scoreGreen,
-	lagScoreBlue, lagScoreRed, lagScoreGreen uint8,
+	scores, lagScores [len(scoredColors)]uint8,
 ) (cur, lag map[model.PlayerColor]int) {
-	curScores := make(map[model.PlayerColor]int, 3)
-	lagScores := make(map[model.PlayerColor]int, 3)
-	if scoreBlue > 0 {
-		curScores[model.Blue] = int(scoreBlue)
-		lagScores[model.Blue] = int(lagScoreBlue)
-	}
-	if scoreRed > 0 {
-		curScores[model.Red] = int(scoreRed)
-		lagScores[model.Red] = int(lagScoreRed)
-	}
-	if scoreGreen > 0 {
-		curScores[model.Green] = int(scoreGreen)
-		lagScores[model.Green] = int(lagScoreGreen)
+	curScores := make(map[model.PlayerColor]int, len(scoredColors))
+	lagScoresByColor := make(map[model.PlayerColor]int, len(scoredColors))
+	for i, color := range scoredColors {
+		if scores[i] > 0 {
+			curScores[color] = int(scores[i])
+			lagScoresByColor[color] = int(lagScores[i])
+		}
 	}
 
-	return curScores, lagScores
+	return curScores, lagScoresByColor
 }
 
 func addInPopulatedColor(
@@ -336,6 +501,37 @@ func serializeBlockingPlayers(input map[model.PlayerID]model.Blocker) ([]byte, e
 	return json.Marshal(input)
 }
 
+func getDeadlines(ser []byte) (map[model.PlayerID]time.Time, error) {
+	if len(ser) == 0 {
+		// the game doesn't have a turn timer
+		return nil, nil
+	}
+
+	deadlines := map[model.PlayerID]time.Time{}
+	err := json.Unmarshal(ser, &deadlines)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadlines, nil
+}
+
+func serializeDeadlines(input map[model.PlayerID]time.Time) ([]byte, error) {
+	if input == nil {
+		return nil, nil
+	}
+	return json.Marshal(input)
+}
+
+// nextDeadline is what's stored in the NextDeadline column for the game
+func nextDeadline(mg model.Game) sql.NullTime {
+	dl, ok := mg.NextDeadline()
+	return sql.NullTime{
+		Time:  dl,
+		Valid: ok,
+	}
+}
+
 func getHands(ser []byte) (map[model.PlayerID][]model.Card, error) {
 	hands := map[model.PlayerID][]model.Card{}
 
@@ -366,33 +562,117 @@ func serializePeggedCards(input []model.PeggedCard) ([]byte, error) {
 	return json.Marshal(input)
 }
 
-func (g *gameService) getPlayersForGame(
-	p1ID, p2ID model.PlayerID,
-	p3ID, p4ID *model.PlayerID,
-) ([]model.Player, error) {
+func getGameOptions(ser []byte) (model.GameOptions, error) {
+	opts := model.GameOptions{}
+	if len(ser) == 0 {
+		// games saved before options existed were played without any
+		return opts, nil
+	}
 
-	if len(p1ID) == 0 || len(p2ID) == 0 {
-		return nil, errors.New(`at least two players required`)
+	err := json.Unmarshal(ser, &opts)
+	if err != nil {
+		return model.GameOptions{}, err
+	}
+
+	return opts, nil
+}
+
+func serializeGameOptions(input model.GameOptions) ([]byte, error) {
+	return json.Marshal(input)
+}
+
+func getGameResult(ser []byte) (*model.GameResult, error) {
+	if len(ser) == 0 {
+		// the game isn't over yet
+		return nil, nil
+	}
+
+	res := model.GameResult{}
+	err := json.Unmarshal(ser, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func serializeGameResult(input *model.GameResult) ([]byte, error) {
+	if input == nil {
+		return nil, nil
+	}
+	return json.Marshal(input)
+}
+
+func (g *gameService) getEvents(
+	gID model.GameID,
+	numActions int,
+) ([]model.GameEvent, error) {
+
+	rows, err := g.db.Query(queryGameEventsAt, gID, numActions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evs []model.GameEvent
+	var ser []byte
+	var ge model.GameEvent
+	for rows.Next() {
+		err = rows.Scan(&ser)
+		if err != nil {
+			return nil, err
+		}
+		// each event is typed by its GameEventType, so we have to unmarshal
+		// them one at a time
+		ge, err = jsonutils.UnmarshalGameEvent(ser)
+		if err != nil {
+			return nil, err
+		}
+		evs = append(evs, ge)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
 	}
 
-	pIDs := []model.PlayerID{
-		p1ID, p2ID,
+	return evs, nil
+}
+
+func serializeGameEvent(input model.GameEvent) ([]byte, error) {
+	// Remember: this is complemented by jsonutils.UnmarshalGameEvent
+	// because we have to unmarshal into an interface
+	return json.Marshal(input)
+}
+
+func (g *gameService) getPlayersForGame(
+	gID model.GameID,
+) ([]model.Player, error) {
+
+	rows, err := g.db.Query(queryGamePlayers, gID)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if p3ID != nil && len(*p3ID) > 0 {
-		// The third and fourth players can only exist if the first two do
-		pIDs = append(pIDs, *p3ID)
-		if p4ID != nil && len(*p4ID) > 0 {
-			pIDs = append(pIDs, *p4ID)
+	players := make([]model.Player, 0, model.MaxPlayerGame)
+	for rows.Next() {
+		var pID model.PlayerID
+		err = rows.Scan(&pID)
+		if err != nil {
+			return nil, err
 		}
+		players = append(players, model.Player{ID: pID})
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
 	}
 
-	players := make([]model.Player, len(pIDs))
-	for i, pID := range pIDs {
-		players[i].ID = pID
+	if len(players) < model.MinPlayerGame {
+		return nil, errors.New(`at least two players required`)
 	}
-	return players, nil
 
+	return players, nil
 }
 
 func (g *gameService) getPlayerColors(
@@ -497,25 +777,17 @@ func (g *gameService) UpdatePlayerColor(id model.GameID, pID model.PlayerID, col
 }
 
 func (g *gameService) Begin(mg model.Game) error {
-	ifs := []interface{}{
-		mg.ID,
-	}
 	for _, p := range mg.Players {
 		if len(p.ID) > maxPlayerUUIDLen {
 			return persistence.ErrInvalidPlayerID
 		}
-
-		ifs = append(ifs, p.ID)
-	}
-	for len(ifs) < 5 {
-		// the query expects 5 inputs. it'd be better to have variadic queries
-		// but I don't want to write that right now.
-		ifs = append(ifs, nil)
 	}
 
-	_, err := g.db.Exec(addPlayersToGamePlayers, ifs...)
-	if err != nil {
-		return err
+	for seat, p := range mg.Players {
+		_, err := g.db.Exec(addPlayerToGamePlayers, mg.ID, seat, p.ID)
+		if err != nil {
+			return err
+		}
 	}
 
 	return g.Save(mg)
@@ -549,6 +821,18 @@ func (g *gameService) Save(mg model.Game) error {
 	if err != nil {
 		return err
 	}
+	opts, err := serializeGameOptions(mg.Options)
+	if err != nil {
+		return err
+	}
+	res, err := serializeGameResult(mg.Result)
+	if err != nil {
+		return err
+	}
+	dls, err := serializeDeadlines(mg.Deadlines)
+	if err != nil {
+		return err
+	}
 	var a []byte
 	if ai := mg.NumActions() - 1; ai >= 0 {
 		// get the last action in the slice of actions. Serialize it for saving
@@ -560,16 +844,138 @@ func (g *gameService) Save(mg model.Game) error {
 
 	ifs := []interface{}{
 		mg.ID, mg.NumActions(),
-		uint8(mg.CurrentScores[model.Blue]), uint8(mg.CurrentScores[model.Red]), uint8(mg.CurrentScores[model.Green]),
-		uint8(mg.LagScores[model.Blue]), uint8(mg.LagScores[model.Red]), uint8(mg.LagScores[model.Green]),
+	}
+	for _, color := range scoredColors {
+		ifs = append(ifs, uint8(mg.CurrentScores[color]))
+	}
+	for _, color := range scoredColors {
+		ifs = append(ifs, uint8(mg.LagScores[color]))
+	}
+	ifs = append(ifs,
 		mg.Phase, cut, crib,
 		mg.CurrentDealer,
 		bp, h, pegged, a,
-	}
+		opts, res,
+		mg.Seed,
+		dls, nextDeadline(mg),
+		mg.Status,
+	)
 	_, err = g.db.Exec(insertGameAt, ifs...)
 	if err != nil {
 		return err
 	}
 
+	return g.saveNewEvents(mg)
+}
+
+// saveNewEvents saves the events that were added to the game since it was last saved
+func (g *gameService) saveNewEvents(mg model.Game) error {
+	var numSaved int
+	err := g.db.QueryRow(queryNumGameEvents, mg.ID).Scan(&numSaved)
+	if err != nil {
+		return err
+	}
+
+	var ser []byte
+	for i := numSaved; i < len(mg.Events); i++ {
+		ser, err = serializeGameEvent(mg.Events[i])
+		if err != nil {
+			return err
+		}
+		_, err = g.db.Exec(insertGameEvent, mg.ID, i, mg.NumActions(), ser)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func (g *gameService) Rewind(id model.GameID, numActions uint) error {
+	var count int
+	err := g.db.QueryRow(queryHasGameAtNumActions, id, numActions).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return persistence.ErrGameNotFound
+	}
+
+	_, err = g.db.Exec(deleteGamesAfter, id, numActions)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.db.Exec(deleteGameEventsAfter, id, numActions)
+	return err
+}
+
+func (g *gameService) UpdateDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error {
+	dls, err := serializeDeadlines(deadlines)
+	if err != nil {
+		return err
+	}
+	next := nextDeadline(model.Game{Deadlines: deadlines})
+
+	_, err = g.db.Exec(updateLatestDeadlines, dls, next, id)
+	return err
+}
+
+func (g *gameService) GetOverdue(now time.Time) ([]model.GameID, error) {
+	rows, err := g.db.Query(queryOverdueGames, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gIDs []model.GameID
+	for rows.Next() {
+		var gID model.GameID
+		err = rows.Scan(&gID)
+		if err != nil {
+			return nil, err
+		}
+		gIDs = append(gIDs, gID)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return gIDs, nil
+}
+
+func (g *gameService) GetWithStatus(ids []model.GameID, status model.GameStatus) ([]model.GameID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	ifs := make([]interface{}, 0, len(ids)+1)
+	for _, id := range ids {
+		ifs = append(ifs, id)
+	}
+	ifs = append(ifs, status)
+	placeholders := strings.TrimSuffix(strings.Repeat(`?, `, len(ids)), `, `)
+
+	rows, err := g.db.Query(fmt.Sprintf(queryGamesWithStatusFmt, placeholders), ifs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gIDs []model.GameID
+	for rows.Next() {
+		var gID model.GameID
+		err = rows.Scan(&gID)
+		if err != nil {
+			return nil, err
+		}
+		gIDs = append(gIDs, gID)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return gIDs, nil
+}
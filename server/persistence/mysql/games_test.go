@@ -135,3 +135,12 @@ func TestSerializeAndDeserializeAllTheThings(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, peggedCpy, actPC)
 }
+
+func TestPopulateScores(t *testing.T) {
+	cur, lag := populateScores(
+		[len(scoredColors)]uint8{5, 0, 0, 0, 121},
+		[len(scoredColors)]uint8{3, 0, 0, 0, 110},
+	)
+	assert.Equal(t, map[model.PlayerColor]int{model.Blue: 5, model.Purple: 121}, cur)
+	assert.Equal(t, map[model.PlayerColor]int{model.Blue: 3, model.Purple: 110}, lag)
+}
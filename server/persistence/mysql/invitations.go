@@ -0,0 +1,306 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// Invitations stores the invitations to sit down for a game.
+	// The columns act as follows:
+	// InvitationID is a UUID to identify an invitation
+	// Host is the player who sent the invitation
+	// NumPlayers is how many players the game is for
+	// Open is true if anyone can join the invitation from the lobby
+	// Options is the json encoded model.GameOptions the game will be played with
+	// Status is the model.InvitationStatus
+	// Expires is when the invitation expires if not everyone has accepted it
+	// GameID is the game that was created, which is 0 until everyone accepts
+	createInvitationsTable = `CREATE TABLE IF NOT EXISTS Invitations (
+		InvitationID INT UNSIGNED,
+		Host VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		NumPlayers TINYINT UNSIGNED,
+		Open BOOL,
+		Options BLOB,
+		Status TINYINT,
+		Expires TIMESTAMP(6),
+		GameID INT UNSIGNED,
+		PRIMARY KEY (InvitationID),
+		INDEX (Open, Status)
+	) ENGINE = INNODB;`
+
+	// InvitationPlayers stores who has a seat at an invitation, with one row per player.
+	// The columns act as follows:
+	// InvitationID is the invitation the player is seated at
+	// PlayerID is the player with the seat
+	// Seat is where the player will sit at the table, starting at 0
+	// Accepted is true once the player has accepted the invitation
+	createInvitationPlayersTable = `CREATE TABLE IF NOT EXISTS InvitationPlayers (
+		InvitationID INT UNSIGNED,
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		Seat TINYINT UNSIGNED,
+		Accepted BOOL,
+		PRIMARY KEY (InvitationID, PlayerID),
+		INDEX (PlayerID)
+	) ENGINE = INNODB;`
+
+	queryInvitation = `SELECT
+		Host, NumPlayers, Open, Options, Status, Expires, GameID
+	FROM Invitations
+		WHERE InvitationID = ?
+	;`
+
+	queryInvitationPlayers = `SELECT
+		PlayerID, Accepted
+	FROM InvitationPlayers
+		WHERE InvitationID = ?
+	ORDER BY
+		Seat
+	;`
+
+	queryPendingInvitationsForPlayer = `SELECT
+		i.InvitationID
+	FROM Invitations i
+	INNER JOIN InvitationPlayers p
+		ON i.InvitationID = p.InvitationID
+	WHERE p.PlayerID = ? AND i.Status = ?
+	ORDER BY
+		i.Expires, i.InvitationID
+	;`
+
+	queryOpenInvitations = `SELECT
+		InvitationID
+	FROM Invitations
+	WHERE Open = TRUE AND Status = ?
+	ORDER BY
+		Expires, InvitationID
+	;`
+
+	createInvitation = `INSERT INTO Invitations
+		(InvitationID, Host, NumPlayers, Open, Options, Status, Expires, GameID)
+	VALUES
+		(?, ?, ?, ?, ?, ?, ?, ?)
+	;`
+
+	updateInvitation = `UPDATE Invitations
+	SET
+		Status = ?,
+		GameID = ?
+	WHERE
+		InvitationID = ?
+	;`
+
+	addInvitationPlayer = `INSERT INTO InvitationPlayers
+		(InvitationID, PlayerID, Seat, Accepted)
+	VALUES
+		(?, ?, ?, ?)
+	;`
+
+	removeInvitationPlayers = `DELETE FROM InvitationPlayers
+	WHERE
+		InvitationID = ?
+	;`
+)
+
+var (
+	invitationsCreateStmts = []string{
+		createInvitationsTable,
+		createInvitationPlayersTable,
+	}
+)
+
+var _ persistence.InvitationService = (*invitationService)(nil)
+
+type invitationService struct {
+	db *txWrapper
+}
+
+func getInvitationService(
+	db *txWrapper,
+) persistence.InvitationService {
+
+	return &invitationService{
+		db: db,
+	}
+}
+
+func (is *invitationService) Get(id model.InvitationID) (model.Invitation, error) {
+	inv := model.Invitation{
+		ID: id,
+	}
+	var serOptions []byte
+
+	r := is.db.QueryRow(queryInvitation, id)
+	err := r.Scan(
+		&inv.Host,
+		&inv.NumPlayers,
+		&inv.Open,
+		&serOptions,
+		&inv.Status,
+		&inv.Expires,
+		&inv.GameID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Invitation{}, persistence.ErrInvitationNotFound
+		}
+		return model.Invitation{}, err
+	}
+
+	inv.Options, err = getGameOptions(serOptions)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	err = is.populatePlayers(&inv)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	return inv, nil
+}
+
+func (is *invitationService) populatePlayers(inv *model.Invitation) error {
+	rows, err := is.db.Query(queryInvitationPlayers, inv.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pID model.PlayerID
+		var accepted bool
+		err = rows.Scan(&pID, &accepted)
+		if err != nil {
+			return err
+		}
+		inv.PlayerIDs = append(inv.PlayerIDs, pID)
+		if accepted {
+			inv.Accepted = append(inv.Accepted, pID)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (is *invitationService) GetPending(pID model.PlayerID) ([]model.Invitation, error) {
+	return is.getAll(queryPendingInvitationsForPlayer, pID, model.InvitationPending)
+}
+
+func (is *invitationService) GetOpen() ([]model.Invitation, error) {
+	return is.getAll(queryOpenInvitations, model.InvitationPending)
+}
+
+// getAll returns every invitation whose ID is returned by the query
+func (is *invitationService) getAll(query string, args ...interface{}) ([]model.Invitation, error) {
+	rows, err := is.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []model.InvitationID
+	for rows.Next() {
+		var id model.InvitationID
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	invs := make([]model.Invitation, 0, len(ids))
+	for _, id := range ids {
+		inv, err := is.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		invs = append(invs, inv)
+	}
+
+	return invs, nil
+}
+
+func (is *invitationService) Create(inv model.Invitation) error {
+	serOptions, err := serializeGameOptions(inv.Options)
+	if err != nil {
+		return err
+	}
+
+	_, err = is.db.Exec(
+		createInvitation,
+		inv.ID,
+		inv.Host,
+		inv.NumPlayers,
+		inv.Open,
+		serOptions,
+		inv.Status,
+		inv.Expires,
+		inv.GameID,
+	)
+	err = convertMysqlError(err)
+	if err != nil {
+		if err == errDuplicateEntry {
+			return persistence.ErrInvitationAlreadyExists
+		}
+		return err
+	}
+
+	return is.savePlayers(inv)
+}
+
+func (is *invitationService) Save(inv model.Invitation) error {
+	_, err := is.Get(inv.ID)
+	if err != nil {
+		return err
+	}
+
+	// the host, options, and expiration of an invitation never change
+	_, err = is.db.Exec(
+		updateInvitation,
+		inv.Status,
+		inv.GameID,
+		inv.ID,
+	)
+	err = convertMysqlError(err)
+	if err != nil {
+		return err
+	}
+
+	// players can come and go from open invitations, so reseat all of them
+	_, err = is.db.Exec(removeInvitationPlayers, inv.ID)
+	err = convertMysqlError(err)
+	if err != nil {
+		return err
+	}
+
+	return is.savePlayers(inv)
+}
+
+func (is *invitationService) savePlayers(inv model.Invitation) error {
+	accepted := make(map[model.PlayerID]bool, len(inv.Accepted))
+	for _, pID := range inv.Accepted {
+		accepted[pID] = true
+	}
+
+	for i, pID := range inv.PlayerIDs {
+		_, err := is.db.Exec(
+			addInvitationPlayer,
+			inv.ID,
+			pID,
+			i,
+			accepted[pID],
+		)
+		err = convertMysqlError(err)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
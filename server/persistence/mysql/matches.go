@@ -0,0 +1,150 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// Matches stores a best-of-N series of games.
+	// The columns act as follows:
+	// MatchID is a UUID to identify a match
+	// BestOf is the number of games the match is played over
+	// PlayerIDs is the json encoded slice of players in the match
+	// Options is the json encoded model.GameOptions each game is played with
+	// GameIDs is the json encoded slice of games played in the match so far
+	createMatchesTable = `CREATE TABLE IF NOT EXISTS Matches (
+		MatchID INT UNSIGNED,
+		BestOf TINYINT UNSIGNED,
+		PlayerIDs BLOB,
+		Options BLOB,
+		GameIDs BLOB,
+		PRIMARY KEY (MatchID)
+	) ENGINE = INNODB;`
+
+	queryMatch = `SELECT
+		BestOf, PlayerIDs, Options, GameIDs
+	FROM Matches
+		WHERE MatchID = ?
+	;`
+
+	createMatch = `INSERT INTO Matches
+		(MatchID, BestOf, PlayerIDs, Options, GameIDs)
+	VALUES
+		(?, ?, ?, ?, ?)
+	;`
+
+	updateMatchGames = `UPDATE Matches
+	SET
+		GameIDs = ?
+	WHERE
+		MatchID = ?
+	;`
+)
+
+var (
+	matchesCreateStmts = []string{
+		createMatchesTable,
+	}
+)
+
+var _ persistence.MatchService = (*matchService)(nil)
+
+type matchService struct {
+	db *txWrapper
+}
+
+func getMatchService(
+	db *txWrapper,
+) persistence.MatchService {
+
+	return &matchService{
+		db: db,
+	}
+}
+
+func (ms *matchService) Get(id model.MatchID) (model.Match, error) {
+	m := model.Match{
+		ID: id,
+	}
+	var serPlayerIDs, serOptions, serGameIDs []byte
+
+	r := ms.db.QueryRow(queryMatch, id)
+	err := r.Scan(
+		&m.BestOf,
+		&serPlayerIDs,
+		&serOptions,
+		&serGameIDs,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Match{}, persistence.ErrMatchNotFound
+		}
+		return model.Match{}, err
+	}
+
+	err = json.Unmarshal(serPlayerIDs, &m.PlayerIDs)
+	if err != nil {
+		return model.Match{}, err
+	}
+	m.Options, err = getGameOptions(serOptions)
+	if err != nil {
+		return model.Match{}, err
+	}
+	err = json.Unmarshal(serGameIDs, &m.GameIDs)
+	if err != nil {
+		return model.Match{}, err
+	}
+
+	return m, nil
+}
+
+func (ms *matchService) Create(m model.Match) error {
+	serPlayerIDs, err := json.Marshal(m.PlayerIDs)
+	if err != nil {
+		return err
+	}
+	serOptions, err := serializeGameOptions(m.Options)
+	if err != nil {
+		return err
+	}
+	serGameIDs, err := json.Marshal(m.GameIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = ms.db.Exec(
+		createMatch,
+		m.ID,
+		m.BestOf,
+		serPlayerIDs,
+		serOptions,
+		serGameIDs,
+	)
+	err = convertMysqlError(err)
+	if err != nil {
+		if err == errDuplicateEntry {
+			return persistence.ErrMatchAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (ms *matchService) Save(m model.Match) error {
+	// the players, options, and length of a match never change
+	serGameIDs, err := json.Marshal(m.GameIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = ms.db.Exec(
+		updateMatchGames,
+		serGameIDs,
+		m.ID,
+	)
+	return convertMysqlError(err)
+}
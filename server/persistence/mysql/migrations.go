@@ -0,0 +1,487 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/joshprzybyszewski/cribbage/jsonutils"
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+const (
+	// SchemaVersion stores which migrations have been run on the database, with one
+	//   row per migration.
+	createSchemaVersionTable = `CREATE TABLE IF NOT EXISTS SchemaVersion (
+		Version INT UNSIGNED,
+		Time TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (Version)
+	) ENGINE = INNODB;`
+
+	querySchemaVersion = `SELECT
+		COALESCE(MAX(Version), 0)
+	FROM SchemaVersion
+	;`
+
+	// insertSchemaVersion is IGNORE so that two servers starting up at the same
+	// time don't fail on the same migration
+	insertSchemaVersion = `INSERT IGNORE INTO SchemaVersion
+		(
+			Version
+		)
+	VALUES
+		(
+			?
+		)
+	;`
+
+	queryTableExists = `SELECT
+		COUNT(*)
+	FROM information_schema.TABLES
+	WHERE TABLE_SCHEMA = DATABASE() AND
+		TABLE_NAME = ?
+	;`
+
+	queryColumnExists = `SELECT
+		COUNT(*)
+	FROM information_schema.COLUMNS
+	WHERE TABLE_SCHEMA = DATABASE() AND
+		TABLE_NAME = ? AND
+		COLUMN_NAME = ?
+	;`
+
+	queryIndexExists = `SELECT
+		COUNT(*)
+	FROM information_schema.STATISTICS
+	WHERE TABLE_SCHEMA = DATABASE() AND
+		TABLE_NAME = ? AND
+		COLUMN_NAME = ?
+	;`
+
+	addGamesOptions        = `ALTER TABLE Games ADD COLUMN Options BLOB;`
+	addGamesResult         = `ALTER TABLE Games ADD COLUMN Result BLOB;`
+	addGamesEvents         = `ALTER TABLE Games ADD COLUMN Events MEDIUMBLOB;`
+	addGamesSeed           = `ALTER TABLE Games ADD COLUMN Seed BIGINT;`
+	addGamesScoreYellow    = `ALTER TABLE Games ADD COLUMN ScoreYellow TINYINT UNSIGNED AFTER ScoreGreen;`
+	addGamesScorePurple    = `ALTER TABLE Games ADD COLUMN ScorePurple TINYINT UNSIGNED AFTER ScoreYellow;`
+	addGamesScoreYellowLag = `ALTER TABLE Games ADD COLUMN ScoreYellowLag TINYINT UNSIGNED AFTER ScoreGreenLag;`
+	addGamesScorePurpleLag = `ALTER TABLE Games ADD COLUMN ScorePurpleLag TINYINT UNSIGNED AFTER ScoreYellowLag;`
+	addGamesDeadlines      = `ALTER TABLE Games ADD COLUMN Deadlines BLOB;`
+	addGamesNextDeadline   = `ALTER TABLE Games ADD COLUMN NextDeadline TIMESTAMP NULL;`
+	addGamesStatus         = `ALTER TABLE Games ADD COLUMN Status TINYINT UNSIGNED DEFAULT 0;`
+
+	addGamesNextDeadlineIndex = `ALTER TABLE Games ADD INDEX (NextDeadline);`
+
+	// the scores for the new colors are read into uint8s, so they can't be NULL
+	fillGamesNewScores = `UPDATE Games
+	SET
+		ScoreYellow = COALESCE(ScoreYellow, 0),
+		ScorePurple = COALESCE(ScorePurple, 0),
+		ScoreYellowLag = COALESCE(ScoreYellowLag, 0),
+		ScorePurpleLag = COALESCE(ScorePurpleLag, 0)
+	WHERE ScoreYellow IS NULL OR
+		ScorePurple IS NULL OR
+		ScoreYellowLag IS NULL OR
+		ScorePurpleLag IS NULL
+	;`
+
+	// games that are over have a result, except for the ones from before the Options
+	// and Result columns, which were all played to the standard score
+	fillGamesCompletedStatus = `UPDATE Games
+	SET
+		Status = ?
+	WHERE Status = ? AND
+		(
+			Result IS NOT NULL OR
+			(Options IS NULL AND (ScoreBlue >= ? OR ScoreRed >= ? OR ScoreGreen >= ?))
+		)
+	;`
+
+	// every state of a game used to have a copy of all of the events before it, so
+	// the latest state of each game has all of them
+	queryLatestGamesEvents = `SELECT 
+		g.GameID, g.Events
+	FROM Games g
+	INNER JOIN (
+		SELECT GameID, MAX(NumActions) AS NumActions
+		FROM Games
+		GROUP BY GameID
+	) latest
+		ON g.GameID = latest.GameID AND
+		g.NumActions = latest.NumActions
+	WHERE g.Events IS NOT NULL
+	;`
+
+	// copyGameEvent is IGNORE so that it can be run again if the migration is
+	// stopped part way through
+	copyGameEvent = `INSERT IGNORE INTO GameEvents
+		(
+			GameID, EventIndex, NumActions, Event
+		)
+	VALUES
+		(
+			?, ?, ?, ?
+		)
+	;`
+
+	dropGamesEvents = `ALTER TABLE Games DROP COLUMN Events;`
+
+	renameGamePlayersToV1 = `RENAME TABLE GamePlayers TO GamePlayersV1;`
+
+	// copySeatsFromGamePlayersV1 is IGNORE so that it can be run again if the
+	// migration is stopped part way through
+	copySeatsFromGamePlayersV1 = `INSERT IGNORE INTO GamePlayers
+		(
+			GameID, Seat, PlayerID
+		)
+	SELECT GameID, 0, Player1ID FROM GamePlayersV1
+	UNION ALL
+	SELECT GameID, 1, Player2ID FROM GamePlayersV1
+	UNION ALL
+	SELECT GameID, 2, Player3ID FROM GamePlayersV1 WHERE Player3ID IS NOT NULL
+	UNION ALL
+	SELECT GameID, 3, Player4ID FROM GamePlayersV1 WHERE Player4ID IS NOT NULL
+	;`
+
+	dropGamePlayersV1 = `DROP TABLE GamePlayersV1;`
+)
+
+var (
+	errNoTables = errors.New(`the database has no tables: run with mysql_create_tables to create them`)
+)
+
+// migration brings a database from the previous version of the schema up to its version.
+// MySQL commits after every ALTER TABLE, so a migration can't be rolled back. Instead, each
+// one checks what's already been done, so that it can be run again if it fails part way.
+type migration struct {
+	version uint
+	migrate func(ctx context.Context, db *sql.DB) error
+}
+
+// migrations are in order of their versions. A database created with the original schema
+// is at version 0.
+var migrations = []migration{{
+	version: 1,
+	migrate: addGamesColumn(`Options`, addGamesOptions),
+}, {
+	version: 2,
+	migrate: migrateGamesResults,
+}, {
+	version: 3,
+	migrate: addGamesColumn(`Events`, addGamesEvents),
+}, {
+	version: 4,
+	migrate: addGamesColumn(`Seed`, addGamesSeed),
+}, {
+	version: 5,
+	migrate: migrateGamesNewColors,
+}, {
+	version: 6,
+	migrate: migrateGamePlayersSeats,
+}, {
+	version: 7,
+	migrate: migrateGamesDeadlines,
+}, {
+	version: 8,
+	migrate: migrateGamesStatus,
+}, {
+	version: 9,
+	migrate: createTables(accountsCreateStmts),
+}, {
+	version: 10,
+	migrate: createTables(spectatorsCreateStmts),
+}, {
+	version: 11,
+	migrate: createTables(invitationsCreateStmts),
+}, {
+	version: 12,
+	migrate: createTables(statsCreateStmts),
+}, {
+	version: 13,
+	migrate: createTables(ratingsCreateStmts),
+}, {
+	version: 14,
+	migrate: migrateGameEvents,
+}}
+
+// latestSchemaVersion is the version of the schema in the create statements
+func latestSchemaVersion() uint {
+	return migrations[len(migrations)-1].version
+}
+
+// runMigrations runs any migrations that the database hasn't had yet. A database without
+// any games is only created at the latest version when the create statements can be run.
+func runMigrations(ctx context.Context, db *sql.DB, createStmts []string, canCreate bool) error {
+	hasGames, err := tableExists(ctx, db, `Games`)
+	if err != nil {
+		return err
+	}
+	if !hasGames && !canCreate {
+		return errNoTables
+	}
+
+	_, err = db.ExecContext(ctx, createSchemaVersionTable)
+	if err != nil {
+		return err
+	}
+
+	if !hasGames {
+		err = runCreateStmts(ctx, db, createStmts)
+		if err != nil {
+			return err
+		}
+		_, err = db.ExecContext(ctx, insertSchemaVersion, latestSchemaVersion())
+		return err
+	}
+
+	var version uint
+	err = db.QueryRowContext(ctx, querySchemaVersion).Scan(&version)
+	if err != nil {
+		return err
+	}
+	return migrateFrom(ctx, db, version)
+}
+
+func migrateFrom(ctx context.Context, db *sql.DB, version uint) error {
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+
+		err := m.migrate(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.ExecContext(ctx, insertSchemaVersion, m.version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runCreateStmts(ctx context.Context, db *sql.DB, createStmts []string) error {
+	for _, createStmt := range createStmts {
+		_, err := db.ExecContext(ctx, createStmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateGameEvents moves the events of each game out of Games, where every state
+// had a copy of them, into GameEvents, where each one is only stored once
+func migrateGameEvents(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, createGameEventsTable)
+	if err != nil {
+		return err
+	}
+
+	var numCols int
+	err = db.QueryRowContext(ctx, queryColumnExists, `Games`, `Events`).Scan(&numCols)
+	if err != nil || numCols == 0 {
+		return err
+	}
+
+	gamesEvents, err := getLatestGamesEvents(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for gID, ser := range gamesEvents {
+		err = copyGameEvents(ctx, db, gID, ser)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.ExecContext(ctx, dropGamesEvents)
+	return err
+}
+
+// getLatestGamesEvents returns the serialized events of the latest state of each game
+func getLatestGamesEvents(ctx context.Context, db *sql.DB) (map[model.GameID][]byte, error) {
+	rows, err := db.QueryContext(ctx, queryLatestGamesEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	gamesEvents := map[model.GameID][]byte{}
+	for rows.Next() {
+		var gID model.GameID
+		var ser []byte
+		err = rows.Scan(&gID, &ser)
+		if err != nil {
+			return nil, err
+		}
+		gamesEvents[gID] = ser
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return gamesEvents, nil
+}
+
+// copyGameEvents saves each of the game's events with the number of actions the game
+// had when it happened, which is the state of the game it was first saved with
+func copyGameEvents(ctx context.Context, db *sql.DB, gID model.GameID, ser []byte) error {
+	raws := []json.RawMessage{}
+	err := json.Unmarshal(ser, &raws)
+	if err != nil {
+		return err
+	}
+
+	var ge model.GameEvent
+	for i, raw := range raws {
+		ge, err = jsonutils.UnmarshalGameEvent(raw)
+		if err != nil {
+			return err
+		}
+		_, err = db.ExecContext(ctx, copyGameEvent, gID, i, ge.Action, []byte(raw))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTables returns a migration that creates the tables that were added to the schema
+func createTables(createStmts []string) func(ctx context.Context, db *sql.DB) error {
+	return func(ctx context.Context, db *sql.DB) error {
+		return runCreateStmts(ctx, db, createStmts)
+	}
+}
+
+// addGamesColumn returns a migration that adds the column to Games
+func addGamesColumn(column, alter string) func(ctx context.Context, db *sql.DB) error {
+	return func(ctx context.Context, db *sql.DB) error {
+		_, err := execUnless(ctx, db, queryColumnExists, column, alter)
+		return err
+	}
+}
+
+// migrateGamesResults adds the results of games, and the matches that they're played in
+func migrateGamesResults(ctx context.Context, db *sql.DB) error {
+	_, err := execUnless(ctx, db, queryColumnExists, `Result`, addGamesResult)
+	if err != nil {
+		return err
+	}
+	return runCreateStmts(ctx, db, matchesCreateStmts)
+}
+
+// migrateGamesNewColors adds the scores for the colors of the fifth and sixth players
+func migrateGamesNewColors(ctx context.Context, db *sql.DB) error {
+	for _, col := range []struct {
+		name  string
+		alter string
+	}{
+		{`ScoreYellow`, addGamesScoreYellow},
+		{`ScorePurple`, addGamesScorePurple},
+		{`ScoreYellowLag`, addGamesScoreYellowLag},
+		{`ScorePurpleLag`, addGamesScorePurpleLag},
+	} {
+		_, err := execUnless(ctx, db, queryColumnExists, col.name, col.alter)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := db.ExecContext(ctx, fillGamesNewScores)
+	return err
+}
+
+// migrateGamePlayersSeats moves GamePlayers from one row per game, with a column for each
+// player, to one row per seat
+func migrateGamePlayersSeats(ctx context.Context, db *sql.DB) error {
+	var numCols int
+	err := db.QueryRowContext(ctx, queryColumnExists, `GamePlayers`, `Player1ID`).Scan(&numCols)
+	if err != nil {
+		return err
+	}
+	if numCols > 0 {
+		_, err = db.ExecContext(ctx, renameGamePlayersToV1)
+		if err != nil {
+			return err
+		}
+	}
+
+	hasV1, err := tableExists(ctx, db, `GamePlayersV1`)
+	if err != nil {
+		return err
+	}
+	if !hasV1 {
+		return nil
+	}
+
+	return runCreateStmts(ctx, db, []string{
+		createGamePlayersTable,
+		copySeatsFromGamePlayersV1,
+		dropGamePlayersV1,
+	})
+}
+
+// migrateGamesDeadlines adds the turn timers, and the index to find the overdue games
+func migrateGamesDeadlines(ctx context.Context, db *sql.DB) error {
+	_, err := execUnless(ctx, db, queryColumnExists, `Deadlines`, addGamesDeadlines)
+	if err != nil {
+		return err
+	}
+
+	_, err = execUnless(ctx, db, queryColumnExists, `NextDeadline`, addGamesNextDeadline)
+	if err != nil {
+		return err
+	}
+
+	_, err = execUnless(ctx, db, queryIndexExists, `NextDeadline`, addGamesNextDeadlineIndex)
+	return err
+}
+
+// migrateGamesStatus adds the status of each game, and marks the ones that are over
+func migrateGamesStatus(ctx context.Context, db *sql.DB) error {
+	// the statuses are only filled in when the column is added, so that running this
+	// again doesn't change any that have been saved since
+	addedStatus, err := execUnless(ctx, db, queryColumnExists, `Status`, addGamesStatus)
+	if err != nil || !addedStatus {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, fillGamesCompletedStatus,
+		model.StatusCompleted, model.StatusActive,
+		model.WinningScore, model.WinningScore, model.WinningScore,
+	)
+	return err
+}
+
+// execUnless runs the statement unless Games already has the column that the
+// query looks for. It returns whether the statement was run.
+func execUnless(ctx context.Context, db *sql.DB, query, column, stmt string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, query, `Games`, column).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	_, err = db.ExecContext(ctx, stmt)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func tableExists(ctx context.Context, db *sql.DB, table string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, queryTableExists, table).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+const (
+	migrationsTestDatabaseName = `testing_cribbage_migrations`
+
+	// the Games and GamePlayers tables as they were first created
+	originalCreateGameTable = `CREATE TABLE IF NOT EXISTS Games (
+		GameID INT UNSIGNED,
+		NumActions INT UNSIGNED,
+		Time TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		ScoreBlue TINYINT UNSIGNED,
+		ScoreRed TINYINT UNSIGNED,
+		ScoreGreen TINYINT UNSIGNED,
+		ScoreBlueLag TINYINT UNSIGNED,
+		ScoreRedLag TINYINT UNSIGNED,
+		ScoreGreenLag TINYINT UNSIGNED,
+		Phase TINYINT UNSIGNED,
+		CutCard SMALLINT,
+		Crib INT,
+		CurrentDealer VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs,
+		BlockingPlayers BLOB,
+		Hands BLOB,
+		PeggedCards BLOB,
+		Action BLOB,
+		PRIMARY KEY (GameID, NumActions)
+	) ENGINE = INNODB;`
+
+	originalCreateGamePlayersTable = `CREATE TABLE IF NOT EXISTS GamePlayers (
+		GameID INT UNSIGNED,
+		Player1ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		Player2ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		Player3ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs,
+		Player4ID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs,
+		PRIMARY KEY (GameID)
+	) ENGINE = INNODB;`
+
+	originalInsertGamePlayers = `INSERT INTO GamePlayers
+		(GameID, Player1ID, Player2ID, Player3ID, Player4ID)
+	VALUES
+		(?, ?, ?, ?, ?)
+	;`
+
+	originalInsertGame = `INSERT INTO Games
+		(
+			GameID, NumActions,
+			ScoreBlue, ScoreRed, ScoreGreen,
+			ScoreBlueLag, ScoreRedLag, ScoreGreenLag,
+			Phase, CutCard, Crib,
+			CurrentDealer,
+			BlockingPlayers, Hands, PeggedCards, Action
+		)
+	VALUES
+		(
+			?, 0,
+			?, ?, ?,
+			0, 0, 0,
+			?, ?, ?,
+			?,
+			'{}', '{}', '[]', NULL
+		)
+	;`
+)
+
+// createOriginalDatabase makes a new database with the tables as they were first created,
+// with a game between two players that's still going, and a three player game that's over
+func createOriginalDatabase(ctx context.Context, cfg Config) error {
+	server := cfg
+	server.DatabaseName = ``
+	sdb, err := sql.Open(`mysql`, server.dsn())
+	if err != nil {
+		return err
+	}
+	defer sdb.Close()
+
+	for _, stmt := range []string{
+		`DROP DATABASE IF EXISTS ` + migrationsTestDatabaseName + `;`,
+		`CREATE DATABASE ` + migrationsTestDatabaseName + `;`,
+	} {
+		_, err = sdb.ExecContext(ctx, stmt)
+		if err != nil {
+			return err
+		}
+	}
+
+	db, err := sql.Open(`mysql`, cfg.dsn())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	stmts := []string{originalCreateGameTable, originalCreateGamePlayersTable}
+	stmts = append(stmts, playersCreateStmts...)
+	stmts = append(stmts, interactionCreateStmts...)
+	err = runCreateStmts(ctx, db, stmts)
+	if err != nil {
+		return err
+	}
+
+	noCut := int8(model.NumCardsPerDeck + 1)
+	for _, ifs := range [][]interface{}{
+		{originalInsertGamePlayers, 1, `alice`, `bob`, nil, nil},
+		{originalInsertGame, 1, 10, 20, 0, model.Deal, noCut, serializeCribCards(nil), `alice`},
+		{originalInsertGamePlayers, 2, `alice`, `bob`, `charlie`, nil},
+		{originalInsertGame, 2, 121, 90, 100, model.Counting, noCut, serializeCribCards(nil), `bob`},
+	} {
+		_, err = db.ExecContext(ctx, ifs[0].(string), ifs[1:]...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestMigrationsFromOriginalSchema(t *testing.T) {
+	if testing.Short() {
+		t.Skip(`the migrations need a mysql database`)
+	}
+
+	ctx := context.Background()
+
+	// We assume you have mysql stood up locally when running without -short
+	cfg := GetTestConfig()
+	cfg.DatabaseName = migrationsTestDatabaseName
+	err := createOriginalDatabase(ctx, cfg)
+	if err != nil {
+		t.Logf("Expected to connect, but got error: %q. This is expected when running locally.", err.Error())
+		cfg = GetTestConfigForLocal()
+		cfg.DatabaseName = migrationsTestDatabaseName
+		err = createOriginalDatabase(ctx, cfg)
+	}
+	require.NoError(t, err)
+
+	// running them a second time shouldn't change anything
+	for i := 0; i < 2; i++ {
+		dbf, err := NewFactory(ctx, cfg)
+		require.NoError(t, err)
+
+		db, err := dbf.New(ctx)
+		require.NoError(t, err)
+
+		g1, err := db.GetGame(model.GameID(1))
+		require.NoError(t, err)
+		assert.Equal(t, []model.Player{{ID: `alice`}, {ID: `bob`}}, g1.Players)
+		assert.Equal(t, model.StatusActive, g1.Status)
+		assert.Equal(t, model.Deal, g1.Phase)
+		assert.Empty(t, g1.Events)
+		assert.Nil(t, g1.Result)
+		assert.Nil(t, g1.Deadlines)
+
+		g2, err := db.GetGame(model.GameID(2))
+		require.NoError(t, err)
+		assert.Equal(t, []model.Player{{ID: `alice`}, {ID: `bob`}, {ID: `charlie`}}, g2.Players)
+		assert.Equal(t, model.StatusCompleted, g2.Status)
+
+		require.NoError(t, db.Close())
+		require.NoError(t, dbf.Close())
+	}
+}
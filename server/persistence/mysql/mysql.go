@@ -17,35 +17,30 @@ type mysqlDBFactory struct {
 }
 
 func NewFactory(ctx context.Context, config Config) (persistence.DBFactory, error) {
-	dsn := fmt.Sprintf(`%s:%s@tcp(%s:%d)`,
-		config.DSNUser,
-		config.DSNPassword,
-		config.DSNHost,
-		config.DSNPort,
-	)
-	if len(config.DatabaseName) > 0 {
-		dsn += `/` + config.DatabaseName
-	}
-	if len(config.DSNParams) > 0 {
-		dsn += `?` + config.DSNParams
-	}
-	db, err := sql.Open(`mysql`, dsn)
+	db, err := sql.Open(`mysql`, config.dsn())
 	if err != nil {
 		return nil, err
 	}
 
-	if config.RunCreateStmts {
-		allCreateStmts := make([]string, 0, len(gamesCreateStmts)+len(playersCreateStmts)+len(interactionCreateStmts))
-		allCreateStmts = append(allCreateStmts, gamesCreateStmts...)
-		allCreateStmts = append(allCreateStmts, playersCreateStmts...)
-		allCreateStmts = append(allCreateStmts, interactionCreateStmts...)
-
-		for _, createStmt := range allCreateStmts {
-			_, err := db.ExecContext(ctx, createStmt)
-			if err != nil {
-				return nil, err
-			}
-		}
+	allCreateStmts := make([]string, 0,
+		len(gamesCreateStmts)+len(playersCreateStmts)+len(interactionCreateStmts)+
+			len(matchesCreateStmts)+len(accountsCreateStmts)+len(spectatorsCreateStmts)+
+			len(invitationsCreateStmts)+len(statsCreateStmts)+len(ratingsCreateStmts),
+	)
+	allCreateStmts = append(allCreateStmts, gamesCreateStmts...)
+	allCreateStmts = append(allCreateStmts, playersCreateStmts...)
+	allCreateStmts = append(allCreateStmts, interactionCreateStmts...)
+	allCreateStmts = append(allCreateStmts, matchesCreateStmts...)
+	allCreateStmts = append(allCreateStmts, accountsCreateStmts...)
+	allCreateStmts = append(allCreateStmts, spectatorsCreateStmts...)
+	allCreateStmts = append(allCreateStmts, invitationsCreateStmts...)
+	allCreateStmts = append(allCreateStmts, statsCreateStmts...)
+	allCreateStmts = append(allCreateStmts, ratingsCreateStmts...)
+
+	// the migrations run on every startup, so that the tables are never behind the code
+	err = runMigrations(ctx, db, allCreateStmts, config.RunCreateStmts)
+	if err != nil {
+		return nil, err
 	}
 
 	return &mysqlDBFactory{
@@ -66,6 +61,12 @@ func (dbf *mysqlDBFactory) New(ctx context.Context) (persistence.DB, error) {
 		getGameService(&dbWrapper),
 		getPlayerService(&dbWrapper),
 		getInteractionService(&dbWrapper),
+		getMatchService(&dbWrapper),
+		getAccountService(&dbWrapper),
+		getSpectatorService(&dbWrapper),
+		getInvitationService(&dbWrapper),
+		getStatsService(&dbWrapper),
+		getRatingService(&dbWrapper),
 	)
 
 	mw := mysqlWrapper{
@@ -89,6 +90,21 @@ type Config struct {
 	RunCreateStmts bool
 }
 
+func (config Config) dsn() string {
+	// the slash is needed before any params, even without a database name
+	dsn := fmt.Sprintf(`%s:%s@tcp(%s:%d)/%s`,
+		config.DSNUser,
+		config.DSNPassword,
+		config.DSNHost,
+		config.DSNPort,
+		config.DatabaseName,
+	)
+	if len(config.DSNParams) > 0 {
+		dsn += `?` + config.DSNParams
+	}
+	return dsn
+}
+
 var _ persistence.DB = (*mysqlWrapper)(nil)
 
 type mysqlWrapper struct {
@@ -0,0 +1,236 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// Ratings stores each player's Glicko-2 rating in each pool they've played in.
+	// The columns act as follows:
+	// PlayerID is the player that is rated
+	// NumPlayers and Variant are the model.RatingPool the rating is for
+	// Rating, Deviation, and Volatility are the player's Glicko-2 rating
+	// Games is how many rated games the player has finished in the pool
+	// Updated is when the rating last changed
+	createRatingsTable = `CREATE TABLE IF NOT EXISTS Ratings (
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		NumPlayers TINYINT UNSIGNED,
+		Variant VARCHAR(32),
+		Rating DOUBLE,
+		Deviation DOUBLE,
+		Volatility DOUBLE,
+		Games INT UNSIGNED,
+		Updated TIMESTAMP(6),
+		PRIMARY KEY (PlayerID, NumPlayers, Variant),
+		INDEX (NumPlayers, Variant, Rating)
+	) ENGINE = INNODB;`
+
+	// RatingHistory stores how each game changed each player's rating.
+	// The columns act as follows:
+	// PlayerID is the player whose rating changed
+	// GameID is the game that changed it
+	// NumPlayers and Variant are the model.RatingPool of the rating
+	// RatingBefore and RatingAfter are the player's rating before and after the game
+	// Deviation is the deviation of the rating after the game
+	// ChangedAt is when the rating changed
+	createRatingHistoryTable = `CREATE TABLE IF NOT EXISTS RatingHistory (
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		GameID INT UNSIGNED,
+		NumPlayers TINYINT UNSIGNED,
+		Variant VARCHAR(32),
+		RatingBefore DOUBLE,
+		RatingAfter DOUBLE,
+		Deviation DOUBLE,
+		ChangedAt TIMESTAMP(6),
+		PRIMARY KEY (PlayerID, GameID),
+		INDEX (PlayerID, ChangedAt)
+	) ENGINE = INNODB;`
+
+	queryRating = `SELECT
+		Rating, Deviation, Volatility, Games, Updated
+	FROM Ratings
+		WHERE PlayerID = ? AND NumPlayers = ? AND Variant = ?
+	;`
+
+	saveRating = `INSERT INTO Ratings
+		(PlayerID, NumPlayers, Variant, Rating, Deviation, Volatility, Games, Updated)
+	VALUES
+		(?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		Rating = VALUES(Rating),
+		Deviation = VALUES(Deviation),
+		Volatility = VALUES(Volatility),
+		Games = VALUES(Games),
+		Updated = VALUES(Updated)
+	;`
+
+	// the zero NumPlayers and empty Variant of a persistence.LeaderboardFilter match every rating
+	queryLeaderboard = `SELECT
+		PlayerID, NumPlayers, Variant, Rating, Deviation, Volatility, Games, Updated
+	FROM Ratings
+		WHERE (? = 0 OR NumPlayers = ?) AND (? = '' OR Variant = ?)
+	ORDER BY
+		Rating DESC, PlayerID, NumPlayers, Variant
+	LIMIT ? OFFSET ?
+	;`
+
+	addRatingChange = `INSERT INTO RatingHistory
+		(PlayerID, GameID, NumPlayers, Variant, RatingBefore, RatingAfter, Deviation, ChangedAt)
+	VALUES
+		(?, ?, ?, ?, ?, ?, ?, ?)
+	;`
+
+	queryRatingHistory = `SELECT
+		GameID, NumPlayers, Variant, RatingBefore, RatingAfter, Deviation, ChangedAt
+	FROM RatingHistory
+		WHERE PlayerID = ?
+	ORDER BY
+		ChangedAt
+	;`
+)
+
+var (
+	ratingsCreateStmts = []string{
+		createRatingsTable,
+		createRatingHistoryTable,
+	}
+)
+
+var _ persistence.RatingService = (*ratingService)(nil)
+
+type ratingService struct {
+	db *txWrapper
+}
+
+func getRatingService(
+	db *txWrapper,
+) persistence.RatingService {
+
+	return &ratingService{
+		db: db,
+	}
+}
+
+func (rs *ratingService) Get(id model.PlayerID, pool model.RatingPool) (model.Rating, error) {
+	r := model.Rating{
+		PlayerID: id,
+		Pool:     pool,
+	}
+
+	row := rs.db.QueryRow(queryRating, id, pool.NumPlayers, pool.Variant)
+	err := row.Scan(
+		&r.Rating,
+		&r.Deviation,
+		&r.Volatility,
+		&r.Games,
+		&r.Updated,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Rating{}, persistence.ErrRatingNotFound
+		}
+		return model.Rating{}, err
+	}
+
+	return r, nil
+}
+
+func (rs *ratingService) Save(r model.Rating) error {
+	_, err := rs.db.Exec(
+		saveRating,
+		r.PlayerID,
+		r.Pool.NumPlayers,
+		r.Pool.Variant,
+		r.Rating,
+		r.Deviation,
+		r.Volatility,
+		r.Games,
+		r.Updated,
+	)
+	return convertMysqlError(err)
+}
+
+func (rs *ratingService) AddChange(rc model.RatingChange) error {
+	_, err := rs.db.Exec(
+		addRatingChange,
+		rc.PlayerID,
+		rc.GameID,
+		rc.Pool.NumPlayers,
+		rc.Pool.Variant,
+		rc.Before,
+		rc.After,
+		rc.Deviation,
+		rc.At,
+	)
+	return convertMysqlError(err)
+}
+
+func (rs *ratingService) GetHistory(id model.PlayerID) ([]model.RatingChange, error) {
+	rows, err := rs.db.Query(queryRatingHistory, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var h []model.RatingChange
+	for rows.Next() {
+		rc := model.RatingChange{
+			PlayerID: id,
+		}
+		err = rows.Scan(
+			&rc.GameID,
+			&rc.Pool.NumPlayers,
+			&rc.Pool.Variant,
+			&rc.Before,
+			&rc.After,
+			&rc.Deviation,
+			&rc.At,
+		)
+		if err != nil {
+			return nil, err
+		}
+		h = append(h, rc)
+	}
+
+	return h, rows.Err()
+}
+
+func (rs *ratingService) GetLeaderboard(f persistence.LeaderboardFilter) ([]model.Rating, error) {
+	rows, err := rs.db.Query(
+		queryLeaderboard,
+		f.NumPlayers,
+		f.NumPlayers,
+		f.Variant,
+		f.Variant,
+		f.Limit,
+		f.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []model.Rating
+	for rows.Next() {
+		r := model.Rating{}
+		err = rows.Scan(
+			&r.PlayerID,
+			&r.Pool.NumPlayers,
+			&r.Pool.Variant,
+			&r.Rating,
+			&r.Deviation,
+			&r.Volatility,
+			&r.Games,
+			&r.Updated,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, r)
+	}
+
+	return ratings, rows.Err()
+}
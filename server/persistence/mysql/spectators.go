@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// GameSpectators stores who is watching a game, with one row per spectator.
+	// The columns act as follows:
+	// GameID is the game being watched
+	// PlayerID is the player watching it
+	// Started is when they started watching, which orders the spectators
+	createGameSpectatorsTable = `CREATE TABLE IF NOT EXISTS GameSpectators (
+		GameID INT UNSIGNED,
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		Started TIMESTAMP(6) DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (GameID, PlayerID)
+	) ENGINE = INNODB;`
+
+	queryGameSpectators = `SELECT 
+		PlayerID
+	FROM GameSpectators
+	WHERE GameID = ?
+	ORDER BY
+		Started, PlayerID
+	;`
+
+	addGameSpectator = `INSERT INTO GameSpectators
+		(GameID, PlayerID)
+	VALUES
+		(?, ?)
+	;`
+)
+
+var (
+	spectatorsCreateStmts = []string{
+		createGameSpectatorsTable,
+	}
+)
+
+var _ persistence.SpectatorService = (*spectatorService)(nil)
+
+type spectatorService struct {
+	db *txWrapper
+}
+
+func getSpectatorService(
+	db *txWrapper,
+) persistence.SpectatorService {
+
+	return &spectatorService{
+		db: db,
+	}
+}
+
+func (ss *spectatorService) Get(id model.GameID) ([]model.PlayerID, error) {
+	rows, err := ss.db.Query(queryGameSpectators, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pIDs []model.PlayerID
+	for rows.Next() {
+		var pID model.PlayerID
+		err = rows.Scan(&pID)
+		if err != nil {
+			return nil, err
+		}
+		pIDs = append(pIDs, pID)
+	}
+
+	return pIDs, rows.Err()
+}
+
+func (ss *spectatorService) Add(id model.GameID, pID model.PlayerID) error {
+	_, err := ss.db.Exec(addGameSpectator, id, pID)
+	err = convertMysqlError(err)
+	if err != nil {
+		if err == errDuplicateEntry {
+			return persistence.ErrAlreadySpectating
+		}
+		return err
+	}
+	return nil
+}
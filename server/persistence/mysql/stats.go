@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+const (
+	// PlayerStats stores the totals of how each player has done in their finished games.
+	// The columns act as follows:
+	// PlayerID is the player that the stats are for
+	// Stats is the json encoded model.PlayerStats
+	createPlayerStatsTable = `CREATE TABLE IF NOT EXISTS PlayerStats (
+		PlayerID VARCHAR(` + maxPlayerUUIDLenStr + `) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_cs NOT NULL,
+		Stats BLOB,
+		PRIMARY KEY (PlayerID)
+	) ENGINE = INNODB;`
+
+	queryPlayerStats = `SELECT
+		Stats
+	FROM PlayerStats
+		WHERE PlayerID = ?
+	;`
+
+	savePlayerStats = `INSERT INTO PlayerStats
+		(PlayerID, Stats)
+	VALUES
+		(?, ?)
+	ON DUPLICATE KEY UPDATE
+		Stats = ?
+	;`
+)
+
+var (
+	statsCreateStmts = []string{
+		createPlayerStatsTable,
+	}
+)
+
+var _ persistence.StatsService = (*statsService)(nil)
+
+type statsService struct {
+	db *txWrapper
+}
+
+func getStatsService(
+	db *txWrapper,
+) persistence.StatsService {
+
+	return &statsService{
+		db: db,
+	}
+}
+
+func (ss *statsService) Get(id model.PlayerID) (model.PlayerStats, error) {
+	var serStats []byte
+
+	r := ss.db.QueryRow(queryPlayerStats, id)
+	err := r.Scan(&serStats)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.PlayerStats{}, persistence.ErrStatsNotFound
+		}
+		return model.PlayerStats{}, err
+	}
+
+	ps := model.PlayerStats{}
+	err = json.Unmarshal(serStats, &ps)
+	if err != nil {
+		return model.PlayerStats{}, err
+	}
+	ps.PlayerID = id
+
+	return ps, nil
+}
+
+func (ss *statsService) Save(ps model.PlayerStats) error {
+	serStats, err := json.Marshal(ps)
+	if err != nil {
+		return err
+	}
+
+	_, err = ss.db.Exec(
+		savePlayerStats,
+		ps.PlayerID,
+		serStats,
+		serStats,
+	)
+	return convertMysqlError(err)
+}
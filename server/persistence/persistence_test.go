@@ -37,6 +37,16 @@ var (
 		`saveGameMissingAction`:         testSaveGameWithMissingAction,
 		`saveInteraction`:               testSaveInteraction,
 		`addColorToGame`:                testAddPlayerColorToGame,
+		`createMatch`:                   testCreateMatch,
+		`rewindGame`:                    testRewindGame,
+		`gameDeadlines`:                 testGameDeadlines,
+		`gameStatus`:                    testGameStatus,
+		`createAccount`:                 testCreateAccount,
+		`addSpectators`:                 testAddSpectators,
+		`invitations`:                   testInvitations,
+		`playerStats`:                   testPlayerStats,
+		`ratings`:                       testRatings,
+		`leaderboard`:                   testLeaderboard,
 	}
 )
 
@@ -250,7 +260,15 @@ func testCreateGame(t *testing.T, name dbName, db persistence.DB) {
 		PlayerColors:    map[model.PlayerID]model.PlayerColor{},
 		CurrentScores:   map[model.PlayerColor]int{},
 		LagScores:       map[model.PlayerColor]int{},
-		Phase:           model.Pegging,
+		Options: model.GameOptions{
+			Muggins: true,
+			Rules: model.GameRules{
+				Variant:      model.ShortVariant,
+				WinningScore: 61,
+				HandSize:     6,
+			},
+		},
+		Phase: model.Pegging,
 		Hands: map[model.PlayerID][]model.Card{
 			alice.ID: {
 				model.NewCardFromString(`7s`),
@@ -358,6 +376,149 @@ func testSaveGameMultipleTimes(t *testing.T, name dbName, db persistence.DB) {
 	checkPersistedGame(t, name, db, gCopy)
 }
 
+func testRewindGame(t *testing.T, name dbName, db persistence.DB) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := play.CreateGame([]model.Player{alice, bob}, abAPIs)
+	require.NoError(t, err)
+
+	for i, p := range g.Players {
+		require.NoError(t, db.CreatePlayer(p))
+		if c, ok := g.PlayerColors[p.ID]; ok {
+			g.Players[i].Games = map[model.GameID]model.PlayerColor{
+				g.ID: c,
+			}
+		}
+	}
+
+	require.NoError(t, db.CreateGame(g))
+	assert.EqualError(t, db.RewindGame(g.ID, 1), persistence.ErrGameNotFound.Error())
+
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		ID:        alice.ID,
+		GameID:    g.ID,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 10},
+	}, abAPIs))
+	var dealt model.Game
+	persistenceGameCopy(&dealt, g)
+	require.NoError(t, db.SaveGame(g))
+
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		ID:        alice.ID,
+		GameID:    g.ID,
+		Overcomes: model.CribCard,
+		Action:    model.BuildCribAction{Cards: []model.Card{g.Hands[alice.ID][0], g.Hands[alice.ID][1]}},
+	}, abAPIs))
+	require.NoError(t, db.SaveGame(g))
+
+	require.NoError(t, db.RewindGame(g.ID, 1))
+	checkPersistedGame(t, name, db, dealt)
+
+	// the game can carry on from the state it was rewound to
+	require.NoError(t, play.HandleAction(&dealt, model.PlayerAction{
+		ID:        bob.ID,
+		GameID:    g.ID,
+		Overcomes: model.CribCard,
+		Action:    model.BuildCribAction{Cards: []model.Card{dealt.Hands[bob.ID][0], dealt.Hands[bob.ID][1]}},
+	}, abAPIs))
+	require.NoError(t, db.SaveGame(dealt))
+	actGame, err := db.GetGame(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, actGame.NumActions())
+	assert.Equal(t, bob.ID, actGame.Actions[1].ID)
+}
+
+func testGameDeadlines(t *testing.T, name dbName, db persistence.DB) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := play.CreateGameWithOptions([]model.Player{alice, bob}, model.GameOptions{
+		TurnTimeLimit: time.Minute,
+	}, abAPIs)
+	require.NoError(t, err)
+	for _, p := range g.Players {
+		require.NoError(t, db.CreatePlayer(p))
+	}
+	require.NoError(t, db.CreateGame(g))
+
+	dl, ok := g.NextDeadline()
+	require.True(t, ok)
+	gIDs, err := db.GetOverdueGames(dl.Add(-time.Second))
+	require.NoError(t, err)
+	assert.NotContains(t, gIDs, g.ID)
+	gIDs, err = db.GetOverdueGames(dl.Add(time.Second))
+	require.NoError(t, err)
+	assert.Contains(t, gIDs, g.ID)
+
+	// pushing the deadline back takes the game off of the overdue list
+	later := time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, db.UpdateGameDeadlines(g.ID, map[model.PlayerID]time.Time{
+		g.CurrentDealer: later,
+	}))
+	gIDs, err = db.GetOverdueGames(dl.Add(time.Second))
+	require.NoError(t, err)
+	assert.NotContains(t, gIDs, g.ID)
+
+	actGame, err := db.GetGame(g.ID)
+	require.NoError(t, err)
+	require.Len(t, actGame.Deadlines, 1)
+	assert.True(t, later.Equal(actGame.Deadlines[g.CurrentDealer]))
+	assert.Zero(t, actGame.NumActions())
+}
+
+func testGameStatus(t *testing.T, name dbName, db persistence.DB) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := play.CreateGame([]model.Player{alice, bob}, abAPIs)
+	require.NoError(t, err)
+	for _, p := range g.Players {
+		require.NoError(t, db.CreatePlayer(p))
+	}
+	require.NoError(t, db.CreateGame(g))
+
+	gIDs, err := db.GetPlayerGamesWithStatus(alice.ID, model.StatusActive)
+	require.NoError(t, err)
+	assert.Equal(t, []model.GameID{g.ID}, gIDs)
+	gIDs, err = db.GetPlayerGamesWithStatus(alice.ID, model.StatusResigned)
+	require.NoError(t, err)
+	assert.Empty(t, gIDs)
+
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        g.CurrentDealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 2},
+	}, abAPIs))
+	require.NoError(t, db.SaveGame(g))
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}, abAPIs))
+	require.NoError(t, db.SaveGame(g))
+
+	for _, pID := range []model.PlayerID{alice.ID, bob.ID} {
+		gIDs, err = db.GetPlayerGamesWithStatus(pID, model.StatusActive)
+		require.NoError(t, err)
+		assert.Empty(t, gIDs)
+		gIDs, err = db.GetPlayerGamesWithStatus(pID, model.StatusResigned)
+		require.NoError(t, err)
+		assert.Equal(t, []model.GameID{g.ID}, gIDs)
+	}
+
+	actGame, err := db.GetGame(g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusResigned, actGame.Status)
+	require.NotNil(t, actGame.Result)
+	assert.Equal(t, g.PlayerColors[alice.ID], actGame.Result.Winner)
+
+	// earlier states of the game were still being played
+	before, err := db.GetGameAction(g.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusActive, before.Status)
+}
+
 func testSaveInteraction(t *testing.T, name dbName, db persistence.DB) {
 	p1 := interaction.PlayerMeans{
 		PlayerID:      model.PlayerID(rand.String(50)),
@@ -397,6 +558,321 @@ func testSaveInteraction(t *testing.T, name dbName, db persistence.DB) {
 	assert.NotEqual(t, p1Copy, actPM)
 }
 
+func testCreateMatch(t *testing.T, name dbName, db persistence.DB) {
+	m := model.Match{
+		ID:        model.NewMatchID(),
+		PlayerIDs: []model.PlayerID{`alice`, `bob`},
+		Options: model.GameOptions{
+			Muggins: true,
+		},
+		BestOf:  3,
+		GameIDs: []model.GameID{model.NewGameID()},
+	}
+
+	assert.Equal(t, persistence.ErrInvalidMatchID, db.CreateMatch(model.Match{}))
+	require.NoError(t, db.CreateMatch(m))
+	assert.Equal(t, persistence.ErrMatchAlreadyExists, db.CreateMatch(m))
+
+	actMatch, err := db.GetMatch(m.ID)
+	require.NoError(t, err)
+	assert.Equal(t, m, actMatch)
+
+	m.GameIDs = append(m.GameIDs, model.NewGameID())
+	require.NoError(t, db.SaveMatch(m))
+
+	actMatch, err = db.GetMatch(m.ID)
+	require.NoError(t, err)
+	assert.Equal(t, m, actMatch)
+
+	_, err = db.GetMatch(model.NewMatchID())
+	assert.Equal(t, persistence.ErrMatchNotFound, err)
+}
+
+func testCreateAccount(t *testing.T, name dbName, db persistence.DB) {
+	pID := model.PlayerID(rand.String(50))
+	a := model.Account{
+		PlayerID:     pID,
+		PasswordHash: []byte(`not really a hash`),
+	}
+
+	assert.Equal(t, persistence.ErrInvalidPlayerID, db.CreateAccount(model.Account{}), name)
+	assert.Equal(t, persistence.ErrAccountNotFound, db.SaveAccount(a), name)
+	require.NoError(t, db.CreateAccount(a), name)
+	assert.Equal(t, persistence.ErrAccountAlreadyExists, db.CreateAccount(a), name)
+
+	actAccount, err := db.GetAccount(pID)
+	require.NoError(t, err, name)
+	assert.Equal(t, a.PasswordHash, actAccount.PasswordHash, name)
+	assert.True(t, actAccount.SignedOutAt.IsZero(), name)
+
+	// the databases don't all keep nanoseconds
+	a.SignedOutAt = time.Now().Truncate(time.Second)
+	require.NoError(t, db.SaveAccount(a), name)
+
+	actAccount, err = db.GetAccount(pID)
+	require.NoError(t, err, name)
+	assert.Equal(t, pID, actAccount.PlayerID, name)
+	assert.True(t, a.SignedOutAt.Equal(actAccount.SignedOutAt), name)
+
+	_, err = db.GetAccount(model.PlayerID(rand.String(50)))
+	assert.Equal(t, persistence.ErrAccountNotFound, err, name)
+}
+
+func testAddSpectators(t *testing.T, name dbName, db persistence.DB) {
+	gID := model.NewGameID()
+
+	pIDs, err := db.GetSpectators(gID)
+	require.NoError(t, err, name)
+	assert.Empty(t, pIDs, name)
+
+	alice := model.PlayerID(`alice` + rand.String(10))
+	bob := model.PlayerID(`bob` + rand.String(10))
+	assert.Equal(t, persistence.ErrInvalidPlayerID, db.AddSpectator(gID, model.InvalidPlayerID), name)
+	require.NoError(t, db.AddSpectator(gID, alice), name)
+	require.NoError(t, db.AddSpectator(gID, bob), name)
+	assert.Equal(t, persistence.ErrAlreadySpectating, db.AddSpectator(gID, alice), name)
+
+	pIDs, err = db.GetSpectators(gID)
+	require.NoError(t, err, name)
+	assert.ElementsMatch(t, []model.PlayerID{alice, bob}, pIDs, name)
+
+	// spectators are only watching the one game
+	pIDs, err = db.GetSpectators(model.NewGameID())
+	require.NoError(t, err, name)
+	assert.Empty(t, pIDs, name)
+}
+
+func checkPersistedInvitation(t *testing.T, name dbName, exp, act model.Invitation) {
+	assert.True(t, exp.Expires.Equal(act.Expires), name)
+	exp.Expires, act.Expires = time.Time{}, time.Time{}
+	assert.Equal(t, exp, act, name)
+}
+
+func invitationIDs(invs []model.Invitation) []model.InvitationID {
+	ids := make([]model.InvitationID, 0, len(invs))
+	for _, inv := range invs {
+		ids = append(ids, inv.ID)
+	}
+	return ids
+}
+
+func testInvitations(t *testing.T, name dbName, db persistence.DB) {
+	alice := model.PlayerID(`alice` + rand.String(10))
+	bob := model.PlayerID(`bob` + rand.String(10))
+	charlie := model.PlayerID(`charlie` + rand.String(10))
+	// the databases don't all keep nanoseconds
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	inv, err := model.NewInvitation(alice, []model.PlayerID{bob}, model.GameOptions{Muggins: true}, expires)
+	require.NoError(t, err, name)
+	open, err := model.NewOpenInvitation(charlie, 3, model.GameOptions{}, expires)
+	require.NoError(t, err, name)
+
+	assert.Equal(t, persistence.ErrInvalidInvitationID, db.CreateInvitation(model.Invitation{}), name)
+	assert.Equal(t, persistence.ErrInvitationNotFound, db.SaveInvitation(inv), name)
+	require.NoError(t, db.CreateInvitation(inv), name)
+	require.NoError(t, db.CreateInvitation(open), name)
+	assert.Equal(t, persistence.ErrInvitationAlreadyExists, db.CreateInvitation(inv), name)
+
+	act, err := db.GetInvitation(inv.ID)
+	require.NoError(t, err, name)
+	checkPersistedInvitation(t, name, inv, act)
+
+	invs, err := db.GetPendingInvitations(bob)
+	require.NoError(t, err, name)
+	require.Len(t, invs, 1, name)
+	checkPersistedInvitation(t, name, inv, invs[0])
+
+	invs, err = db.GetOpenInvitations()
+	require.NoError(t, err, name)
+	assert.Contains(t, invitationIDs(invs), open.ID, name)
+	assert.NotContains(t, invitationIDs(invs), inv.ID, name)
+
+	// players can join, and leave, open invitations
+	require.NoError(t, open.Accept(alice, time.Now()), name)
+	require.NoError(t, open.Accept(bob, time.Now()), name)
+	require.NoError(t, open.Decline(alice, time.Now()), name)
+	require.NoError(t, db.SaveInvitation(open), name)
+	act, err = db.GetInvitation(open.ID)
+	require.NoError(t, err, name)
+	checkPersistedInvitation(t, name, open, act)
+
+	invs, err = db.GetPendingInvitations(bob)
+	require.NoError(t, err, name)
+	assert.ElementsMatch(t, []model.InvitationID{inv.ID, open.ID}, invitationIDs(invs), name)
+
+	// the invitation is no longer pending once the game has been made
+	require.NoError(t, inv.Accept(bob, time.Now()), name)
+	inv.Status = model.InvitationAccepted
+	inv.GameID = model.NewGameID()
+	require.NoError(t, db.SaveInvitation(inv), name)
+	act, err = db.GetInvitation(inv.ID)
+	require.NoError(t, err, name)
+	checkPersistedInvitation(t, name, inv, act)
+
+	invs, err = db.GetPendingInvitations(alice)
+	require.NoError(t, err, name)
+	assert.Empty(t, invs, name)
+
+	_, err = db.GetInvitation(model.NewInvitationID())
+	assert.Equal(t, persistence.ErrInvitationNotFound, err, name)
+}
+
+func testPlayerStats(t *testing.T, name dbName, db persistence.DB) {
+	pID := model.PlayerID(rand.String(50))
+	ps := model.PlayerStats{
+		PlayerID:     pID,
+		Wins:         2,
+		Losses:       1,
+		SkunksGiven:  1,
+		Deals:        20,
+		PegPoints:    61,
+		Hands:        19,
+		HandPoints:   150,
+		Cribs:        10,
+		CribPoints:   43,
+		BestHand:     29,
+		BestHandGame: model.NewGameID(),
+		TwentyNines:  1,
+		Discards:     19,
+		PointsLeft:   7.25,
+	}
+
+	_, err := db.GetPlayerStats(pID)
+	assert.Equal(t, persistence.ErrStatsNotFound, err, name)
+	assert.Equal(t, persistence.ErrInvalidPlayerID, db.SavePlayerStats(model.PlayerStats{}), name)
+	require.NoError(t, db.SavePlayerStats(ps), name)
+
+	act, err := db.GetPlayerStats(pID)
+	require.NoError(t, err, name)
+	assert.Equal(t, ps, act, name)
+
+	ps.Losses++
+	ps.SkunksTaken++
+	require.NoError(t, db.SavePlayerStats(ps), name)
+
+	act, err = db.GetPlayerStats(pID)
+	require.NoError(t, err, name)
+	assert.Equal(t, ps, act, name)
+}
+
+func testRatings(t *testing.T, name dbName, db persistence.DB) {
+	pID := model.PlayerID(rand.String(50))
+	pool := model.RatingPool{
+		NumPlayers: 2,
+		Variant:    model.StandardVariant,
+	}
+	// the databases don't all keep nanoseconds
+	now := time.Now().Truncate(time.Second)
+	r := model.Rating{
+		PlayerID:   pID,
+		Pool:       pool,
+		Rating:     1500,
+		Deviation:  350,
+		Volatility: 0.06,
+	}
+
+	_, err := db.GetRating(pID, pool)
+	assert.Equal(t, persistence.ErrRatingNotFound, err, name)
+	assert.Equal(t, persistence.ErrInvalidPlayerID, db.SaveRating(model.Rating{}), name)
+	require.NoError(t, db.SaveRating(r), name)
+
+	act, err := db.GetRating(pID, pool)
+	require.NoError(t, err, name)
+	checkPersistedRating(t, name, r, act)
+
+	rc := model.RatingChange{
+		PlayerID:  pID,
+		Pool:      pool,
+		GameID:    model.NewGameID(),
+		Before:    r.Rating,
+		After:     1662.31,
+		Deviation: 290.32,
+		At:        now,
+	}
+	r.Rating = rc.After
+	r.Deviation = rc.Deviation
+	r.Volatility = 0.05999
+	r.Games++
+	r.Updated = now
+	require.NoError(t, db.SaveRating(r), name)
+	require.NoError(t, db.AddRatingChange(rc), name)
+
+	act, err = db.GetRating(pID, pool)
+	require.NoError(t, err, name)
+	checkPersistedRating(t, name, r, act)
+
+	// ratings in other pools are kept apart
+	_, err = db.GetRating(pID, model.RatingPool{NumPlayers: 4, Variant: model.StandardVariant})
+	assert.Equal(t, persistence.ErrRatingNotFound, err, name)
+
+	h, err := db.GetRatingHistory(pID)
+	require.NoError(t, err, name)
+	require.Len(t, h, 1, name)
+	assert.True(t, rc.At.Equal(h[0].At), name)
+	rc.At, h[0].At = time.Time{}, time.Time{}
+	assert.Equal(t, rc, h[0], name)
+}
+
+func testLeaderboard(t *testing.T, name dbName, db persistence.DB) {
+	// a variant of our own keeps out the ratings from the other tests
+	variant := rand.String(20)
+	pIDs := []model.PlayerID{
+		model.PlayerID(`a` + rand.String(40)),
+		model.PlayerID(`b` + rand.String(40)),
+		model.PlayerID(`c` + rand.String(40)),
+	}
+	for i, pID := range pIDs {
+		for _, np := range []int{2, 3} {
+			require.NoError(t, db.SaveRating(model.Rating{
+				PlayerID: pID,
+				Pool: model.RatingPool{
+					NumPlayers: np,
+					Variant:    variant,
+				},
+				Rating: float64(1400 + 100*i + np),
+			}), name)
+		}
+	}
+
+	getLeaderboard := func(f persistence.LeaderboardFilter) []model.PlayerID {
+		f.Variant = variant
+		ratings, err := db.GetLeaderboard(f)
+		require.NoError(t, err, name)
+		var act []model.PlayerID
+		for _, r := range ratings {
+			act = append(act, r.PlayerID)
+		}
+		return act
+	}
+
+	assert.Equal(t, []model.PlayerID{pIDs[2], pIDs[1], pIDs[0]}, getLeaderboard(persistence.LeaderboardFilter{
+		NumPlayers: 2,
+		Limit:      10,
+	}), name)
+	assert.Equal(t, []model.PlayerID{pIDs[1]}, getLeaderboard(persistence.LeaderboardFilter{
+		NumPlayers: 3,
+		Offset:     1,
+		Limit:      1,
+	}), name)
+	assert.Equal(t, []model.PlayerID{pIDs[2], pIDs[2], pIDs[1], pIDs[1]}, getLeaderboard(persistence.LeaderboardFilter{
+		Limit: 4,
+	}), name)
+	assert.Empty(t, getLeaderboard(persistence.LeaderboardFilter{
+		Offset: 6,
+		Limit:  10,
+	}), name)
+
+	_, err := db.GetLeaderboard(persistence.LeaderboardFilter{})
+	assert.Equal(t, persistence.ErrInvalidLeaderboardFilter, err, name)
+}
+
+func checkPersistedRating(t *testing.T, name dbName, exp, act model.Rating) {
+	assert.True(t, exp.Updated.Equal(act.Updated), name)
+	exp.Updated, act.Updated = time.Time{}, time.Time{}
+	assert.Equal(t, exp, act, name)
+}
+
 func testAddPlayerColorToGame(t *testing.T, name dbName, db persistence.DB) {
 	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
 
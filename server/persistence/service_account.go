@@ -0,0 +1,12 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type AccountService interface {
+	Get(id model.PlayerID) (model.Account, error)
+
+	Create(a model.Account) error
+	Save(a model.Account) error
+}
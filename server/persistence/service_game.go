@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/joshprzybyszewski/cribbage/model"
 )
 
@@ -11,4 +13,15 @@ type GameService interface {
 	UpdatePlayerColor(id model.GameID, pID model.PlayerID, color model.PlayerColor) error
 	Begin(g model.Game) error
 	Save(g model.Game) error
+
+	// Rewind throws away every state of the game after its first numActions actions
+	Rewind(id model.GameID, numActions uint) error
+
+	// UpdateDeadlines changes when the players need to act by in the latest state of the game
+	UpdateDeadlines(id model.GameID, deadlines map[model.PlayerID]time.Time) error
+	// GetOverdue returns the games that are waiting on a player whose deadline isn't after now
+	GetOverdue(now time.Time) ([]model.GameID, error)
+
+	// GetWithStatus returns which of the games have the status in their latest state
+	GetWithStatus(ids []model.GameID, status model.GameStatus) ([]model.GameID, error)
 }
@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type InvitationService interface {
+	Get(id model.InvitationID) (model.Invitation, error)
+	// GetPending returns the pending invitations that the player has a seat in
+	GetPending(pID model.PlayerID) ([]model.Invitation, error)
+	// GetOpen returns the pending invitations in the lobby that anyone can join
+	GetOpen() ([]model.Invitation, error)
+
+	Create(inv model.Invitation) error
+	Save(inv model.Invitation) error
+}
@@ -0,0 +1,12 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type MatchService interface {
+	Get(id model.MatchID) (model.Match, error)
+
+	Create(m model.Match) error
+	Save(m model.Match) error
+}
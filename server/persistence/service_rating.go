@@ -0,0 +1,30 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+// LeaderboardFilter picks out one page of the leaderboard
+type LeaderboardFilter struct {
+	// NumPlayers only includes ratings from games with that many players. Zero includes every game.
+	NumPlayers int
+	// Variant only includes ratings from games of that variant. Empty includes every variant.
+	Variant string
+
+	// Offset is how many of the highest ratings to skip, and Limit is how many to return after them
+	Offset int
+	Limit  int
+}
+
+type RatingService interface {
+	Get(id model.PlayerID, pool model.RatingPool) (model.Rating, error)
+	// Save creates the player's rating in the pool if they don't have one yet
+	Save(r model.Rating) error
+
+	AddChange(rc model.RatingChange) error
+	// GetHistory returns every change to the player's ratings, oldest first
+	GetHistory(id model.PlayerID) ([]model.RatingChange, error)
+
+	// GetLeaderboard returns the ratings that the filter picks out, highest first
+	GetLeaderboard(f LeaderboardFilter) ([]model.Rating, error)
+}
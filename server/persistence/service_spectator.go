@@ -0,0 +1,12 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type SpectatorService interface {
+	// Get returns who is watching the game, in the order they started
+	Get(id model.GameID) ([]model.PlayerID, error)
+
+	Add(id model.GameID, pID model.PlayerID) error
+}
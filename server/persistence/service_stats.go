@@ -0,0 +1,11 @@
+package persistence
+
+import (
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+type StatsService interface {
+	Get(id model.PlayerID) (model.PlayerStats, error)
+	// Save creates the player's stats if they don't have any yet
+	Save(ps model.PlayerStats) error
+}
@@ -24,6 +24,15 @@ func (*cribCountingHandler) HandleAction(
 	pAPIs map[model.PlayerID]interaction.Player,
 ) error {
 
+	if action.Overcomes == model.CallMuggins {
+		done, counter, err := handleCallMuggins(g, action, pAPIs)
+		if err != nil || !done || g.IsOver() {
+			return err
+		}
+		moveToNextDealer(g, counter)
+		return nil
+	}
+
 	if err := validateAction(g, action, model.CountCrib); err != nil {
 		return err
 	}
@@ -41,31 +50,42 @@ func (*cribCountingHandler) HandleAction(
 	crib := g.Crib
 	leadCard := g.CutCard
 	pts := scorer.CribPoints(leadCard, crib)
+	claimed := countedPoints(cca.Pts)
 
-	if cca.Pts == 19 {
-		cca.Pts = 0
-	}
-
-	if cca.Pts != pts {
+	if !canAcceptCount(g, claimed, pts) {
 		addPlayerToBlocker(g, pID, model.CountCrib, pAPIs, `you did not submit the correct number of points for the crib`)
 		return errors.New(`wrong number of points`)
 	}
 
-	addPoints(g, pID, pts, pAPIs, `crib (`+leadCard.String()+`: `+handString(crib)+`)`)
+	addPoints(g, pID, claimed, pAPIs, model.ScoreEvent{
+		Reason:    model.CribScore,
+		Cards:     append([]model.Card(nil), crib...),
+		CutCard:   &leadCard,
+		Breakdown: countedBreakdown(leadCard, crib, true, claimed, pts),
+	})
 
 	if g.IsOver() {
 		return nil
 	}
 	removePlayerFromBlockers(g, action)
 
-	// Move forward to dealing
+	if claimed < pts {
+		startMuggins(g, pID, pAPIs)
+		return nil
+	}
+
+	moveToNextDealer(g, pID)
+
+	return nil
+}
+
+// moveToNextDealer passes the deal to the player after the current dealer
+func moveToNextDealer(g *model.Game, dealer model.PlayerID) {
 	pIDs := playersToDealTo(g)
 	for i, id := range pIDs {
-		if id == pID {
+		if id == dealer {
 			g.CurrentDealer = pIDs[(i+1)%len(pIDs)]
 			break
 		}
 	}
-
-	return nil
 }
@@ -25,6 +25,15 @@ func (*handCountingHandler) HandleAction(g *model.Game,
 	pAPIs map[model.PlayerID]interaction.Player,
 ) error {
 
+	if action.Overcomes == model.CallMuggins {
+		done, counter, err := handleCallMuggins(g, action, pAPIs)
+		if err != nil || !done || g.IsOver() {
+			return err
+		}
+		blockNextHandCounter(g, counter, pAPIs)
+		return nil
+	}
+
 	if err := validateAction(g, action, model.CountHand); err != nil {
 		return err
 	}
@@ -38,22 +47,44 @@ func (*handCountingHandler) HandleAction(g *model.Game,
 	hand := g.Hands[pID]
 	leadCard := g.CutCard
 	pts := scorer.HandPoints(leadCard, hand)
+	claimed := countedPoints(cha.Pts)
 
-	if cha.Pts == 19 {
-		cha.Pts = 0
-	}
-
-	if cha.Pts != pts {
+	if !canAcceptCount(g, claimed, pts) {
 		addPlayerToBlocker(g, pID, model.CountHand, pAPIs, `you did not submit the correct number of points for your hand`)
 		return errors.New(`wrong number of points`)
 	}
 
-	addPoints(g, pID, pts, pAPIs, `hand (`+leadCard.String()+`: `+handString(hand)+`)`)
+	addPoints(g, pID, claimed, pAPIs, model.ScoreEvent{
+		Reason:    model.HandScore,
+		Cards:     append([]model.Card(nil), hand...),
+		CutCard:   &leadCard,
+		Breakdown: countedBreakdown(leadCard, hand, false, claimed, pts),
+	})
 
 	if g.IsOver() {
 		return nil
 	}
 
+	if claimed < pts {
+		removePlayerFromBlockers(g, action)
+		startMuggins(g, pID, pAPIs)
+		return nil
+	}
+
+	blockNextHandCounter(g, pID, pAPIs)
+	removePlayerFromBlockers(g, action)
+
+	return nil
+}
+
+// blockNextHandCounter adds the player who counts after pID as a blocker.
+// If pID was the last to count their hand, then nobody is added.
+func blockNextHandCounter(
+	g *model.Game,
+	pID model.PlayerID,
+	pAPIs map[model.PlayerID]interaction.Player,
+) {
+
 	pIDs := playersToDealTo(g)
 	nextScorerIndex := len(pIDs) // invalid index
 	for i, id := range pIDs {
@@ -67,7 +98,4 @@ func (*handCountingHandler) HandleAction(g *model.Game,
 		nextID := pIDs[nextScorerIndex]
 		addPlayerToBlocker(g, nextID, model.CountHand, pAPIs, ``)
 	}
-	removePlayerFromBlockers(g, action)
-
-	return nil
 }
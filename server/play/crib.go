@@ -10,14 +10,17 @@ import (
 
 var _ PhaseHandler = (*cribBuildingHandler)(nil)
 
+// cribSize is how many cards are in every crib
+const cribSize = 4
+
 type cribBuildingHandler struct{}
 
 func (*cribBuildingHandler) Start(g *model.Game, pAPIs map[model.PlayerID]interaction.Player) error {
 	// Clear out the previous crib before we start building this one
 	g.Crib = g.Crib[:0]
 
-	// Tell all of the players they need to give us the desired number of cards
-	pIDs := playersToDealTo(g)
+	// Tell all of the crib builders they need to give us the desired number of cards
+	pIDs := cribBuilders(g)
 	desired := numDesiredCribCards(g)
 	msg := fmt.Sprintf("needs to cut %d cards", desired)
 
@@ -57,13 +60,38 @@ func (*cribBuildingHandler) HandleAction(g *model.Game,
 	g.Crib = append(g.Crib, bca.Cards...)
 	g.Hands[action.ID] = removeSubset(g.Hands[action.ID], bca.Cards)
 
-	if len(g.BlockingPlayers) == 0 && len(g.Crib) != 4 {
+	if len(g.BlockingPlayers) == 0 && len(g.Crib) != cribSize {
 		return errors.New(`no remaining blockers, but not enough cards in the crib`)
 	}
 
 	return nil
 }
 
+// cribBuilders returns the players who discard into the crib, in the order they're
+// dealt to. With five players the dealer sits out, and with six the dealer's partner
+// sits out too, so that there are never more than four players building the crib.
+func cribBuilders(g *model.Game) []model.PlayerID {
+	pIDs := playersToDealTo(g)
+	if len(pIDs) <= cribSize {
+		return pIDs
+	}
+
+	sitsOut := map[model.PlayerID]struct{}{
+		g.CurrentDealer: {},
+	}
+	for _, pID := range g.Partners(g.CurrentDealer) {
+		sitsOut[pID] = struct{}{}
+	}
+
+	builders := make([]model.PlayerID, 0, cribSize)
+	for _, pID := range pIDs {
+		if _, ok := sitsOut[pID]; !ok {
+			builders = append(builders, pID)
+		}
+	}
+	return builders
+}
+
 func numDesiredCribCards(g *model.Game) int {
 	if len(g.Players) > 2 {
 		return 1
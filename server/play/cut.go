@@ -45,15 +45,17 @@ func (*cuttingHandler) HandleAction(
 		return err
 	}
 
-	if c.Value == model.JackValue {
-		// Check if the dealer was cut a jack
-		addPoints(g, g.CurrentDealer, 2, pAPIs, `his nibs`)
-	}
-
 	g.CutCard = c
+	addEvent(g, pAPIs, model.CutRevealedEvent{
+		Card: c,
+	})
 
-	for _, pAPI := range pAPIs {
-		_ = pAPI.NotifyMessage(*g, "Cut card "+g.CutCard.String())
+	if c.Value == model.JackValue {
+		// Check if the dealer was cut a jack
+		addPoints(g, g.CurrentDealer, 2, pAPIs, model.ScoreEvent{
+			Reason:  model.NibsScore,
+			CutCard: &c,
+		})
 	}
 
 	return nil
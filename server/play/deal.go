@@ -69,39 +69,59 @@ func (*dealingHandler) HandleAction(g *model.Game,
 		return err
 	}
 
+	if bonus := g.Options.Rules.PoneBonus; bonus > 0 && isFirstDeal(g) {
+		// the pone gets a head start to make up for not having the first crib
+		addPoints(g, playersToDealTo(g)[0], bonus, pAPIs, model.ScoreEvent{
+			Reason: model.PoneBonusScore,
+		})
+	}
+
 	return nil
 }
 
+// isFirstDeal returns true if nobody has dealt yet in this game
+func isFirstDeal(g *model.Game) bool {
+	for _, a := range g.Actions {
+		if _, ok := a.Action.(model.DealAction); ok {
+			return false
+		}
+	}
+	return true
+}
+
 func deal(g *model.Game, deck model.Deck, pAPIs map[model.PlayerID]interaction.Player) error {
 	// Get the order of players we need to deal to
 	pIDs := playersToDealTo(g)
 
-	// Define how many cards we need to deal and the hand size
-	handSize := 6
-	switch len(pIDs) {
-	case 3, 4:
-		handSize = 5
+	// Everyone keeps the same number of cards, but only the crib builders
+	// are dealt the cards that they'll discard
+	keptHandSize := g.Options.Rules.KeptHandSize()
+	builders := make(map[model.PlayerID]struct{}, len(pIDs))
+	for _, pID := range cribBuilders(g) {
+		builders[pID] = struct{}{}
 	}
-	numCardsToDeal := handSize * len(pIDs)
+	handSize := keptHandSize + numDesiredCribCards(g)
 
-	for numDealt := 0; numDealt < numCardsToDeal; {
+	for round := 0; round < handSize; round++ {
 		for _, pID := range pIDs {
-			c := deck.Deal()
-			numDealt++
-			g.Hands[pID] = append(g.Hands[pID], c)
+			if _, ok := builders[pID]; !ok && round >= keptHandSize {
+				continue
+			}
+			g.Hands[pID] = append(g.Hands[pID], deck.Deal())
 		}
 	}
 
-	// For three player games, we need to deal another card to the crib
-	if len(pIDs) == 3 {
-		c := deck.Deal()
-		g.Crib = append(g.Crib, c)
+	// With three players, the crib needs another card from the deck
+	for len(builders)*numDesiredCribCards(g)+len(g.Crib) < cribSize {
+		g.Crib = append(g.Crib, deck.Deal())
 	}
 
 	// Now that the hands are all dealt, tell everyone about what they have
-	for pID, hand := range g.Hands {
-		handStr := handString(hand)
-		_ = pAPIs[pID].NotifyMessage(*g, `Received Hand `+handStr)
+	for _, pID := range pIDs {
+		addPrivateEvent(g, pID, pAPIs, model.CardDealtEvent{
+			PlayerID: pID,
+			Cards:    append([]model.Card(nil), g.Hands[pID]...),
+		})
 	}
 
 	return nil
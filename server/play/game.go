@@ -2,6 +2,7 @@ package play
 
 import (
 	"errors"
+	"time"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/server/interaction"
@@ -14,24 +15,39 @@ var (
 )
 
 func CreateGame(players []model.Player, pAPIs map[model.PlayerID]interaction.Player) (model.Game, error) {
+	return CreateGameWithOptions(players, model.GameOptions{}, pAPIs)
+}
+
+// CreateGameWithOptions creates a new game for the players using the provided house rules.
+// It returns an error if the game can't be played with the given options.
+func CreateGameWithOptions(
+	players []model.Player,
+	opts model.GameOptions,
+	pAPIs map[model.PlayerID]interaction.Player,
+) (model.Game, error) {
+
+	if err := opts.Validate(); err != nil {
+		return model.Game{}, err
+	}
+
 	playersCopy := make([]model.Player, len(players))
 	colorsByID := make(map[model.PlayerID]model.PlayerColor, len(players))
 	curScores := make(map[model.PlayerColor]int, len(players))
 	lagScores := make(map[model.PlayerColor]int, len(players))
 
-	playerColors := []model.PlayerColor{
+	// partners sit evenly around the table, so the colors go around it
+	teamColors := []model.PlayerColor{
 		model.Blue,
 		model.Red,
+		model.Green,
+		model.Yellow,
+		model.Purple,
 	}
-	if len(players) == 3 {
-		playerColors = append(playerColors, model.Green)
-	} else if len(players) == 4 {
-		playerColors = append(playerColors, model.Blue, model.Red)
-	}
+	numTeams := model.NumTeams(len(players))
 
 	for i, p := range players {
 		playersCopy[i] = p
-		color := playerColors[i]
+		color := teamColors[i%numTeams]
 		colorsByID[p.ID] = color
 		curScores[color] = 0
 		lagScores[color] = 0
@@ -43,19 +59,22 @@ func CreateGame(players []model.Player, pAPIs map[model.PlayerID]interaction.Pla
 		BlockingPlayers: make(map[model.PlayerID]model.Blocker, len(players)),
 		CurrentDealer:   players[0].ID,
 		PlayerColors:    colorsByID,
+		Options:         opts,
+		Seed:            model.NewGameSeed(),
 		CurrentScores:   curScores,
 		LagScores:       lagScores,
 		Phase:           model.DealingReady,
 		Hands:           make(map[model.PlayerID][]model.Card, len(players)),
 		CutCard:         model.Card{},
 		Crib:            make([]model.Card, 0, 4),
-		PeggedCards:     make([]model.PeggedCard, 0, 4*len(players)),
+		PeggedCards:     make([]model.PeggedCard, 0, opts.Rules.KeptHandSize()*len(players)),
 	}
 
 	err := runStartHandlers(&g, pAPIs)
 	if err != nil {
 		return model.Game{}, err
 	}
+	refreshDeadlines(&g, model.InvalidPlayerID, nil, time.Now())
 
 	return g, nil
 }
@@ -77,26 +96,39 @@ var (
 	}
 )
 
+// HandleAction plays the action in the game. The players are told about everything that
+// happens because of it, and the observers are told about the public events.
 func HandleAction(g *model.Game,
 	action model.PlayerAction,
 	pAPIs map[model.PlayerID]interaction.Player,
+	observers ...interaction.Observer,
 ) error {
 
-	if g.ID != action.GameID {
-		return ErrActionNotForGame
+	before := copyBlockers(g.BlockingPlayers)
+	numEvents := len(g.Events)
+	if err := handleAction(g, action, pAPIs); err != nil {
+		return err
 	}
-	playerIsInGame := false
-	for i := range g.Players {
-		if g.Players[i].ID == action.ID {
-			playerIsInGame = true
-			break
-		}
+	refreshDeadlines(g, action.ID, before, time.Now())
+	notifyObservers(g, numEvents, observers)
+
+	return nil
+}
+
+func handleAction(g *model.Game,
+	action model.PlayerAction,
+	pAPIs map[model.PlayerID]interaction.Player,
+) error {
+
+	if err := validateActionForGame(g, action); err != nil {
+		return err
 	}
-	if !playerIsInGame {
-		return ErrPlayerNotInGame
+	if action.Overcomes == model.Resign {
+		// a player doesn't need to be blocking, or to wait on an undo, to give up
+		return handleResign(g, action)
 	}
-	if g.IsOver() {
-		return ErrGameAlreadyOver
+	if pendingUndo(g) >= 0 {
+		return ErrUndoPending
 	}
 	switch p := g.Phase; p {
 	case model.Deal,
@@ -113,6 +145,12 @@ func HandleAction(g *model.Game,
 	}
 
 	if g.IsOver() {
+		res, err := model.NewGameResult(g)
+		if err != nil {
+			return err
+		}
+		g.Result = &res
+		g.Status = model.StatusCompleted
 		return nil
 	}
 
@@ -123,6 +161,28 @@ func HandleAction(g *model.Game,
 	return runStartHandlers(g, pAPIs)
 }
 
+// validateActionForGame checks that the action is for this game, by one of its
+// players, and that the game can still be played
+func validateActionForGame(g *model.Game, action model.PlayerAction) error {
+	if g.ID != action.GameID {
+		return ErrActionNotForGame
+	}
+	playerIsInGame := false
+	for i := range g.Players {
+		if g.Players[i].ID == action.ID {
+			playerIsInGame = true
+			break
+		}
+	}
+	if !playerIsInGame {
+		return ErrPlayerNotInGame
+	}
+	if g.IsOver() {
+		return ErrGameAlreadyOver
+	}
+	return nil
+}
+
 func runStartHandlers(g *model.Game, pAPIs map[model.PlayerID]interaction.Player) error {
 	switch p := g.Phase; p {
 	case model.BuildCribReady,
@@ -131,14 +191,19 @@ func runStartHandlers(g *model.Game, pAPIs map[model.PlayerID]interaction.Player
 		model.CountingReady,
 		model.CribCountingReady,
 		model.DealingReady:
+		next := p + 1
+		if next > model.DealingReady {
+			next = model.Deal
+		}
+		addEvent(g, pAPIs, model.PhaseChangedEvent{
+			Phase: next,
+		})
+
 		err := handlers[p].Start(g, pAPIs)
 		if err != nil {
 			return err
 		}
-		g.Phase++
-		if g.Phase > model.DealingReady {
-			g.Phase = model.Deal
-		}
+		g.Phase = next
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package play
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -9,9 +10,42 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
 	"github.com/joshprzybyszewski/cribbage/utils/testutils"
 )
 
+// scoreEventFor matches a score event that was described as being "for <desc>"
+func scoreEventFor(desc string) interface{} {
+	return mock.MatchedBy(func(ge model.GameEvent) bool {
+		return ge.Type == model.Scored && strings.Contains(ge.String(), `for `+desc)
+	})
+}
+
+func eventOfType(t model.GameEventType) interface{} {
+	return mock.MatchedBy(func(ge model.GameEvent) bool {
+		return ge.Type == t
+	})
+}
+
+// allowEvents lets the players be told about events of these types without the test expecting them
+func allowEvents(ts []model.GameEventType, apis ...*interaction.Mock) {
+	for _, t := range ts {
+		for _, api := range apis {
+			api.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(t)).Return(nil).Maybe()
+		}
+	}
+}
+
+func eventsOfType(g *model.Game, t model.GameEventType) []interface{} {
+	var es []interface{}
+	for _, ge := range g.Events {
+		if ge.Type == t {
+			es = append(es, ge.Event)
+		}
+	}
+	return es
+}
+
 func TestHandleAction_InvalidINputs(t *testing.T) {
 	alice, bob, _, _, abAPIs := testutils.AliceAndBob()
 
@@ -56,6 +90,7 @@ func TestHandleAction_InvalidINputs(t *testing.T) {
 
 func TestHandleAction_Deal(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -79,9 +114,9 @@ func TestHandleAction_Deal(t *testing.T) {
 			NumShuffles: 50,
 		},
 	}
-	aliceAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.MatchedBy(func(s string) bool { return strings.HasPrefix(s, `Received Hand `) })).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
 	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
-	bobAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.MatchedBy(func(s string) bool { return strings.HasPrefix(s, `Received Hand `) })).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
 
 	err := HandleAction(&g, action, abAPIs)
@@ -97,13 +132,251 @@ func TestHandleAction_Deal(t *testing.T) {
 	assert.Len(t, g.Hands[bob.ID], 6)
 	// assert that entering the build crib phase has cleared out the crib
 	assert.Empty(t, g.Crib)
+	// everyone was told about their own hand and the new phase
+	assert.Equal(t, []interface{}{
+		model.CardDealtEvent{PlayerID: bob.ID, Cards: g.Hands[bob.ID]},
+		model.CardDealtEvent{PlayerID: alice.ID, Cards: g.Hands[alice.ID]},
+	}, eventsOfType(&g, model.CardDealt))
+	assert.Equal(t, []interface{}{
+		model.PhaseChangedEvent{Phase: model.BuildCrib},
+	}, eventsOfType(&g, model.PhaseChanged))
+
+	aliceAPI.AssertExpectations(t)
+	bobAPI.AssertExpectations(t)
+}
+
+func TestHandleAction_Observers(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged, model.CardDealt}, aliceAPI, bobAPI)
+	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), mock.Anything).Return(nil)
+	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), mock.Anything).Return(nil)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{alice.ID: model.DealCards},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Phase:           model.Deal,
+		Hands:           make(map[model.PlayerID][]model.Card, 2),
+		Crib:            make([]model.Card, 4),
+		PeggedCards:     make([]model.PeggedCard, 0, 8),
+	}
+	action := model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action: model.DealAction{
+			NumShuffles: 50,
+		},
+	}
+
+	// the observer hears about the new phase, but not the cards that were dealt
+	observer := &interaction.Mock{}
+	observer.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.PhaseChanged)).Return(nil).Once()
+
+	require.NoError(t, HandleAction(&g, action, abAPIs, observer))
+	assert.Len(t, eventsOfType(&g, model.CardDealt), 2)
+	observer.AssertExpectations(t)
+}
+
+func TestHandleAction_DealSeeded(t *testing.T) {
+	dealSeeded := func(seed int64) [][]model.Card {
+		alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+		allowEvents([]model.GameEventType{model.PhaseChanged, model.CardDealt}, aliceAPI, bobAPI)
+		for _, api := range []*interaction.Mock{aliceAPI, bobAPI} {
+			api.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil)
+		}
+
+		g := model.Game{
+			ID:              model.GameID(5),
+			Players:         []model.Player{alice, bob},
+			BlockingPlayers: map[model.PlayerID]model.Blocker{alice.ID: model.DealCards},
+			CurrentDealer:   alice.ID,
+			PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+			CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+			LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+			Phase:           model.Deal,
+			Seed:            seed,
+			Hands:           make(map[model.PlayerID][]model.Card, 2),
+			Crib:            make([]model.Card, 4),
+			PeggedCards:     make([]model.PeggedCard, 0, 8),
+		}
+		action := model.PlayerAction{
+			GameID:    g.ID,
+			ID:        alice.ID,
+			Overcomes: model.DealCards,
+			Action: model.DealAction{
+				NumShuffles: 7,
+			},
+		}
+
+		require.NoError(t, HandleAction(&g, action, abAPIs))
+		return [][]model.Card{g.Hands[alice.ID], g.Hands[bob.ID]}
+	}
+
+	// the same seed and actions always deal the same hands
+	assert.Equal(t, dealSeeded(1234), dealSeeded(1234))
+	assert.NotEqual(t, dealSeeded(1234), dealSeeded(4321))
+}
+
+func TestHandleAction_DealFiveCard(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
+
+	rules, err := model.NewGameRules(model.FiveCardVariant)
+	require.NoError(t, err)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{alice.ID: model.DealCards},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		Options:         model.GameOptions{Rules: rules},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Phase:           model.Deal,
+		Hands:           make(map[model.PlayerID][]model.Card, 2),
+		CutCard:         model.Card{},
+		Crib:            make([]model.Card, 4),
+		PeggedCards:     make([]model.PeggedCard, 0, 6),
+	}
+	action := model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action: model.DealAction{
+			NumShuffles: 50,
+		},
+	}
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pone bonus`)).Return(nil).Once()
+	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pone bonus`)).Return(nil).Once()
+	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
+
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, model.BuildCrib, g.Phase)
+	// the players should have 5 card hands
+	assert.Len(t, g.Hands[alice.ID], 5)
+	assert.Len(t, g.Hands[bob.ID], 5)
+	// and the pone gets a head start
+	assert.Equal(t, 0, g.CurrentScores[model.Blue])
+	assert.Equal(t, 3, g.CurrentScores[model.Red])
+
+	// the next deal doesn't get the bonus
+	g.Phase = model.Deal
+	g.BlockingPlayers = map[model.PlayerID]model.Blocker{alice.ID: model.DealCards}
+	for pID := range g.Hands {
+		g.Hands[pID] = g.Hands[pID][:0]
+	}
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
+	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
+	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
+
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Len(t, g.Hands[alice.ID], 5)
+	assert.Equal(t, 3, g.CurrentScores[model.Red])
 
 	aliceAPI.AssertExpectations(t)
 	bobAPI.AssertExpectations(t)
 }
 
+func TestHandleAction_DealFiveAndSixPlayers(t *testing.T) {
+	for _, tc := range []struct {
+		msg        string
+		numPlayers int
+		// sitsOut are the seats, counted from the dealer, that don't discard into the crib
+		sitsOut []int
+	}{{
+		msg:        `five players`,
+		numPlayers: 5,
+		sitsOut:    []int{0},
+	}, {
+		msg:        `six players`,
+		numPlayers: 6,
+		sitsOut:    []int{0, 3},
+	}} {
+		players := make([]model.Player, tc.numPlayers)
+		pAPIs := make(map[model.PlayerID]interaction.Player, tc.numPlayers)
+		for i := range players {
+			players[i] = model.Player{ID: model.PlayerID(fmt.Sprintf("p%d", i))}
+			pAPIs[players[i].ID] = interaction.Empty(players[i].ID)
+		}
+
+		g, err := CreateGame(players, pAPIs)
+		require.NoError(t, err, tc.msg)
+
+		dealerSeat := 0
+		for i, p := range g.Players {
+			if p.ID == g.CurrentDealer {
+				dealerSeat = i
+			}
+		}
+		err = HandleAction(&g, model.PlayerAction{
+			GameID:    g.ID,
+			ID:        g.CurrentDealer,
+			Overcomes: model.DealCards,
+			Action:    model.DealAction{NumShuffles: 5},
+		}, pAPIs)
+		require.NoError(t, err, tc.msg)
+		assert.Equal(t, model.BuildCrib, g.Phase, tc.msg)
+		assert.Empty(t, g.Crib, tc.msg)
+
+		// the players who sit out of the crib are only dealt the cards they'll keep
+		builders := make([]model.PlayerID, 0, len(players))
+		for i := range g.Players {
+			pID := g.Players[(dealerSeat+i)%len(g.Players)].ID
+			if len(tc.sitsOut) > 0 && tc.sitsOut[0] == i {
+				tc.sitsOut = tc.sitsOut[1:]
+				assert.Len(t, g.Hands[pID], 4, tc.msg)
+				assert.NotContains(t, g.BlockingPlayers, pID, tc.msg)
+				continue
+			}
+			assert.Len(t, g.Hands[pID], 5, tc.msg)
+			assert.Equal(t, model.CribCard, g.BlockingPlayers[pID], tc.msg)
+			builders = append(builders, pID)
+		}
+		require.Len(t, builders, 4, tc.msg)
+
+		for _, pID := range builders {
+			err = HandleAction(&g, model.PlayerAction{
+				GameID:    g.ID,
+				ID:        pID,
+				Overcomes: model.CribCard,
+				Action:    model.BuildCribAction{Cards: g.Hands[pID][:1]},
+			}, pAPIs)
+			require.NoError(t, err, tc.msg)
+		}
+		assert.Len(t, g.Crib, 4, tc.msg)
+		assert.Equal(t, model.Cut, g.Phase, tc.msg)
+		for _, p := range g.Players {
+			assert.Len(t, g.Hands[p.ID], 4, tc.msg)
+		}
+	}
+}
+
+func TestCreateGameWithOptions_InvalidRules(t *testing.T) {
+	alice, bob, _, _, abAPIs := testutils.AliceAndBob()
+
+	_, err := CreateGameWithOptions(
+		[]model.Player{alice, bob},
+		model.GameOptions{Rules: model.GameRules{HandSize: 9}},
+		abAPIs,
+	)
+	assert.Equal(t, model.ErrInvalidHandSize, err)
+}
+
 func TestHandleAction_Crib(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -208,6 +481,7 @@ func TestHandleAction_Crib(t *testing.T) {
 
 func TestHandleAction_Cut(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -251,11 +525,11 @@ func TestHandleAction_Cut(t *testing.T) {
 		},
 	}
 
-	aliceAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.Anything).Return(nil).Once()
-	bobAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.Anything).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CutRevealed)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CutRevealed)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.PegCard, mock.AnythingOfType(`model.Game`), `please peg a card`).Return(nil).Once()
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`his nibs`}).Return(nil).Maybe()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`his nibs`}).Return(nil).Maybe()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`his nibs`)).Return(nil).Maybe()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`his nibs`)).Return(nil).Maybe()
 
 	err := HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -290,6 +564,7 @@ func TestHandleAction_Cut(t *testing.T) {
 
 func TestHandleAction_Pegging(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged, model.CardPegged, model.GoSaid}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -349,8 +624,8 @@ func TestHandleAction_Pegging(t *testing.T) {
 		},
 	}
 	// alice and bob are going to get notified because alice scores a 31 and a run of 3
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.PegCard, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -398,8 +673,8 @@ func TestHandleAction_Pegging(t *testing.T) {
 		},
 	}
 	// alice and bob are going to get notified because alice scores a 31 and a run of 3
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.PegCard, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -407,6 +682,11 @@ func TestHandleAction_Pegging(t *testing.T) {
 	assert.Contains(t, g.PeggedCards, model.NewPeggedCardFromString(alice.ID, `8s`, g.NumActions()))
 	assert.Equal(t, g.CurrentPeg(), 0)
 	assert.Equal(t, g.CurrentScores[g.PlayerColors[alice.ID]], 7)
+	scored := eventsOfType(&g, model.Scored)
+	assert.Equal(t,
+		`thirty-one 2, and a run of 3 is 5`,
+		model.DescribeScoreItems(scored[len(scored)-1].(model.ScoreEvent).Breakdown),
+	)
 
 	// bob pegs his 10C
 	action = model.PlayerAction{
@@ -433,8 +713,8 @@ func TestHandleAction_Pegging(t *testing.T) {
 		},
 	}
 	// alice and bob are going to get notified because alice scores a pair
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`pegging`}).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`pegging`)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.PegCard, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -511,8 +791,8 @@ func TestHandleAction_Pegging(t *testing.T) {
 		},
 	}
 	// bob scores a go
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`the go`}).Return(nil).Once()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`the go`}).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`the go`)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`the go`)).Return(nil).Once()
 	aliceAPI.On(`NotifyBlocking`, model.PegCard, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -531,8 +811,8 @@ func TestHandleAction_Pegging(t *testing.T) {
 			Card: g.Hands[alice.ID][3],
 		},
 	}
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`last card`}).Return(nil).Once()
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`last card`}).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`last card`)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`last card`)).Return(nil).Once()
 	// bob will be up to count his hand
 	bobAPI.On(`NotifyBlocking`, model.CountHand, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
@@ -547,12 +827,26 @@ func TestHandleAction_Pegging(t *testing.T) {
 	assert.Contains(t, g.BlockingPlayers, bob.ID)
 	assert.NotContains(t, g.BlockingPlayers, alice.ID)
 
+	// every card pegged and go said has been recorded
+	pegged := eventsOfType(&g, model.CardPegged)
+	require.Len(t, pegged, 8)
+	assert.Equal(t, model.CardPeggedEvent{
+		PlayerID: alice.ID,
+		Card:     model.NewCardFromString(`8s`),
+		Peg:      31,
+	}, pegged[3])
+	assert.Equal(t, []interface{}{
+		model.GoSaidEvent{PlayerID: alice.ID},
+		model.GoSaidEvent{PlayerID: bob.ID},
+	}, eventsOfType(&g, model.GoSaid))
+
 	aliceAPI.AssertExpectations(t)
 	bobAPI.AssertExpectations(t)
 }
 
 func TestHandleAction_Counting(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -606,8 +900,8 @@ func TestHandleAction_Counting(t *testing.T) {
 			Pts: 18,
 		},
 	}
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`hand (7H: 7C, 8C, 9C, 10C)`}).Return(nil).Once()
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`hand (7H: 7C, 8C, 9C, 10C)`}).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
 	aliceAPI.On(`NotifyBlocking`, model.CountHand, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -623,13 +917,21 @@ func TestHandleAction_Counting(t *testing.T) {
 			Pts: 18,
 		},
 	}
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`hand (7H: 7S, 8S, 9S, 10S)`}).Return(nil).Once()
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`hand (7H: 7S, 8S, 9S, 10S)`}).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
 	aliceAPI.On(`NotifyBlocking`, model.CountCrib, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
 	assert.Equal(t, 18, g.CurrentScores[g.PlayerColors[alice.ID]])
 
+	// correct counts are itemized
+	scored := eventsOfType(&g, model.Scored)
+	require.Len(t, scored, 2)
+	assert.Equal(t,
+		`fifteen 2, fifteen 4, a pair 6, a run of 4 10, a run of 4 14, and a flush of 4 is 18`,
+		model.DescribeScoreItems(scored[1].(model.ScoreEvent).Breakdown),
+	)
+
 	// counting is done - we've moved onto counting the crib and alice needs to do that
 	assert.Equal(t, model.CribCounting, g.Phase)
 	assert.Contains(t, g.BlockingPlayers, alice.ID)
@@ -639,8 +941,69 @@ func TestHandleAction_Counting(t *testing.T) {
 	bobAPI.AssertExpectations(t)
 }
 
+func TestHandleAction_CountingWinsGame(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{bob.ID: model.CountHand},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 85, model.Red: 110},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 80, model.Red: 100},
+		Phase:           model.Counting,
+		Hands: map[model.PlayerID][]model.Card{
+			alice.ID: {
+				model.NewCardFromString(`7s`),
+				model.NewCardFromString(`8s`),
+				model.NewCardFromString(`9s`),
+				model.NewCardFromString(`10s`),
+			},
+			bob.ID: {
+				model.NewCardFromString(`7c`),
+				model.NewCardFromString(`8c`),
+				model.NewCardFromString(`9c`),
+				model.NewCardFromString(`10c`),
+			},
+		},
+		CutCard:     model.NewCardFromString(`7h`),
+		Crib:        make([]model.Card, 4),
+		PeggedCards: make([]model.PeggedCard, 0, 8),
+	}
+
+	action := model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CountHand,
+		Action: model.CountHandAction{
+			Pts: 18,
+		},
+	}
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
+	require.NoError(t, HandleAction(&g, action, abAPIs))
+
+	assert.True(t, g.IsOver())
+	require.NotNil(t, g.Result)
+	assert.Equal(t, model.GameResult{
+		Winner:      model.Red,
+		WinnerScore: 128,
+		LoserScore:  85,
+		Margin:      43,
+		Skunk:       model.Skunked,
+		GamePoints:  2,
+	}, *g.Result)
+	assert.Equal(t, model.StatusCompleted, g.Status)
+
+	aliceAPI.AssertExpectations(t)
+	bobAPI.AssertExpectations(t)
+}
+
 func TestHandleAction_CribCounting(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -686,8 +1049,8 @@ func TestHandleAction_CribCounting(t *testing.T) {
 			Pts: 14,
 		},
 	}
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`crib (7H: 7S, 8S, 9S, 10S)`}).Return(nil).Once()
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`crib (7H: 7S, 8S, 9S, 10S)`}).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.DealCards, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err = HandleAction(&g, action, abAPIs)
 	assert.Nil(t, err)
@@ -700,6 +1063,7 @@ func TestHandleAction_CribCounting(t *testing.T) {
 }
 func TestHandleNineteenCribCounting(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -743,6 +1107,7 @@ func TestHandleNineteenCribCounting(t *testing.T) {
 }
 func TestHandleNineteenHandCounting(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	g := model.Game{
 		ID:              model.GameID(5),
@@ -790,6 +1155,7 @@ func TestHandleNineteenHandCounting(t *testing.T) {
 
 func TestHandleAction_DealAgain(t *testing.T) {
 	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
 
 	// Start handlers get called in the *Ready phases, so start from crib
 	// counting to make sure we pass through the DealReady phase
@@ -844,8 +1210,8 @@ func TestHandleAction_DealAgain(t *testing.T) {
 			Pts: 14,
 		},
 	}
-	bobAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`crib (7H: 7D, 8D, 9D, 10D)`}).Return(nil).Once()
-	aliceAPI.On(`NotifyScoreUpdate`, mock.AnythingOfType(`model.Game`), []string{`crib (7H: 7D, 8D, 9D, 10D)`}).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7D, 8D, 9D, 10D)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7D, 8D, 9D, 10D)`)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.DealCards, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
 	err := HandleAction(&g, action, abAPIs)
 	require.Nil(t, err)
@@ -859,9 +1225,9 @@ func TestHandleAction_DealAgain(t *testing.T) {
 			NumShuffles: 1,
 		},
 	}
-	aliceAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.MatchedBy(func(s string) bool { return strings.HasPrefix(s, `Received Hand `) })).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
 	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
-	bobAPI.On(`NotifyMessage`, mock.AnythingOfType(`model.Game`), mock.MatchedBy(func(s string) bool { return strings.HasPrefix(s, `Received Hand `) })).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.CardDealt)).Return(nil).Once()
 	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil).Once()
 
 	err = HandleAction(&g, action, abAPIs)
@@ -873,3 +1239,183 @@ func TestHandleAction_DealAgain(t *testing.T) {
 	aliceAPI.AssertExpectations(t)
 	bobAPI.AssertExpectations(t)
 }
+
+func TestHandleAction_CountingMuggins(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{bob.ID: model.CountHand},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		Options:         model.GameOptions{Muggins: true},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Phase:           model.Counting,
+		Hands: map[model.PlayerID][]model.Card{
+			alice.ID: {
+				model.NewCardFromString(`7s`),
+				model.NewCardFromString(`8s`),
+				model.NewCardFromString(`9s`),
+				model.NewCardFromString(`10s`),
+			},
+			bob.ID: {
+				model.NewCardFromString(`7c`),
+				model.NewCardFromString(`8c`),
+				model.NewCardFromString(`9c`),
+				model.NewCardFromString(`10c`),
+			},
+		},
+		CutCard:     model.NewCardFromString(`7h`),
+		Crib:        make([]model.Card, 4),
+		PeggedCards: make([]model.PeggedCard, 0, 8),
+	}
+
+	// over-counting is never allowed
+	action := model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CountHand,
+		Action: model.CountHandAction{
+			Pts: 20,
+		},
+	}
+	bobAPI.On(`NotifyBlocking`, model.CountHand, mock.AnythingOfType(`model.Game`), `you did not submit the correct number of points for your hand`).Return(nil).Once()
+	err := HandleAction(&g, action, abAPIs)
+	assert.EqualError(t, err, `wrong number of points`)
+	assert.Equal(t, 0, g.CurrentScores[g.PlayerColors[bob.ID]])
+
+	// bob misses 4 points, so alice gets to call muggins
+	action.Action = model.CountHandAction{
+		Pts: 14,
+	}
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`hand (7H: 7C, 8C, 9C, 10C)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyBlocking`, model.CallMuggins, mock.AnythingOfType(`model.Game`), mugginsMessage).Return(nil).Once()
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 14, g.CurrentScores[g.PlayerColors[bob.ID]])
+	assert.Equal(t, model.Counting, g.Phase)
+	assert.Equal(t, map[model.PlayerID]model.Blocker{alice.ID: model.CallMuggins}, g.BlockingPlayers)
+
+	// bob cannot call muggins on himself
+	action = model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CallMuggins,
+		Action: model.CallMugginsAction{
+			Pts: 4,
+		},
+	}
+	err = HandleAction(&g, action, abAPIs)
+	assert.Error(t, err)
+
+	// alice claims the 4 points bob missed, and then she's up to count
+	action.ID = alice.ID
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.MugginsCalled)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.MugginsCalled)).Return(nil).Once()
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`muggins`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`muggins`)).Return(nil).Once()
+	aliceAPI.On(`NotifyBlocking`, model.CountHand, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 4, g.CurrentScores[g.PlayerColors[alice.ID]])
+	assert.Equal(t, 14, g.CurrentScores[g.PlayerColors[bob.ID]])
+	assert.Equal(t, map[model.PlayerID]model.Blocker{alice.ID: model.CountHand}, g.BlockingPlayers)
+
+	// alice misses points and bob makes a bad claim: the points stay on the table
+	action = model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.CountHand,
+		Action: model.CountHandAction{
+			Pts: 19,
+		},
+	}
+	bobAPI.On(`NotifyBlocking`, model.CallMuggins, mock.AnythingOfType(`model.Game`), mugginsMessage).Return(nil).Once()
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 4, g.CurrentScores[g.PlayerColors[alice.ID]])
+	assert.Equal(t, map[model.PlayerID]model.Blocker{bob.ID: model.CallMuggins}, g.BlockingPlayers)
+
+	action = model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CallMuggins,
+		Action: model.CallMugginsAction{
+			Pts: 17,
+		},
+	}
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.MugginsCalled)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), eventOfType(model.MugginsCalled)).Return(nil).Once()
+	aliceAPI.On(`NotifyBlocking`, model.CountCrib, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 14, g.CurrentScores[g.PlayerColors[bob.ID]])
+	assert.Equal(t, model.CribCounting, g.Phase)
+	assert.Equal(t, map[model.PlayerID]model.Blocker{alice.ID: model.CountCrib}, g.BlockingPlayers)
+
+	aliceAPI.AssertExpectations(t)
+	bobAPI.AssertExpectations(t)
+}
+
+func TestHandleAction_CribCountingMuggins(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged}, aliceAPI, bobAPI)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{alice.ID: model.CountCrib},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		Options:         model.GameOptions{Muggins: true},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Phase:           model.CribCounting,
+		Hands:           make(map[model.PlayerID][]model.Card, 2),
+		CutCard:         model.NewCardFromString(`7h`),
+		Crib: []model.Card{
+			model.NewCardFromString(`7s`),
+			model.NewCardFromString(`8s`),
+			model.NewCardFromString(`9s`),
+			model.NewCardFromString(`10s`),
+		},
+		PeggedCards: make([]model.PeggedCard, 0, 8),
+	}
+
+	action := model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.CountCrib,
+		Action: model.CountCribAction{
+			Pts: 12,
+		},
+	}
+	bobAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
+	aliceAPI.On(`NotifyEvent`, mock.AnythingOfType(`model.Game`), scoreEventFor(`crib (7H: 7S, 8S, 9S, 10S)`)).Return(nil).Once()
+	bobAPI.On(`NotifyBlocking`, model.CallMuggins, mock.AnythingOfType(`model.Game`), mugginsMessage).Return(nil).Once()
+	err := HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 12, g.CurrentScores[g.PlayerColors[alice.ID]])
+	assert.Equal(t, alice.ID, g.CurrentDealer)
+
+	// bob passes on muggins, so the deal moves on to him
+	action = model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CallMuggins,
+		Action:    model.CallMugginsAction{},
+	}
+	bobAPI.On(`NotifyBlocking`, model.DealCards, mock.AnythingOfType(`model.Game`), ``).Return(nil).Once()
+	err = HandleAction(&g, action, abAPIs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, g.CurrentScores[g.PlayerColors[bob.ID]])
+	assert.Equal(t, bob.ID, g.CurrentDealer)
+	assert.Equal(t, map[model.PlayerID]model.Blocker{bob.ID: model.DealCards}, g.BlockingPlayers)
+
+	aliceAPI.AssertExpectations(t)
+	bobAPI.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package play
+
+import (
+	"errors"
+
+	"github.com/joshprzybyszewski/cribbage/logic/scorer"
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+)
+
+const (
+	mugginsMessage = `points were missed: call muggins to claim them`
+)
+
+// canAcceptCount returns true if the claimed points can be accepted for a hand (or crib)
+// that is actually worth pts. With muggins, an undercount is accepted as-is.
+func canAcceptCount(g *model.Game, claimed, pts int) bool {
+	if claimed == pts {
+		return true
+	}
+	return g.Options.Muggins && claimed < pts
+}
+
+// countedPoints normalizes the points a player submitted for their hand or crib
+func countedPoints(pts int) int {
+	if pts == 19 {
+		// there's no way to score 19 points, so it's a common way of saying zero
+		return 0
+	}
+	return pts
+}
+
+// countedBreakdown itemizes a hand (or crib) that was counted correctly. An
+// undercount isn't itemized so that opponents have to find the missed points.
+func countedBreakdown(lead model.Card, cards []model.Card, isCrib bool, claimed, pts int) []model.ScoreItem {
+	if claimed != pts {
+		return nil
+	}
+	return scorer.Breakdown(lead, cards, isCrib)
+}
+
+// startMuggins alerts every opponent of the counter that they have a chance
+// to claim the points the counter missed
+func startMuggins(
+	g *model.Game,
+	counter model.PlayerID,
+	pAPIs map[model.PlayerID]interaction.Player,
+) {
+
+	counterColor := g.PlayerColors[counter]
+	for _, p := range g.Players {
+		if p.ID == counter || g.PlayerColors[p.ID] == counterColor {
+			// you can't call muggins on yourself or your teammate
+			continue
+		}
+		addPlayerToBlocker(g, p.ID, model.CallMuggins, pAPIs, mugginsMessage)
+	}
+}
+
+// handleCallMuggins resolves an opponent's muggins claim against the most recent count.
+// It returns true when nobody else is waiting to call muggins and the counter it
+// was called on.
+func handleCallMuggins(
+	g *model.Game,
+	action model.PlayerAction,
+	pAPIs map[model.PlayerID]interaction.Player,
+) (bool, model.PlayerID, error) {
+
+	if err := validateAction(g, action, model.CallMuggins); err != nil {
+		return false, model.InvalidPlayerID, err
+	}
+
+	cma, ok := action.Action.(model.CallMugginsAction)
+	if !ok {
+		return false, model.InvalidPlayerID, errors.New(`tried calling muggins with a different action`)
+	}
+
+	counter, missed, err := missedPoints(g)
+	if err != nil {
+		return false, model.InvalidPlayerID, err
+	}
+
+	removePlayerFromBlockers(g, action)
+
+	if cma.Pts == 0 {
+		// this player passed on calling muggins
+		return !isWaitingOnMuggins(g), counter, nil
+	}
+
+	addEvent(g, pAPIs, model.MugginsCalledEvent{
+		PlayerID: action.ID,
+		Points:   cma.Pts,
+		Correct:  cma.Pts == missed,
+	})
+	if cma.Pts == missed {
+		addPoints(g, action.ID, missed, pAPIs, model.ScoreEvent{
+			Reason: model.MugginsScore,
+		})
+		// the points have been claimed: nobody else gets a chance to call muggins
+		clearMugginsBlockers(g)
+	}
+
+	return !isWaitingOnMuggins(g), counter, nil
+}
+
+func clearMugginsBlockers(g *model.Game) {
+	for pID, b := range g.BlockingPlayers {
+		if b == model.CallMuggins {
+			delete(g.BlockingPlayers, pID)
+		}
+	}
+}
+
+func isWaitingOnMuggins(g *model.Game) bool {
+	for _, b := range g.BlockingPlayers {
+		if b == model.CallMuggins {
+			return true
+		}
+	}
+	return false
+}
+
+// missedPoints looks up the most recent count in the game and returns who
+// counted and how many points they failed to count
+func missedPoints(g *model.Game) (model.PlayerID, int, error) {
+	for i := len(g.Actions) - 1; i >= 0; i-- {
+		a := g.Actions[i]
+		switch ca := a.Action.(type) {
+		case model.CountHandAction:
+			pts := scorer.HandPoints(g.CutCard, g.Hands[a.ID])
+			return a.ID, pts - countedPoints(ca.Pts), nil
+		case model.CountCribAction:
+			pts := scorer.CribPoints(g.CutCard, g.Crib)
+			return a.ID, pts - countedPoints(ca.Pts), nil
+		}
+	}
+
+	return model.InvalidPlayerID, 0, errors.New(`no count to call muggins on`)
+}
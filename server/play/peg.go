@@ -96,18 +96,28 @@ func doPeg(
 	pAPIs map[model.PlayerID]interaction.Player,
 ) error {
 
-	pts, err := pegging.PointsForCard(g.PeggedCards, pa.Card)
+	items, err := pegging.BreakdownForCard(g.PeggedCards, pa.Card)
 	if err != nil {
 		return err
 	}
 
-	addPoints(g, action.ID, pts, pAPIs, `pegging`)
-
+	peg := g.CurrentPeg() + pa.Card.PegValue()
 	g.PeggedCards = append(g.PeggedCards, model.PeggedCard{
 		Card:     pa.Card,
 		PlayerID: action.ID,
 		Action:   g.NumActions() + 1,
 	})
+	addEvent(g, pAPIs, model.CardPeggedEvent{
+		PlayerID: action.ID,
+		Card:     pa.Card,
+		Peg:      peg,
+	})
+
+	addPoints(g, action.ID, model.TotalPoints(items), pAPIs, model.ScoreEvent{
+		Reason:    model.PegScore,
+		Cards:     []model.Card{pa.Card},
+		Breakdown: items,
+	})
 
 	return nil
 }
@@ -117,6 +127,10 @@ func doSayGo(g *model.Game,
 	pAPIs map[model.PlayerID]interaction.Player,
 ) {
 
+	addEvent(g, pAPIs, model.GoSaidEvent{
+		PlayerID: action.ID,
+	})
+
 	if len(g.PeggedCards) == 0 {
 		return
 	}
@@ -124,7 +138,9 @@ func doSayGo(g *model.Game,
 	lastPeggerID := g.PeggedCards[len(g.PeggedCards)-1].PlayerID
 	if lastPeggerID == action.ID {
 		// The go's went all the way around. Take a point
-		addPoints(g, action.ID, 1, pAPIs, `the go`)
+		addPoints(g, action.ID, 1, pAPIs, model.ScoreEvent{
+			Reason: model.GoScore,
+		})
 	}
 }
 
@@ -139,9 +155,11 @@ func progressAfterPeg(
 		return
 	}
 
-	if len(g.PeggedCards) == 4*len(g.Players) {
+	if len(g.PeggedCards) == g.Options.Rules.KeptHandSize()*len(g.Players) {
 		// This was the last card: give one point to this player.
-		addPoints(g, action.ID, 1, pAPIs, `last card`)
+		addPoints(g, action.ID, 1, pAPIs, model.ScoreEvent{
+			Reason: model.LastCardScore,
+		})
 		return
 	}
 
@@ -0,0 +1,54 @@
+package play
+
+import (
+	"errors"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+var (
+	ErrCannotResign error = errors.New(`tried resigning with a different action`)
+)
+
+// handleResign ends the game with the team of the player who resigns losing it.
+// Resigning before anyone has acted abandons the game instead, and nobody wins.
+func handleResign(g *model.Game, action model.PlayerAction) error {
+	if _, ok := action.Action.(model.ResignAction); !ok {
+		return ErrCannotResign
+	}
+
+	if g.NumActions() == 0 {
+		g.AddAction(action)
+		endEarly(g, model.StatusAbandoned)
+		return nil
+	}
+
+	return concede(g, action, model.StatusResigned)
+}
+
+// concede ends the game with the team of the player who acted losing it
+func concede(g *model.Game, action model.PlayerAction, status model.GameStatus) error {
+	color, ok := g.PlayerColors[action.ID]
+	if !ok {
+		return ErrNoForfeitColor
+	}
+
+	res, err := model.NewForfeitResult(g, color)
+	if err != nil {
+		return err
+	}
+
+	g.AddAction(action)
+	g.Result = &res
+	endEarly(g, status)
+
+	return nil
+}
+
+// endEarly stops the game before anyone has won it, so nobody is left waiting
+// to act
+func endEarly(g *model.Game, status model.GameStatus) {
+	g.Status = status
+	g.BlockingPlayers = map[model.PlayerID]model.Blocker{}
+	g.Deadlines = nil
+}
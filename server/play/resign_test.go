@@ -0,0 +1,78 @@
+package play
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/utils/testutils"
+)
+
+func TestHandleActionResign(t *testing.T) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := CreateGameWithOptions([]model.Player{alice, bob}, model.GameOptions{
+		TurnTimeLimit: time.Minute,
+	}, abAPIs)
+	require.NoError(t, err)
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}, abAPIs))
+	g.CurrentScores[g.PlayerColors[bob.ID]] = 30
+
+	assert.Equal(t, ErrCannotResign, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.Resign,
+		Action:    model.DealAction{},
+	}, abAPIs))
+
+	// bob has to build the crib, but he can give up instead
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}, abAPIs))
+	assert.Equal(t, model.StatusResigned, g.Status)
+	assert.True(t, g.IsOver())
+	require.NotNil(t, g.Result)
+	assert.Equal(t, g.PlayerColors[alice.ID], g.Result.Winner)
+	assert.Equal(t, 30, g.Result.LoserScore)
+	assert.True(t, g.Result.Forfeit)
+	assert.Empty(t, g.BlockingPlayers)
+	assert.Nil(t, g.Deadlines)
+	assert.Equal(t, 2, g.NumActions())
+
+	assert.Equal(t, ErrGameAlreadyOver, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}, abAPIs))
+}
+
+func TestHandleActionResignBeforeAnyoneActs(t *testing.T) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := CreateGame([]model.Player{alice, bob}, abAPIs)
+	require.NoError(t, err)
+
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}, abAPIs))
+	assert.Equal(t, model.StatusAbandoned, g.Status)
+	assert.True(t, g.IsOver())
+	assert.Nil(t, g.Result)
+	assert.Empty(t, g.BlockingPlayers)
+	assert.Equal(t, 1, g.NumActions())
+}
@@ -0,0 +1,98 @@
+package play
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+)
+
+var (
+	ErrNoTurnTimer    error = errors.New(`game does not have a turn timer`)
+	ErrNotBlocking    error = errors.New(`player is not blocking the game`)
+	ErrCannotForfeit  error = errors.New(`tried forfeiting with a different action`)
+	ErrNoForfeitColor error = errors.New(`player does not have a color to forfeit`)
+)
+
+const nudgeMessage = `is running out of time`
+
+// refreshDeadlines gives the blocking players until the turn time limit to act. A
+// player who was already blocked for the same reason keeps their deadline, unless
+// they're the one who just acted.
+func refreshDeadlines(
+	g *model.Game,
+	actor model.PlayerID,
+	before map[model.PlayerID]model.Blocker,
+	now time.Time,
+) {
+
+	if !g.Options.HasTurnTimer() || g.IsOver() {
+		g.Deadlines = nil
+		return
+	}
+
+	dls := make(map[model.PlayerID]time.Time, len(g.BlockingPlayers))
+	for pID, b := range g.BlockingPlayers {
+		if dl, ok := g.Deadlines[pID]; ok && pID != actor && before[pID] == b {
+			dls[pID] = dl
+			continue
+		}
+		dls[pID] = now.Add(g.Options.TurnTimeLimit)
+	}
+	g.Deadlines = dls
+}
+
+// RestartDeadlines gives every blocking player a full turn from now to act. It's
+// for when the game goes back to an earlier state, whose deadlines have passed.
+func RestartDeadlines(g *model.Game, now time.Time) {
+	g.Deadlines = nil
+	refreshDeadlines(g, model.InvalidPlayerID, nil, now)
+}
+
+// Nudge reminds a blocking player that the game is waiting on them, and
+// gives them another turn's worth of time to act
+func Nudge(
+	g *model.Game,
+	pID model.PlayerID,
+	pAPIs map[model.PlayerID]interaction.Player,
+	now time.Time,
+) error {
+
+	if !g.Options.HasTurnTimer() {
+		return ErrNoTurnTimer
+	}
+	b, ok := g.BlockingPlayers[pID]
+	if !ok {
+		return ErrNotBlocking
+	}
+
+	addPlayerToBlocker(g, pID, b, pAPIs, nudgeMessage)
+	if g.Deadlines == nil {
+		g.Deadlines = make(map[model.PlayerID]time.Time, 1)
+	}
+	g.Deadlines[pID] = now.Add(g.Options.TurnTimeLimit)
+
+	return nil
+}
+
+// HandleForfeit ends the game because the player ran out of time, with their
+// team losing it
+func HandleForfeit(g *model.Game, action model.PlayerAction) error {
+	if err := validateActionForGame(g, action); err != nil {
+		return err
+	}
+	if _, ok := action.Action.(model.ForfeitAction); !ok || action.Overcomes != model.Forfeit {
+		return ErrCannotForfeit
+	}
+
+	return concede(g, action, model.StatusExpired)
+}
+
+func copyBlockers(bps map[model.PlayerID]model.Blocker) map[model.PlayerID]model.Blocker {
+	cp := make(map[model.PlayerID]model.Blocker, len(bps))
+	for pID, b := range bps {
+		cp[pID] = b
+	}
+	return cp
+}
@@ -0,0 +1,105 @@
+package play
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/utils/testutils"
+)
+
+func TestTurnTimerDeadlines(t *testing.T) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+	start := time.Now()
+
+	g, err := CreateGameWithOptions([]model.Player{alice, bob}, model.GameOptions{
+		TurnTimeLimit: time.Minute,
+	}, abAPIs)
+	require.NoError(t, err)
+	require.Len(t, g.Deadlines, 1)
+	dealerDeadline := g.Deadlines[alice.ID]
+	assert.False(t, dealerDeadline.Before(start.Add(time.Minute)))
+	assert.False(t, dealerDeadline.After(time.Now().Add(time.Minute)))
+
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}, abAPIs))
+	// the dealer is blocked again for a different reason, so their clock restarts
+	require.Len(t, g.Deadlines, 2)
+	assert.True(t, g.Deadlines[alice.ID].After(dealerDeadline))
+	bobDeadline := g.Deadlines[bob.ID]
+
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.CribCard,
+		Action:    model.BuildCribAction{Cards: g.Hands[alice.ID][:2]},
+	}, abAPIs))
+	// bob is still building the crib, and his clock kept running
+	assert.Equal(t, map[model.PlayerID]time.Time{bob.ID: bobDeadline}, g.Deadlines)
+
+	nudged := bobDeadline.Add(time.Hour)
+	require.NoError(t, Nudge(&g, bob.ID, abAPIs, nudged))
+	assert.Equal(t, nudged.Add(time.Minute), g.Deadlines[bob.ID])
+	assert.Equal(t, ErrNotBlocking, Nudge(&g, alice.ID, abAPIs, nudged))
+
+	restarted := nudged.Add(time.Hour)
+	RestartDeadlines(&g, restarted)
+	assert.Equal(t, map[model.PlayerID]time.Time{bob.ID: restarted.Add(time.Minute)}, g.Deadlines)
+}
+
+func TestTurnTimerNotSet(t *testing.T) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := CreateGame([]model.Player{alice, bob}, abAPIs)
+	require.NoError(t, err)
+	assert.Nil(t, g.Deadlines)
+	assert.Equal(t, ErrNoTurnTimer, Nudge(&g, alice.ID, abAPIs, time.Now()))
+}
+
+func TestHandleForfeit(t *testing.T) {
+	alice, bob, abAPIs := testutils.EmptyAliceAndBob()
+
+	g, err := CreateGameWithOptions([]model.Player{alice, bob}, model.GameOptions{
+		TurnTimeLimit: time.Minute,
+		TimeoutPolicy: model.ForfeitGame,
+	}, abAPIs)
+	require.NoError(t, err)
+	g.CurrentScores[g.PlayerColors[alice.ID]] = 50
+
+	assert.Equal(t, ErrCannotForfeit, HandleForfeit(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action:    model.ForfeitAction{},
+	}))
+
+	require.NoError(t, HandleForfeit(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.Forfeit,
+		Action:    model.ForfeitAction{TimedOut: true},
+	}))
+	assert.True(t, g.IsOver())
+	require.NotNil(t, g.Result)
+	assert.Equal(t, g.PlayerColors[bob.ID], g.Result.Winner)
+	assert.Equal(t, 50, g.Result.LoserScore)
+	assert.True(t, g.Result.Forfeit)
+	assert.Equal(t, model.StatusExpired, g.Status)
+	assert.Empty(t, g.BlockingPlayers)
+	assert.Nil(t, g.Deadlines)
+	assert.Equal(t, 1, g.NumActions())
+
+	assert.Equal(t, ErrGameAlreadyOver, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}, abAPIs))
+}
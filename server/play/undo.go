@@ -0,0 +1,112 @@
+package play
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+)
+
+var (
+	ErrUndoPending   error = errors.New(`waiting on players to answer an undo`)
+	ErrNothingToUndo error = errors.New(`there is no action to undo`)
+	ErrCannotUndo    error = errors.New(`only the player who took the last action can undo it`)
+)
+
+const undoMessage = `please approve or deny undoing the last action`
+
+// HandleUndo handles a player asking to take back the last action they took, and the
+// other players answering that ask. When every other player approves, or any of them
+// denies, the game needs to go back to an earlier state: HandleUndo returns true along
+// with how many actions that state had. Otherwise, the action is added to the game.
+// That earlier state's deadlines have passed, so they need to be restarted.
+func HandleUndo(
+	g *model.Game,
+	action model.PlayerAction,
+	pAPIs map[model.PlayerID]interaction.Player,
+) (uint, bool, error) {
+
+	before := copyBlockers(g.BlockingPlayers)
+	numActions, rewind, err := handleUndo(g, action, pAPIs)
+	if err != nil || rewind {
+		return numActions, rewind, err
+	}
+	refreshDeadlines(g, action.ID, before, time.Now())
+
+	return 0, false, nil
+}
+
+func handleUndo(
+	g *model.Game,
+	action model.PlayerAction,
+	pAPIs map[model.PlayerID]interaction.Player,
+) (uint, bool, error) {
+
+	if err := validateActionForGame(g, action); err != nil {
+		return 0, false, err
+	}
+	ua, ok := action.Action.(model.UndoAction)
+	if !ok || action.Overcomes != model.Undo {
+		return 0, false, errors.New(`tried undoing with a different action`)
+	}
+
+	req := pendingUndo(g)
+	if req < 0 {
+		return 0, false, requestUndo(g, action, pAPIs)
+	}
+
+	if err := validateAction(g, action, model.Undo); err != nil {
+		return 0, false, err
+	}
+	if !ua.Approve {
+		// go back to before anyone asked
+		return uint(req), true, nil
+	}
+
+	removePlayerFromBlockers(g, action)
+	if len(g.BlockingPlayers) > 0 {
+		g.AddAction(action)
+		return 0, false, nil
+	}
+
+	// go back to before the action that was undone
+	return uint(req - 1), true, nil
+}
+
+func requestUndo(
+	g *model.Game,
+	action model.PlayerAction,
+	pAPIs map[model.PlayerID]interaction.Player,
+) error {
+
+	n := g.NumActions()
+	if n == 0 {
+		return ErrNothingToUndo
+	}
+	if g.Actions[n-1].ID != action.ID {
+		return ErrCannotUndo
+	}
+
+	// nobody can play on until the other players have answered. If they deny
+	// the undo, the game goes back to the state it was in, blockers and all.
+	g.BlockingPlayers = make(map[model.PlayerID]model.Blocker, len(g.Players)-1)
+	for _, p := range g.Players {
+		if p.ID != action.ID {
+			addPlayerToBlocker(g, p.ID, model.Undo, pAPIs, undoMessage)
+		}
+	}
+	g.AddAction(action)
+
+	return nil
+}
+
+// pendingUndo returns the index of the action that asked for an undo which hasn't
+// been settled yet, or -1 if there isn't one
+func pendingUndo(g *model.Game) int {
+	req := -1
+	for i := len(g.Actions) - 1; i >= 0 && g.Actions[i].Overcomes == model.Undo; i-- {
+		req = i
+	}
+	return req
+}
@@ -0,0 +1,130 @@
+package play
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+	"github.com/joshprzybyszewski/cribbage/utils/testutils"
+)
+
+func undoAction(g *model.Game, pID model.PlayerID, approve bool) model.PlayerAction {
+	return model.PlayerAction{
+		GameID:    g.ID,
+		ID:        pID,
+		Overcomes: model.Undo,
+		Action: model.UndoAction{
+			Approve: approve,
+		},
+	}
+}
+
+func TestHandleUndo(t *testing.T) {
+	alice, bob, aliceAPI, bobAPI, abAPIs := testutils.AliceAndBob()
+	allowEvents([]model.GameEventType{model.PhaseChanged, model.CardDealt}, aliceAPI, bobAPI)
+	aliceAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil)
+	bobAPI.On(`NotifyBlocking`, model.CribCard, mock.AnythingOfType(`model.Game`), `needs to cut 2 cards`).Return(nil)
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{alice.ID: model.DealCards},
+		CurrentDealer:   alice.ID,
+		PlayerColors:    map[model.PlayerID]model.PlayerColor{alice.ID: model.Blue, bob.ID: model.Red},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		LagScores:       map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Phase:           model.Deal,
+		Hands:           make(map[model.PlayerID][]model.Card, 2),
+		Crib:            make([]model.Card, 4),
+		PeggedCards:     make([]model.PeggedCard, 0, 8),
+	}
+
+	_, _, err := HandleUndo(&g, undoAction(&g, alice.ID, false), abAPIs)
+	assert.Equal(t, ErrNothingToUndo, err)
+
+	require.NoError(t, HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        alice.ID,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}, abAPIs))
+	require.Equal(t, 1, g.NumActions())
+
+	// only alice dealt, so only alice can ask to take it back
+	_, _, err = HandleUndo(&g, undoAction(&g, bob.ID, false), abAPIs)
+	assert.Equal(t, ErrCannotUndo, err)
+
+	bobAPI.On(`NotifyBlocking`, model.Undo, mock.AnythingOfType(`model.Game`), undoMessage).Return(nil).Once()
+	_, rewind, err := HandleUndo(&g, undoAction(&g, alice.ID, false), abAPIs)
+	require.NoError(t, err)
+	assert.False(t, rewind)
+	assert.Equal(t, 2, g.NumActions())
+	assert.Equal(t, map[model.PlayerID]model.Blocker{bob.ID: model.Undo}, g.BlockingPlayers)
+
+	// nothing else can happen until bob answers
+	err = HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        bob.ID,
+		Overcomes: model.CribCard,
+		Action:    model.BuildCribAction{Cards: g.Hands[bob.ID][:2]},
+	}, abAPIs)
+	assert.Equal(t, ErrUndoPending, err)
+	_, _, err = HandleUndo(&g, undoAction(&g, alice.ID, true), abAPIs)
+	assert.Error(t, err)
+
+	gDenied := g
+	n, rewind, err := HandleUndo(&gDenied, undoAction(&g, bob.ID, false), abAPIs)
+	require.NoError(t, err)
+	assert.True(t, rewind)
+	assert.Equal(t, uint(1), n)
+
+	n, rewind, err = HandleUndo(&g, undoAction(&g, bob.ID, true), abAPIs)
+	require.NoError(t, err)
+	assert.True(t, rewind)
+	assert.Equal(t, uint(0), n)
+
+	aliceAPI.AssertExpectations(t)
+	bobAPI.AssertExpectations(t)
+}
+
+func TestHandleUndoWaitsForEveryone(t *testing.T) {
+	alice, bob, charlie, diane := testutils.AliceBobCharlieDiane()
+	pAPIs := map[model.PlayerID]interaction.Player{}
+	for _, p := range []model.Player{alice, bob, charlie, diane} {
+		pAPIs[p.ID] = interaction.Empty(p.ID)
+	}
+
+	g := model.Game{
+		ID:              model.GameID(5),
+		Players:         []model.Player{alice, bob, charlie, diane},
+		BlockingPlayers: map[model.PlayerID]model.Blocker{bob.ID: model.CutCard},
+		CurrentScores:   map[model.PlayerColor]int{model.Blue: 0, model.Red: 0},
+		Actions: []model.PlayerAction{{
+			GameID:    model.GameID(5),
+			ID:        alice.ID,
+			Overcomes: model.CribCard,
+		}},
+	}
+
+	_, rewind, err := HandleUndo(&g, undoAction(&g, alice.ID, false), pAPIs)
+	require.NoError(t, err)
+	assert.False(t, rewind)
+	assert.Len(t, g.BlockingPlayers, 3)
+
+	_, rewind, err = HandleUndo(&g, undoAction(&g, bob.ID, true), pAPIs)
+	require.NoError(t, err)
+	assert.False(t, rewind)
+	_, rewind, err = HandleUndo(&g, undoAction(&g, charlie.ID, true), pAPIs)
+	require.NoError(t, err)
+	assert.False(t, rewind)
+	assert.Equal(t, map[model.PlayerID]model.Blocker{diane.ID: model.Undo}, g.BlockingPlayers)
+
+	n, rewind, err := HandleUndo(&g, undoAction(&g, diane.ID, true), pAPIs)
+	require.NoError(t, err)
+	assert.True(t, rewind)
+	assert.Equal(t, uint(0), n)
+}
@@ -72,12 +72,13 @@ func roundCutter(g *model.Game) model.PlayerID {
 	return pIDs[len(pIDs)-2]
 }
 
+// addPoints gives the player pts, and tells everyone why with the (partially filled) ScoreEvent
 func addPoints(
 	g *model.Game,
 	pID model.PlayerID,
 	pts int,
 	pAPIs map[model.PlayerID]interaction.Player,
-	msgs ...string,
+	se model.ScoreEvent,
 ) {
 
 	if pts == 0 {
@@ -91,8 +92,55 @@ func addPoints(
 	g.LagScores[pc] = g.CurrentScores[pc]
 	g.CurrentScores[pc] = g.CurrentScores[pc] + pts
 
+	se.PlayerID = pID
+	se.Points = pts
+	se.Score = g.CurrentScores[pc]
+	addEvent(g, pAPIs, se)
+}
+
+// addEvent records the event in the game and tells every player about it
+func addEvent(
+	g *model.Game,
+	pAPIs map[model.PlayerID]interaction.Player,
+	e interface{},
+) {
+
+	ge := g.AddEvent(e)
 	for _, pAPI := range pAPIs {
-		_ = pAPI.NotifyScoreUpdate(*g, msgs...)
+		_ = pAPI.NotifyEvent(*g, ge)
+	}
+}
+
+// addPrivateEvent records the event in the game, but only tells the given player about it
+func addPrivateEvent(
+	g *model.Game,
+	pID model.PlayerID,
+	pAPIs map[model.PlayerID]interaction.Player,
+	e interface{},
+) {
+
+	ge := g.AddEvent(e)
+	_ = pAPIs[pID].NotifyEvent(*g, ge)
+}
+
+// notifyObservers tells the observers about the events since the first numEvents that
+// anyone could know about
+func notifyObservers(
+	g *model.Game,
+	numEvents int,
+	observers []interaction.Observer,
+) {
+
+	if numEvents > len(g.Events) {
+		return
+	}
+	for _, ge := range g.Events[numEvents:] {
+		if !ge.IsPublic() {
+			continue
+		}
+		for _, o := range observers {
+			_ = o.NotifyEvent(*g, ge)
+		}
 	}
 }
 
@@ -128,14 +176,3 @@ func removeSubset(super, sub []model.Card) []model.Card {
 	}
 	return ret
 }
-
-func handString(hand []model.Card) string {
-	handStr := ``
-	for _, c := range hand {
-		if len(handStr) > 0 {
-			handStr += `, `
-		}
-		handStr += c.String()
-	}
-	return handStr
-}
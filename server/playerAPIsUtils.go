@@ -15,9 +15,13 @@ func getPlayerAPIs(db persistence.DB, players []model.Player) (map[model.PlayerI
 		pm, err := db.GetInteraction(p.ID)
 
 		for i, m := range pm.Interactions {
-			if m.Mode == interaction.NPC {
+			switch m.Mode {
+			case interaction.NPC:
 				m.Info = actionHandler
 				pm.Interactions[i] = m
+			case interaction.ServerSentEvents:
+				m.Info = gameEvents
+				pm.Interactions[i] = m
 			}
 		}
 
@@ -37,3 +41,18 @@ func getPlayerAPIs(db persistence.DB, players []model.Player) (map[model.PlayerI
 	}
 	return pAPIs, nil
 }
+
+// getObservers returns who is spectating the game, who are told about its public
+// events through their event streams
+func getObservers(db persistence.DB, gID model.GameID) ([]interaction.Observer, error) {
+	pIDs, err := db.GetSpectators(gID)
+	if err != nil {
+		return nil, err
+	}
+
+	observers := make([]interaction.Observer, 0, len(pIDs))
+	for _, pID := range pIDs {
+		observers = append(observers, interaction.NewObserver(pID, gameEvents))
+	}
+	return observers, nil
+}
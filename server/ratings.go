@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var (
+	ratingPeriod = flag.Duration(`rating_period`, 7*24*time.Hour, `How long a player can sit out before their rating becomes less certain`) //nolint:lll
+)
+
+const (
+	defaultLeaderboardLimit = 25
+	maxLeaderboardLimit     = 100
+)
+
+// GET /leaderboard?players=2&variant=standard&offset=0&limit=25
+func (cs *cribbageServer) ginGetLeaderboard(c *gin.Context) {
+	f, ok := bindLeaderboardFilter(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	ratings, players, err := getLeaderboard(ctx, db, f)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	resp := network.ConvertToGetLeaderboardResponse(f.Offset, f.Limit, ratings, players)
+	c.JSON(http.StatusOK, resp)
+}
+
+// bindLeaderboardFilter reads the leaderboard's filters from the query. It returns
+// false if they aren't valid, after it has written the error response.
+func bindLeaderboardFilter(c *gin.Context) (persistence.LeaderboardFilter, bool) {
+	f := persistence.LeaderboardFilter{
+		Limit: defaultLeaderboardLimit,
+	}
+
+	if !bindQueryInt(c, `players`, model.MinPlayerGame, model.MaxPlayerGame, &f.NumPlayers) ||
+		!bindQueryInt(c, `offset`, 0, math.MaxInt32, &f.Offset) ||
+		!bindQueryInt(c, `limit`, 1, maxLeaderboardLimit, &f.Limit) {
+		return persistence.LeaderboardFilter{}, false
+	}
+
+	if v, ok := c.GetQuery(`variant`); ok {
+		if _, err := model.NewGameRules(v); err != nil || v == `` {
+			c.String(http.StatusBadRequest, `Invalid variant: %s`, v)
+			return persistence.LeaderboardFilter{}, false
+		}
+		f.Variant = v
+	}
+
+	return f, true
+}
+
+// bindQueryInt reads the query's value for the key into dst, if it has one. It
+// returns false if the value isn't a number from min to max, after it has written
+// the error response.
+func bindQueryInt(c *gin.Context, key string, min, max int, dst *int) bool {
+	s, ok := c.GetQuery(key)
+	if !ok {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		c.String(http.StatusBadRequest, `Invalid %s: %s`, key, s)
+		return false
+	}
+	*dst = n
+	return true
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/logic/rating"
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+func TestRecordRatings(t *testing.T) {
+	cs, _ := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	pool := model.RatingPool{
+		NumPlayers: 2,
+		Variant:    model.StandardVariant,
+	}
+
+	// nobody is rated for a game abandoned before anyone acted
+	g, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        pIDs[0],
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}))
+	_, err = db.GetRating(pIDs[0], pool)
+	assert.Equal(t, persistence.ErrRatingNotFound, err)
+
+	// nor for a game won with the variant's rules changed
+	custom := g
+	custom.Options.Rules = model.GameRules{
+		Variant:      model.StandardVariant,
+		WinningScore: 61,
+	}
+	custom.Result = &model.GameResult{
+		Winner: custom.PlayerColors[pIDs[0]],
+	}
+	require.NoError(t, recordRatings(db, custom, time.Now()))
+	_, err = db.GetRating(pIDs[0], pool)
+	assert.Equal(t, persistence.ErrRatingNotFound, err)
+
+	gID := resignGame(t, db, pIDs, pIDs[1])
+
+	winner, err := db.GetRating(pIDs[0], pool)
+	require.NoError(t, err)
+	assert.Greater(t, winner.Rating, rating.DefaultRating)
+	assert.Less(t, winner.Deviation, rating.DefaultDeviation)
+	assert.Equal(t, 1, winner.Games)
+
+	loser, err := db.GetRating(pIDs[1], pool)
+	require.NoError(t, err)
+	assert.Less(t, loser.Rating, rating.DefaultRating)
+	assert.Equal(t, 1, loser.Games)
+
+	h, err := db.GetRatingHistory(pIDs[0])
+	require.NoError(t, err)
+	require.Len(t, h, 1)
+	assert.Equal(t, gID, h[0].GameID)
+	assert.Equal(t, pool, h[0].Pool)
+	assert.Equal(t, rating.DefaultRating, h[0].Before)
+	assert.Equal(t, winner.Rating, h[0].After)
+	assert.Equal(t, winner.Deviation, h[0].Deviation)
+}
+
+func TestGinGetLeaderboard(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+	require.NoError(t, seedNPCs(context.Background(), cs.dbFactory))
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the NPCs are rated in the games they play too
+	resignGame(t, db, []model.PlayerID{pIDs[0], interaction.Calc}, pIDs[0])
+	resignGame(t, db, pIDs, pIDs[1])
+
+	getLeaderboard := func(query string) network.GetLeaderboardResponse {
+		w, err := performRequest(router, http.MethodGet, `/leaderboard`+query, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp network.GetLeaderboardResponse
+		readBody(t, w.Body, &resp)
+		return resp
+	}
+	entryIDs := func(resp network.GetLeaderboardResponse) []model.PlayerID {
+		var ids []model.PlayerID
+		for _, e := range resp.Entries {
+			ids = append(ids, e.Player.ID)
+		}
+		return ids
+	}
+
+	resp := getLeaderboard(`?players=2&variant=standard`)
+	assert.Equal(t, 0, resp.Offset)
+	assert.Equal(t, defaultLeaderboardLimit, resp.Limit)
+	require.Len(t, resp.Entries, 6)
+	assert.Equal(t, interaction.Calc, resp.Entries[0].Player.ID)
+	assert.Equal(t, 1, resp.Entries[0].Rank)
+	assert.Equal(t, 1, resp.Entries[0].Games)
+	assert.Equal(t, 2, resp.Entries[0].NumPlayers)
+	assert.Equal(t, model.StandardVariant, resp.Entries[0].Variant)
+	assert.Equal(t, []model.PlayerID{
+		pIDs[0],
+		interaction.Dumb,
+		interaction.MonteCarlo,
+		interaction.Simple,
+	}, entryIDs(resp)[1:5])
+	assert.Equal(t, rating.DefaultRating, resp.Entries[2].Rating)
+	assert.Equal(t, pIDs[1], resp.Entries[5].Player.ID)
+	assert.Equal(t, 6, resp.Entries[5].Rank)
+
+	resp = getLeaderboard(`?offset=5&limit=2`)
+	assert.Equal(t, []model.PlayerID{pIDs[1]}, entryIDs(resp))
+	assert.Equal(t, 6, resp.Entries[0].Rank)
+
+	assert.Empty(t, getLeaderboard(`?players=3`).Entries)
+	assert.Empty(t, getLeaderboard(`?variant=short`).Entries)
+
+	for query, expErr := range map[string]string{
+		`?players=7`:  `Invalid players: 7`,
+		`?offset=-1`:  `Invalid offset: -1`,
+		`?limit=0`:    `Invalid limit: 0`,
+		`?limit=1000`: `Invalid limit: 1000`,
+		`?limit=ten`:  `Invalid limit: ten`,
+		`?variant=`:   `Invalid variant: `,
+		`?variant=x`:  `Invalid variant: x`,
+	} {
+		w, err := performRequest(router, http.MethodGet, `/leaderboard`+query, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		assert.Equal(t, expErr, readError(t, w), query)
+	}
+}
@@ -2,57 +2,109 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/contrib/static"
 	"github.com/gin-gonic/gin"
 
 	"github.com/joshprzybyszewski/cribbage/jsonutils"
+	"github.com/joshprzybyszewski/cribbage/logic/scorer"
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/auth"
 	"github.com/joshprzybyszewski/cribbage/server/interaction"
 	"github.com/joshprzybyszewski/cribbage/server/persistence"
 )
 
 type cribbageServer struct {
 	dbFactory persistence.DBFactory
+	signer    *auth.Signer
 }
 
-func newCribbageServer(dbFactory persistence.DBFactory) *cribbageServer {
+func newCribbageServer(dbFactory persistence.DBFactory, signer *auth.Signer) *cribbageServer {
 	return &cribbageServer{
 		dbFactory: dbFactory,
+		signer:    signer,
 	}
 }
 
 func (cs *cribbageServer) NewRouter() http.Handler {
 	router := gin.Default()
+	router.Use(cs.authenticate)
+
+	// Simple group: auth
+	authGroup := router.Group(`/auth`)
+	{
+		authGroup.POST(`/login`, cs.ginPostLogin)
+		authGroup.POST(`/logout`, requireAuth, cs.ginPostLogout)
+	}
 
 	// Simple group: create
 	create := router.Group(`/create`)
 	{
-		create.POST(`/game`, cs.ginPostCreateGame)
+		create.POST(`/game`, requireAuth, cs.ginPostCreateGame)
 		create.POST(`/player`, cs.ginPostCreatePlayer)
-		create.POST(`/interaction`, cs.ginPostCreateInteraction)
+		create.POST(`/interaction`, requireAuth, cs.ginPostCreateInteraction)
+		create.POST(`/match`, requireAuth, cs.ginPostCreateMatch)
+		create.POST(`/invitation`, requireAuth, cs.ginPostCreateInvitation)
 	}
 
 	router.GET(`/game/:gameID`, cs.ginGetGame)
+	router.GET(`/game/:gameID/result`, cs.ginGetGameResult)
+	router.GET(`/game/:gameID/events`, cs.ginGetGameEvents)
+	router.GET(`/game/:gameID/replay`, cs.ginGetGameReplay)
+	router.GET(`/game/:gameID/at/:numActions`, cs.ginGetGameAt)
+	router.GET(`/game/:gameID/spectators`, cs.ginGetSpectators)
+	router.POST(`/game/:gameID/spectators`, requireAuth, cs.ginPostSpectator)
+	router.POST(`/game/:gameID/undo`, requireAuth, cs.ginPostGameUndo)
+
+	// Simple group: match
+	match := router.Group(`/match`)
+	{
+		match.GET(`/:matchID`, cs.ginGetMatch)
+		match.POST(`/:matchID/game`, requireAuth, cs.ginPostMatchGame)
+	}
 
 	// Simple group: games
 	game := router.Group(`/games`)
 	{
 		game.GET(`/active`, cs.ginGetActiveGamesForPlayer)
+		game.GET(`/status/:status`, cs.ginGetGamesForPlayer)
+	}
+
+	invitation := router.Group(`/invitation`)
+	{
+		invitation.GET(`/:invitationID`, cs.ginGetInvitation)
+		invitation.POST(`/:invitationID/accept`, requireAuth, cs.ginPostAcceptInvitation)
+		invitation.POST(`/:invitationID/decline`, requireAuth, cs.ginPostDeclineInvitation)
+	}
+	router.GET(`/invitations`, requireAuth, cs.ginGetPendingInvitations)
+
+	lobby := router.Group(`/lobby`)
+	{
+		lobby.GET(``, cs.ginGetLobby)
+		lobby.POST(``, requireAuth, cs.ginPostLobby)
 	}
 
 	// Simple group: player
 	player := router.Group(`/player`)
 	{
 		player.GET(`/:username`, cs.ginGetPlayer)
+		player.GET(`/:username/stats`, cs.ginGetPlayerStats)
 	}
 
-	router.POST(`/action`, cs.ginPostAction)
+	router.POST(`/action`, requireAuth, cs.ginPostAction)
+
+	router.GET(`/score`, cs.ginGetScore)
+
+	router.GET(`/leaderboard`, cs.ginGetLeaderboard)
 
 	return router
 }
@@ -102,17 +154,28 @@ func (cs *cribbageServer) ginPostCreateGame(c *gin.Context) {
 		c.String(http.StatusInternalServerError, `Error: %s`, err)
 		return
 	}
-	pIDs := make([]model.PlayerID, len(gameReq.PlayerIDs))
-	for i, pID := range gameReq.PlayerIDs {
+	pIDs, err := seatPlayers(gameReq)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid teams: %s`, err)
+		return
+	}
+	for i, pID := range pIDs {
 		if pID == model.InvalidPlayerID {
 			c.String(http.StatusBadRequest, `Invalid player ID at index %d`, i)
 			return
 		}
-		pIDs[i] = pID
 	}
 
 	if len(pIDs) < model.MinPlayerGame || len(pIDs) > model.MaxPlayerGame {
-		c.String(http.StatusBadRequest, `Invalid num players: %d`, len(gameReq.PlayerIDs))
+		c.String(http.StatusBadRequest, `Invalid num players: %d`, len(pIDs))
+		return
+	}
+	if !isAuthedPlayerSeated(c, pIDs) {
+		return
+	}
+
+	opts, ok := bindGameOptions(c, gameReq.Muggins, gameReq.Rules, gameReq.TurnTimer)
+	if !ok {
 		return
 	}
 
@@ -124,7 +187,7 @@ func (cs *cribbageServer) ginPostCreateGame(c *gin.Context) {
 	}
 	defer db.Close()
 
-	g, err := createGame(ctx, db, pIDs)
+	g, err := createGame(ctx, db, pIDs, opts)
 	if err != nil {
 		c.String(http.StatusInternalServerError, `createGame error: %s`, err)
 		return
@@ -133,6 +196,18 @@ func (cs *cribbageServer) ginPostCreateGame(c *gin.Context) {
 	c.JSON(http.StatusOK, network.ConvertToCreateGameResponse(g))
 }
 
+// seatPlayers returns the players in the order they sit. If the request chose
+// teams, then partners are seated across from each other.
+func seatPlayers(gameReq network.CreateGameRequest) ([]model.PlayerID, error) {
+	if len(gameReq.Teams) == 0 {
+		return gameReq.PlayerIDs, nil
+	}
+	if len(gameReq.PlayerIDs) > 0 {
+		return nil, errors.New(`cannot choose both playerIDs and teams`)
+	}
+	return model.SeatTeams(gameReq.Teams)
+}
+
 // POST /create/player
 func (cs *cribbageServer) ginPostCreatePlayer(c *gin.Context) {
 	var cpr network.CreatePlayerRequest
@@ -153,6 +228,15 @@ func (cs *cribbageServer) ginPostCreatePlayer(c *gin.Context) {
 		c.String(http.StatusBadRequest, `Username must be alphanumeric`)
 		return
 	}
+	hash, err := auth.HashPassword(cpr.Password)
+	if err != nil {
+		if err == auth.ErrPasswordTooShort {
+			c.String(http.StatusBadRequest, `Password must be at least %d characters`, auth.MinPasswordLength)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
 
 	ctx := context.Background()
 	db, err := cs.dbFactory.New(ctx)
@@ -166,17 +250,28 @@ func (cs *cribbageServer) ginPostCreatePlayer(c *gin.Context) {
 		ID:   cpr.Player.ID,
 		Name: cpr.Player.Name,
 	}
-	err = createPlayer(ctx, db, p)
+	a := model.Account{
+		PlayerID:     p.ID,
+		PasswordHash: hash,
+	}
+	err = createPlayer(ctx, db, p, a)
 	if err != nil {
 		switch err {
-		case persistence.ErrPlayerAlreadyExists:
+		case persistence.ErrPlayerAlreadyExists, persistence.ErrAccountAlreadyExists:
 			c.String(http.StatusBadRequest, `Username already exists`)
 		default:
 			c.String(http.StatusInternalServerError, `Error: %s`, err)
 		}
 		return
 	}
-	c.JSON(http.StatusOK, network.ConvertToCreatePlayerResponse(p))
+
+	s, ok := cs.startSession(c, p.ID)
+	if !ok {
+		return
+	}
+	resp := network.ConvertToCreatePlayerResponse(p)
+	resp.Session = &s
+	c.JSON(http.StatusOK, resp)
 }
 
 func (cs *cribbageServer) ginPostCreateInteraction(c *gin.Context) {
@@ -186,11 +281,10 @@ func (cs *cribbageServer) ginPostCreateInteraction(c *gin.Context) {
 		c.String(http.StatusInternalServerError, `Error: %s`, err)
 		return
 	}
-	pID := cir.PlayerID
-	if pID == model.InvalidPlayerID {
-		c.String(http.StatusBadRequest, `Needs playerId`)
+	if !bindAuthedPlayer(c, &cir.PlayerID) {
 		return
 	}
+	pID := cir.PlayerID
 
 	var pm interaction.PlayerMeans
 	switch {
@@ -201,7 +295,7 @@ func (cs *cribbageServer) ginPostCreateInteraction(c *gin.Context) {
 		})
 	case len(cir.NPCType) > 0:
 		switch cir.NPCType {
-		case interaction.Simple, interaction.Calc, interaction.Dumb:
+		case interaction.Simple, interaction.Calc, interaction.Dumb, interaction.MonteCarlo:
 		default:
 			c.String(http.StatusBadRequest, `unsupported interaction mode`)
 			return
@@ -210,6 +304,10 @@ func (cs *cribbageServer) ginPostCreateInteraction(c *gin.Context) {
 			Mode: interaction.NPC,
 			Info: cir.NPCType,
 		})
+	case cir.Events:
+		pm = interaction.New(pID, interaction.Means{
+			Mode: interaction.ServerSentEvents,
+		})
 	default:
 		c.String(http.StatusBadRequest, `unsupported interaction mode`)
 		return
@@ -232,12 +330,17 @@ func (cs *cribbageServer) ginPostCreateInteraction(c *gin.Context) {
 }
 
 // GET /game/:gameID?player=<playerID>
+// Without a player, this is the spectator view, which hides the cards nobody has shown
 func (cs *cribbageServer) ginGetGame(c *gin.Context) {
 	gID, err := getGameIDFromContext(c)
 	if err != nil {
 		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
 		return
 	}
+	pID := model.PlayerID(c.Query(`player`))
+	if !canViewHand(c, pID) {
+		return
+	}
 
 	ctx := context.Background()
 	db, err := cs.dbFactory.New(ctx)
@@ -257,13 +360,96 @@ func (cs *cribbageServer) ginGetGame(c *gin.Context) {
 		return
 	}
 
-	pID := c.Query(`player`)
-	if pID == `` {
+	if pID == model.InvalidPlayerID {
 		resp := network.ConvertToGetGameResponse(g)
 		c.JSON(http.StatusOK, resp)
 		return
 	}
-	resp, err := network.ConvertToGetGameResponseForPlayer(g, model.PlayerID(pID))
+	resp, err := network.ConvertToGetGameResponseForPlayer(g, pID)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /game/:gameID/replay?player=<playerID>
+func (cs *cribbageServer) ginGetGameReplay(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+	pID := model.PlayerID(c.Query(`player`))
+	if !canViewHand(c, pID) {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	states, err := getGameReplay(ctx, db, gID)
+	if err != nil {
+		if err == persistence.ErrGameNotFound {
+			c.String(http.StatusNotFound, `Game not found`)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	resp, err := network.ConvertToGetGameReplayResponse(gID, states, pID)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /game/:gameID/at/:numActions?player=<playerID>
+func (cs *cribbageServer) ginGetGameAt(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+	n, err := strconv.ParseUint(c.Param(`numActions`), 10, 32)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid number of actions: %v`, err)
+		return
+	}
+	pID := model.PlayerID(c.Query(`player`))
+	if !canViewHand(c, pID) {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	g, err := getGameAt(ctx, db, gID, uint(n))
+	if err != nil {
+		switch err {
+		case persistence.ErrGameNotFound:
+			c.String(http.StatusNotFound, `Game not found`)
+		case errActionNotFound:
+			c.String(http.StatusNotFound, `Action not found`)
+		default:
+			c.String(http.StatusInternalServerError, `Error: %s`, err)
+		}
+		return
+	}
+
+	resp, err := network.ConvertToGameSnapshot(g, pID)
 	if err != nil {
 		c.String(http.StatusBadRequest, err.Error())
 		return
@@ -271,6 +457,117 @@ func (cs *cribbageServer) ginGetGame(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GET /game/:gameID/result
+func (cs *cribbageServer) ginGetGameResult(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	g, res, err := getGameResult(ctx, db, gID)
+	if err != nil {
+		switch err {
+		case persistence.ErrGameNotFound:
+			c.String(http.StatusNotFound, `Game not found`)
+		case model.ErrGameNotOver:
+			c.String(http.StatusBadRequest, `Game is not over`)
+		case model.ErrGameAbandoned:
+			c.String(http.StatusBadRequest, `Game was abandoned`)
+		default:
+			c.String(http.StatusInternalServerError, `Error: %s`, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetGameResultResponse(g, res))
+}
+
+// GET /game/:gameID/events?player=<playerID>
+// Spectators only hear about the events that everyone can see
+func (cs *cribbageServer) ginGetGameEvents(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+	pID := model.PlayerID(c.Query(`player`))
+	if pID == model.InvalidPlayerID {
+		c.String(http.StatusBadRequest, `Requires player`)
+		return
+	}
+	if !canViewHand(c, pID) {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	ok, err := isWatchingGame(ctx, db, gID, pID)
+	// don't hold onto the db for as long as the client is listening
+	db.Close()
+	if err != nil {
+		if err == persistence.ErrGameNotFound {
+			c.String(http.StatusNotFound, `Game not found`)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+	if !ok {
+		c.String(http.StatusBadRequest, `Player not in game`)
+		return
+	}
+
+	sub := gameEvents.subscribe(gID, pID)
+	defer gameEvents.unsubscribe(gID, sub)
+
+	// let the client know it's connected before the first event shows up
+	c.Header(`Content-Type`, `text/event-stream`)
+	c.Header(`Cache-Control`, `no-cache`)
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e := <-sub.events:
+			c.SSEvent(string(e.Type), e)
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
+
+func isPlayerInGame(g model.Game, pID model.PlayerID) bool {
+	for _, p := range g.Players {
+		if p.ID == pID {
+			return true
+		}
+	}
+	return false
+}
+
+func isPlayerInMatch(m model.Match, pID model.PlayerID) bool {
+	for _, mpID := range m.PlayerIDs {
+		if mpID == pID {
+			return true
+		}
+	}
+	return false
+}
+
 func getGameIDFromContext(c *gin.Context) (model.GameID, error) {
 	gIDStr := c.Param(`gameID`)
 	n, err := strconv.Atoi(gIDStr)
@@ -305,13 +602,9 @@ func (cs *cribbageServer) ginGetPlayer(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-// GET /games/active?playerID=pID
-func (cs *cribbageServer) ginGetActiveGamesForPlayer(c *gin.Context) {
-	pID := model.PlayerID(c.Query(`playerID`))
-	if len(pID) == 0 {
-		c.String(http.StatusBadRequest, `Requires playerID`)
-		return
-	}
+// GET /player/:username/stats
+func (cs *cribbageServer) ginGetPlayerStats(c *gin.Context) {
+	pID := model.PlayerID(c.Param(`username`))
 
 	ctx := context.Background()
 	db, err := cs.dbFactory.New(ctx)
@@ -321,7 +614,7 @@ func (cs *cribbageServer) ginGetActiveGamesForPlayer(c *gin.Context) {
 	}
 	defer db.Close()
 
-	p, err := getPlayer(ctx, db, pID)
+	p, ps, err := getPlayerStats(ctx, db, pID)
 	if err != nil {
 		if err == persistence.ErrPlayerNotFound {
 			c.String(http.StatusNotFound, `Player not found`)
@@ -330,21 +623,70 @@ func (cs *cribbageServer) ginGetActiveGamesForPlayer(c *gin.Context) {
 		c.String(http.StatusInternalServerError, `Error: %s`, err)
 		return
 	}
-	games := make(map[model.GameID]model.Game, len(p.Games))
-	for gID := range p.Games {
-		mg, err := getGame(ctx, db, gID)
-		if err != nil {
-			c.String(http.StatusInternalServerError, `Error: %s`, err)
-			return
-		} else if mg.IsOver() {
-			continue
-		}
-		games[gID] = mg
+	resp := network.ConvertToGetPlayerStatsResponse(p, ps)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /games/active?playerID=pID
+func (cs *cribbageServer) ginGetActiveGamesForPlayer(c *gin.Context) {
+	p, games, ok := cs.getPlayerGamesWithStatus(c, model.StatusActive)
+	if !ok {
+		return
 	}
 	resp := network.ConvertToGetActiveGamesForPlayerResponse(p, games)
 	c.JSON(http.StatusOK, resp)
 }
 
+// GET /games/status/:status?playerID=pID
+func (cs *cribbageServer) ginGetGamesForPlayer(c *gin.Context) {
+	status, err := model.NewGameStatusFromString(c.Param(`status`))
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid status: %s`, err)
+		return
+	}
+
+	p, games, ok := cs.getPlayerGamesWithStatus(c, status)
+	if !ok {
+		return
+	}
+	resp := network.ConvertToGetGamesForPlayerResponse(p, status, games)
+	c.JSON(http.StatusOK, resp)
+}
+
+// getPlayerGamesWithStatus looks up the playerID's games that have the status. It
+// returns false if it couldn't, after it has written the error response.
+func (cs *cribbageServer) getPlayerGamesWithStatus(
+	c *gin.Context,
+	status model.GameStatus,
+) (model.Player, map[model.GameID]model.Game, bool) {
+
+	pID := model.PlayerID(c.Query(`playerID`))
+	if len(pID) == 0 {
+		c.String(http.StatusBadRequest, `Requires playerID`)
+		return model.Player{}, nil, false
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return model.Player{}, nil, false
+	}
+	defer db.Close()
+
+	p, games, err := getPlayerGamesWithStatus(ctx, db, pID, status)
+	if err != nil {
+		if err == persistence.ErrPlayerNotFound {
+			c.String(http.StatusNotFound, `Player not found`)
+			return model.Player{}, nil, false
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return model.Player{}, nil, false
+	}
+
+	return p, games, true
+}
+
 func (cs *cribbageServer) ginPostAction(c *gin.Context) {
 	reqBytes, err := ioutil.ReadAll(c.Request.Body)
 	if err != nil {
@@ -356,6 +698,9 @@ func (cs *cribbageServer) ginPostAction(c *gin.Context) {
 		c.String(http.StatusBadRequest, `Error: %s`, err)
 		return
 	}
+	if !bindAuthedPlayer(c, &action.ID) {
+		return
+	}
 
 	ctx := context.Background()
 	db, err := cs.dbFactory.New(ctx)
@@ -373,3 +718,225 @@ func (cs *cribbageServer) ginPostAction(c *gin.Context) {
 
 	c.String(http.StatusOK, `action handled`)
 }
+
+// POST /game/:gameID/undo
+func (cs *cribbageServer) ginPostGameUndo(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+	var undoReq network.UndoRequest
+	err = c.ShouldBindJSON(&undoReq)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+	if !bindAuthedPlayer(c, &undoReq.PlayerID) {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	g, err := handleUndo(ctx, db, network.ConvertFromUndoRequest(gID, undoReq))
+	if err != nil {
+		if err == persistence.ErrGameNotFound {
+			c.String(http.StatusNotFound, `Game not found`)
+			return
+		}
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+
+	resp, err := network.ConvertToGetGameResponseForPlayer(g, undoReq.PlayerID)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /score?hand=5S,5C,5D,JH&cut=5H&crib=false
+func (cs *cribbageServer) ginGetScore(c *gin.Context) {
+	cut, hand, isCrib, err := getScoreQuery(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+	if !scorer.CanScore(len(hand), isCrib) {
+		c.String(http.StatusBadRequest, `Cannot score %d cards`, len(hand))
+		return
+	}
+
+	items := scorer.Breakdown(cut, hand, isCrib)
+	resp := network.ConvertToGetScoreResponse(cut, hand, isCrib, items)
+	c.JSON(http.StatusOK, resp)
+}
+
+func getScoreQuery(c *gin.Context) (model.Card, []model.Card, bool, error) {
+	handStr := c.Query(`hand`)
+	if len(handStr) == 0 {
+		return model.Card{}, nil, false, errors.New(`requires hand`)
+	}
+	cutStr := c.Query(`cut`)
+	if len(cutStr) == 0 {
+		return model.Card{}, nil, false, errors.New(`requires cut`)
+	}
+
+	isCrib := false
+	if cribStr := c.Query(`crib`); len(cribStr) > 0 {
+		b, err := strconv.ParseBool(cribStr)
+		if err != nil {
+			return model.Card{}, nil, false, fmt.Errorf(`invalid crib: %s`, err)
+		}
+		isCrib = b
+	}
+
+	cut, err := model.NewCardFromExternalString(cutStr)
+	if err != nil {
+		return model.Card{}, nil, false, fmt.Errorf(`invalid cut: %s`, err)
+	}
+
+	cardStrs := strings.Split(handStr, `,`)
+	hand := make([]model.Card, len(cardStrs))
+	seen := map[model.Card]struct{}{cut: {}}
+	for i, cardStr := range cardStrs {
+		hand[i], err = model.NewCardFromExternalString(cardStr)
+		if err != nil {
+			return model.Card{}, nil, false, fmt.Errorf(`invalid hand: %s`, err)
+		}
+		if _, ok := seen[hand[i]]; ok {
+			return model.Card{}, nil, false, fmt.Errorf(`duplicate card: %s`, hand[i])
+		}
+		seen[hand[i]] = struct{}{}
+	}
+
+	return cut, hand, isCrib, nil
+}
+
+// POST /create/match
+func (cs *cribbageServer) ginPostCreateMatch(c *gin.Context) {
+	var matchReq network.CreateMatchRequest
+	err := c.ShouldBindJSON(&matchReq)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+	for i, pID := range matchReq.PlayerIDs {
+		if pID == model.InvalidPlayerID {
+			c.String(http.StatusBadRequest, `Invalid player ID at index %d`, i)
+			return
+		}
+	}
+	if n := len(matchReq.PlayerIDs); n < model.MinPlayerGame || n > model.MaxPlayerGame {
+		c.String(http.StatusBadRequest, `Invalid num players: %d`, n)
+		return
+	}
+	if !isAuthedPlayerSeated(c, matchReq.PlayerIDs) {
+		return
+	}
+	if err = model.ValidateMatchLength(matchReq.BestOf); err != nil {
+		c.String(http.StatusBadRequest, `Invalid best_of: %s`, err)
+		return
+	}
+
+	opts, ok := bindGameOptions(c, matchReq.Muggins, matchReq.Rules, matchReq.TurnTimer)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	m, err := createMatch(ctx, db, matchReq.PlayerIDs, matchReq.BestOf, opts)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `createMatch error: %s`, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetMatchResponse(m, model.MatchStandings{}))
+}
+
+// GET /match/:matchID
+func (cs *cribbageServer) ginGetMatch(c *gin.Context) {
+	mID, err := getMatchIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid MatchID: %v`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	m, ms, err := getMatch(ctx, db, mID)
+	if err != nil {
+		if err == persistence.ErrMatchNotFound {
+			c.String(http.StatusNotFound, `Match not found`)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetMatchResponse(m, ms))
+}
+
+// POST /match/:matchID/game
+func (cs *cribbageServer) ginPostMatchGame(c *gin.Context) {
+	mID, err := getMatchIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid MatchID: %v`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	pID, _ := authedPlayer(c)
+	g, err := startNextMatchGame(ctx, db, mID, pID)
+	if err != nil {
+		switch err {
+		case persistence.ErrMatchNotFound:
+			c.String(http.StatusNotFound, `Match not found`)
+		case errNotInMatch:
+			c.String(http.StatusForbidden, `Only players in the match can start its games`)
+		case errMatchGameInProgress, errMatchOver:
+			c.String(http.StatusBadRequest, `Error: %s`, err)
+		default:
+			c.String(http.StatusInternalServerError, `Error: %s`, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToCreateGameResponse(g))
+}
+
+func getMatchIDFromContext(c *gin.Context) (model.MatchID, error) {
+	mIDStr := c.Param(`matchID`)
+	n, err := strconv.ParseUint(mIDStr, 10, 32)
+	if err != nil {
+		return model.InvalidMatchID, err
+	}
+	return model.MatchID(n), nil
+}
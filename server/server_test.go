@@ -9,13 +9,16 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/auth"
 	"github.com/joshprzybyszewski/cribbage/server/persistence"
 	"github.com/joshprzybyszewski/cribbage/server/persistence/memory"
 )
@@ -30,6 +33,27 @@ func performRequest(r http.Handler, method, path string, body io.Reader) (*httpt
 	return w, nil
 }
 
+// performAuthedRequest makes the request with a session for the player
+func performAuthedRequest(
+	t *testing.T,
+	cs *cribbageServer,
+	r http.Handler,
+	pID model.PlayerID,
+	method, path string,
+	body io.Reader,
+) *httptest.ResponseRecorder {
+
+	req, err := http.NewRequest(method, path, body)
+	require.NoError(t, err)
+	token, _, err := cs.signer.Sign(pID, time.Now())
+	require.NoError(t, err)
+	req.Header.Set(`Authorization`, bearerPrefix+token)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func readBody(t *testing.T, r io.Reader, v interface{}) {
 	bs, err := ioutil.ReadAll(r)
 	require.NoError(t, err)
@@ -49,11 +73,13 @@ func prepareBody(t *testing.T, v interface{}) io.Reader {
 	return bytes.NewReader(reqBytes)
 }
 
-func newServerAndRouter(_ *testing.T) (*cribbageServer, http.Handler) {
+func newServerAndRouter(t *testing.T) (*cribbageServer, http.Handler) {
 	// first make sure the db is completely cleared
 	dbf := memory.NewFactory()
 	memory.Clear()
-	cs := newCribbageServer(dbf)
+	signer, err := auth.NewSigner([]byte(`test signing key`), time.Hour)
+	require.NoError(t, err)
+	cs := newCribbageServer(dbf, signer)
 	router := cs.NewRouter()
 	return cs, router
 }
@@ -70,6 +96,11 @@ func seedPlayers(t *testing.T, dbf persistence.DBFactory, n int) []model.PlayerI
 			Name: `name`,
 		})
 		require.NoError(t, err)
+		// without a password, they can only act with a session the test signs
+		err = db.CreateAccount(model.Account{
+			PlayerID: model.PlayerID(idStr),
+		})
+		require.NoError(t, err)
 		pIDs[i] = model.PlayerID(idStr)
 	}
 	return pIDs
@@ -92,6 +123,7 @@ func TestGinPostCreatePlayer(t *testing.T) {
 					ID:   `abc`,
 					Name: `def`,
 				},
+				Password: `password`,
 			},
 			expCode: http.StatusOK,
 			expErr:  ``,
@@ -116,6 +148,7 @@ func TestGinPostCreatePlayer(t *testing.T) {
 					ID:   `abc`,
 					Name: `def`,
 				},
+				Password: `password`,
 			},
 			expCode: http.StatusOK,
 			expErr:  ``,
@@ -125,6 +158,7 @@ func TestGinPostCreatePlayer(t *testing.T) {
 					ID:   `abc`,
 					Name: `def`,
 				},
+				Password: `password`,
 			},
 			expCode: http.StatusBadRequest,
 			expErr:  `Username already exists`,
@@ -153,6 +187,19 @@ func TestGinPostCreatePlayer(t *testing.T) {
 			expCode: http.StatusBadRequest,
 			expErr:  `Display name is required`,
 		}},
+	}, {
+		msg: `short password`,
+		reqs: []testRequest{{
+			req: network.CreatePlayerRequest{
+				Player: network.Player{
+					ID:   `abc`,
+					Name: `def`,
+				},
+				Password: `short`,
+			},
+			expCode: http.StatusBadRequest,
+			expErr:  `Password must be at least 8 characters`,
+		}},
 	}, {
 		msg: `send wrong JSON data - this is equivalent to PlayerID and DispName being empty`,
 		reqs: []testRequest{{
@@ -193,6 +240,8 @@ func TestGinPostCreatePlayer(t *testing.T) {
 			}
 			assert.NoError(t, err)
 			assert.Equal(t, expPlayer, player, tc.msg)
+			require.NotNil(t, playerResp.Session, tc.msg)
+			assert.NotEmpty(t, playerResp.Session.Token, tc.msg)
 		}
 	}
 }
@@ -200,6 +249,7 @@ func TestGinPostCreateGame(t *testing.T) {
 	testCases := []struct {
 		msg     string
 		pIDs    []string
+		rules   *network.GameRules
 		expCode int
 		expErr  string
 	}{{
@@ -225,8 +275,18 @@ func TestGinPostCreateGame(t *testing.T) {
 	}, {
 		msg:     `five player game`,
 		pIDs:    []string{`p1`, `p2`, `p3`, `p4`, `p5`},
+		expCode: http.StatusOK,
+		expErr:  ``,
+	}, {
+		msg:     `six player game`,
+		pIDs:    []string{`p1`, `p2`, `p3`, `p4`, `p5`, `p6`},
+		expCode: http.StatusOK,
+		expErr:  ``,
+	}, {
+		msg:     `seven player game`,
+		pIDs:    []string{`p1`, `p2`, `p3`, `p4`, `p5`, `p6`, `p7`},
 		expCode: http.StatusBadRequest,
-		expErr:  `Invalid num players: 5`,
+		expErr:  `Invalid num players: 7`,
 	}, {
 		msg:     `zero player game`,
 		pIDs:    []string{},
@@ -244,7 +304,7 @@ func TestGinPostCreateGame(t *testing.T) {
 		expErr:  `createGame error: player not found`,
 	}, {
 		msg:     `create a game with nonexistent players`,
-		pIDs:    []string{`p1`, `p6`},
+		pIDs:    []string{`p1`, `p8`},
 		expCode: http.StatusInternalServerError,
 		expErr:  `createGame error: player not found`,
 	}, {
@@ -252,20 +312,33 @@ func TestGinPostCreateGame(t *testing.T) {
 		pIDs:    []string{},
 		expCode: http.StatusBadRequest,
 		expErr:  `Invalid num players: 0`,
+	}, {
+		msg:     `five-card game`,
+		pIDs:    []string{`p1`, `p2`},
+		rules:   &network.GameRules{Variant: model.FiveCardVariant},
+		expCode: http.StatusOK,
+		expErr:  ``,
+	}, {
+		msg:     `unknown variant`,
+		pIDs:    []string{`p1`, `p2`},
+		rules:   &network.GameRules{Variant: `nine-card`},
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid rules: unknown game variant`,
 	}}
 	cs, router := newServerAndRouter(t)
 	// seed the db with players
-	seedPlayers(t, cs.dbFactory, 5)
+	seedPlayers(t, cs.dbFactory, 6)
 	for _, tc := range testCases {
-		cgr := network.CreateGameRequest{}
+		cgr := network.CreateGameRequest{
+			Rules: tc.rules,
+		}
 		cgr.PlayerIDs = make([]model.PlayerID, len(tc.pIDs))
 		for i, id := range tc.pIDs {
 			cgr.PlayerIDs[i] = model.PlayerID(id)
 		}
 		// make the request
 		body := prepareBody(t, cgr)
-		w, err := performRequest(router, `POST`, `/create/game`, body)
-		require.NoError(t, err)
+		w := performAuthedRequest(t, cs, router, `p1`, `POST`, `/create/game`, body)
 		// verify
 		require.Equal(t, tc.expCode, w.Code)
 		if tc.expCode != http.StatusOK {
@@ -281,29 +354,116 @@ func TestGinPostCreateGame(t *testing.T) {
 			assert.Contains(t, cgr.PlayerIDs, p.ID)
 		}
 	}
+
+	cgr := network.CreateGameRequest{
+		PlayerIDs: []model.PlayerID{`p1`, `p2`},
+	}
+	w, err := performRequest(router, `POST`, `/create/game`, prepareBody(t, cgr))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Requires signing in`, readError(t, w))
+
+	// players can't start games for other people
+	w = performAuthedRequest(t, cs, router, `p3`, `POST`, `/create/game`, prepareBody(t, cgr))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `Can only start games that you play in`, readError(t, w))
 }
+func TestGinPostCreateGameWithTeams(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 4)
+
+	cgr := network.CreateGameRequest{
+		Teams: [][]model.PlayerID{{pIDs[0], pIDs[1]}, {pIDs[2], pIDs[3]}},
+	}
+	w := performAuthedRequest(t, cs, router, pIDs[3], `POST`, `/create/game`, prepareBody(t, cgr))
+	require.Equal(t, http.StatusOK, w.Code)
+	var gameResp network.CreateGameResponse
+	readBody(t, w.Body, &gameResp)
+
+	// partners sit across from each other and share a color
+	require.Len(t, gameResp.Players, 4)
+	for i, pID := range []model.PlayerID{pIDs[0], pIDs[2], pIDs[1], pIDs[3]} {
+		assert.Equal(t, pID, gameResp.Players[i].ID)
+	}
+	assert.Equal(t, map[model.PlayerID]string{
+		pIDs[0]: `blue`,
+		pIDs[1]: `blue`,
+		pIDs[2]: `red`,
+		pIDs[3]: `red`,
+	}, gameResp.PlayerColors)
+
+	for _, tc := range []struct {
+		msg    string
+		req    network.CreateGameRequest
+		expErr string
+	}{{
+		msg: `uneven teams`,
+		req: network.CreateGameRequest{
+			Teams: [][]model.PlayerID{{pIDs[0], pIDs[1], pIDs[2]}, {pIDs[3]}},
+		},
+		expErr: `Invalid teams: each team needs the same number of players`,
+	}, {
+		msg: `teams and players`,
+		req: network.CreateGameRequest{
+			PlayerIDs: pIDs,
+			Teams:     [][]model.PlayerID{{pIDs[0], pIDs[1]}, {pIDs[2], pIDs[3]}},
+		},
+		expErr: `Invalid teams: cannot choose both playerIDs and teams`,
+	}, {
+		msg: `player on both teams`,
+		req: network.CreateGameRequest{
+			Teams: [][]model.PlayerID{{pIDs[0], pIDs[1]}, {pIDs[2], pIDs[0]}},
+		},
+		expErr: `Invalid teams: players can only be on one team`,
+	}} {
+		w = performAuthedRequest(t, cs, router, pIDs[0], `POST`, `/create/game`, prepareBody(t, tc.req))
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.msg)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+	}
+}
+
 func TestGinPostCreateInteraction(t *testing.T) {
 	testCases := []struct {
 		msg     string
-		pIDs    []string
+		authAs  model.PlayerID
 		reqData network.CreateInteractionRequest
 		expCode int
 		expErr  string
 	}{{
-		msg: `missing player ID`,
+		msg: `not signed in`,
+		reqData: network.CreateInteractionRequest{
+			PlayerID:      `p1`,
+			LocalhostPort: `1234`,
+		},
+		expCode: http.StatusUnauthorized,
+		expErr:  `Requires signing in`,
+	}, {
+		msg:    `another player`,
+		authAs: `p2`,
+		reqData: network.CreateInteractionRequest{
+			PlayerID:      `p1`,
+			LocalhostPort: `1234`,
+		},
+		expCode: http.StatusForbidden,
+		expErr:  `Cannot act for another player`,
+	}, {
+		msg:    `missing player ID is the signed in player`,
+		authAs: `p1`,
 		reqData: network.CreateInteractionRequest{
 			PlayerID:      ``,
 			LocalhostPort: `1234`,
 		},
-		expCode: http.StatusBadRequest,
-		expErr:  `Needs playerId`,
+		expCode: http.StatusOK,
+		expErr:  ``,
 	}, {
 		msg:     `bad request body - equivalent to an empty network.CreateInteractionRequest`,
+		authAs:  `p1`,
 		reqData: network.CreateInteractionRequest{},
 		expCode: http.StatusBadRequest,
-		expErr:  `Needs playerId`,
+		expErr:  `unsupported interaction mode`,
 	}, {
-		msg: `good request`,
+		msg:    `good request`,
+		authAs: `p1`,
 		reqData: network.CreateInteractionRequest{
 			PlayerID:      `p1`,
 			LocalhostPort: `1234`,
@@ -311,7 +471,8 @@ func TestGinPostCreateInteraction(t *testing.T) {
 		expCode: http.StatusOK,
 		expErr:  ``,
 	}, {
-		msg: `unsupported interaction mode`,
+		msg:    `unsupported interaction mode`,
+		authAs: `p1`,
 		reqData: network.CreateInteractionRequest{
 			PlayerID: `p1`,
 		},
@@ -323,13 +484,19 @@ func TestGinPostCreateInteraction(t *testing.T) {
 	for _, tc := range testCases {
 		// make the request
 		body := prepareBody(t, tc.reqData)
-		w, err := performRequest(router, `POST`, `/create/interaction`, body)
-		require.NoError(t, err)
+		var w *httptest.ResponseRecorder
+		if tc.authAs == model.InvalidPlayerID {
+			var err error
+			w, err = performRequest(router, `POST`, `/create/interaction`, body)
+			require.NoError(t, err)
+		} else {
+			w = performAuthedRequest(t, cs, router, tc.authAs, `POST`, `/create/interaction`, body)
+		}
 		// verify
-		require.Equal(t, tc.expCode, w.Code)
+		require.Equal(t, tc.expCode, w.Code, tc.msg)
 		if tc.expCode != http.StatusOK {
 			errMsg := readError(t, w)
-			assert.Equal(t, tc.expErr, errMsg)
+			assert.Equal(t, tc.expErr, errMsg, tc.msg)
 			continue
 		}
 		bs, err := ioutil.ReadAll(w.Body)
@@ -345,7 +512,7 @@ func TestGinGetGame(t *testing.T) {
 		db, err := cs.dbFactory.New(ctx)
 		require.NoError(t, err)
 		defer db.Close()
-		g, err := createGame(ctx, db, pIDs)
+		g, err := createGame(ctx, db, pIDs, model.GameOptions{})
 		require.NoError(t, err)
 		return g
 	}
@@ -399,6 +566,352 @@ func TestGinGetGame(t *testing.T) {
 		assert.Equal(t, g.ID, gameResp.ID)
 	}
 }
+
+func TestGinGetGameResult(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	inProgress, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+
+	finished := model.Game{
+		ID: model.NewGameID(),
+		Players: []model.Player{
+			{ID: pIDs[0], Name: `name`},
+			{ID: pIDs[1], Name: `name`},
+		},
+		PlayerColors: map[model.PlayerID]model.PlayerColor{
+			pIDs[0]: model.Blue,
+			pIDs[1]: model.Red,
+		},
+		CurrentScores: map[model.PlayerColor]int{
+			model.Blue: 121,
+			model.Red:  80,
+		},
+		LagScores: map[model.PlayerColor]int{
+			model.Blue: 115,
+			model.Red:  72,
+		},
+		Phase: model.Counting,
+	}
+	require.NoError(t, db.CreateGame(finished))
+
+	testCases := []struct {
+		msg       string
+		url       string
+		expCode   int
+		expErr    string
+		expResult network.GameResult
+	}{{
+		msg:     `bad game ID`,
+		url:     `/game/123zzz/result`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid GameID: strconv.Atoi: parsing "123zzz": invalid syntax`,
+	}, {
+		msg:     `nonexistent game`,
+		url:     `/game/123/result`,
+		expCode: http.StatusNotFound,
+		expErr:  `Game not found`,
+	}, {
+		msg:     `game in progress`,
+		url:     fmt.Sprintf(`/game/%d/result`, inProgress.ID),
+		expCode: http.StatusBadRequest,
+		expErr:  `Game is not over`,
+	}, {
+		msg:     `skunked game`,
+		url:     fmt.Sprintf(`/game/%d/result`, finished.ID),
+		expCode: http.StatusOK,
+		expResult: network.GameResult{
+			Winner:         `blue`,
+			WinningPlayers: []model.PlayerID{pIDs[0]},
+			WinnerScore:    121,
+			LoserScore:     80,
+			Margin:         41,
+			Skunk:          `skunk`,
+			GamePoints:     2,
+		},
+	}}
+
+	for _, tc := range testCases {
+		w, err := performRequest(router, `GET`, tc.url, nil)
+		require.NoError(t, err, tc.msg)
+		require.Equal(t, tc.expCode, w.Code, tc.msg)
+		if tc.expCode != http.StatusOK {
+			assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+			continue
+		}
+		var resp network.GetGameResultResponse
+		readBody(t, w.Body, &resp)
+		assert.Equal(t, finished.ID, resp.GameID, tc.msg)
+		assert.Equal(t, tc.expResult, resp.GameResult, tc.msg)
+	}
+}
+
+func TestGinGetGameReplay(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 3)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	g, err := createGame(ctx, db, pIDs[:2], model.GameOptions{})
+	require.NoError(t, err)
+	dealer := g.CurrentDealer
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        dealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}))
+	g, err = getGame(ctx, db, g.ID)
+	require.NoError(t, err)
+	discards := g.Hands[dealer][:2]
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        dealer,
+		Overcomes: model.CribCard,
+		Action:    model.BuildCribAction{Cards: discards},
+	}))
+	other := pIDs[0]
+	if other == dealer {
+		other = pIDs[1]
+	}
+
+	// the whole replay, as the dealer saw it
+	w := performAuthedRequest(t, cs, router, dealer, `GET`, fmt.Sprintf(`/game/%d/replay?player=%s`, g.ID, dealer), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var replay network.GetGameReplayResponse
+	readBody(t, w.Body, &replay)
+	assert.Equal(t, g.ID, replay.ID)
+	require.Len(t, replay.Snapshots, 3)
+	assert.Nil(t, replay.Snapshots[0].LastAction)
+	assert.Equal(t, `Deal`, replay.Snapshots[0].Game.Phase)
+	assert.Equal(t, 1, replay.Snapshots[1].NumActions)
+	assert.Equal(t, 3, replay.Snapshots[1].LastAction.NumShuffles)
+	assert.Equal(t, `BuildCrib`, replay.Snapshots[1].Game.Phase)
+	assert.Len(t, replay.Snapshots[1].Game.Hands[dealer], 6)
+	assert.Len(t, replay.Snapshots[2].Game.Hands[dealer], 4)
+	assert.Equal(t, discards[0].String(), replay.Snapshots[2].LastAction.Cards[0].Name)
+
+	// a single snapshot, as the other player saw it
+	w = performAuthedRequest(t, cs, router, other, `GET`, fmt.Sprintf(`/game/%d/at/2?player=%s`, g.ID, other), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var snapshot network.GameSnapshot
+	readBody(t, w.Body, &snapshot)
+	assert.Equal(t, 2, snapshot.NumActions)
+	assert.Equal(t, dealer, snapshot.LastAction.PlayerID)
+	assert.Equal(t, `AddToCrib`, snapshot.LastAction.Overcomes)
+	require.Len(t, snapshot.LastAction.Cards, 2)
+	assert.Equal(t, `unknown`, snapshot.LastAction.Cards[0].Name)
+
+	for _, tc := range []struct {
+		url     string
+		authAs  model.PlayerID
+		expCode int
+		expErr  string
+	}{{
+		url:     `/game/123/replay`,
+		expCode: http.StatusNotFound,
+		expErr:  `Game not found`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/replay?player=%s`, g.ID, pIDs[2]),
+		authAs:  pIDs[2],
+		expCode: http.StatusBadRequest,
+		expErr:  `player does not exist in game`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/replay?player=%s`, g.ID, dealer),
+		expCode: http.StatusUnauthorized,
+		expErr:  `Requires signing in`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/at/1?player=%s`, g.ID, dealer),
+		authAs:  other,
+		expCode: http.StatusForbidden,
+		expErr:  `Cannot view another player's hand`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/at/3`, g.ID),
+		expCode: http.StatusNotFound,
+		expErr:  `Action not found`,
+	}, {
+		url:     fmt.Sprintf(`/game/%d/at/-1`, g.ID),
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid number of actions: strconv.ParseUint: parsing "-1": invalid syntax`,
+	}, {
+		url:     `/game/123/at/0`,
+		expCode: http.StatusNotFound,
+		expErr:  `Game not found`,
+	}} {
+		if tc.authAs == model.InvalidPlayerID {
+			w, err = performRequest(router, `GET`, tc.url, nil)
+			require.NoError(t, err, tc.url)
+		} else {
+			w = performAuthedRequest(t, cs, router, tc.authAs, `GET`, tc.url, nil)
+		}
+		assert.Equal(t, tc.expCode, w.Code, tc.url)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.url)
+	}
+}
+
+func TestGinPostGameUndo(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	g, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	dealer := g.CurrentDealer
+	other := pIDs[0]
+	if other == dealer {
+		other = pIDs[1]
+	}
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        dealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}))
+	undoURL := fmt.Sprintf(`/game/%d/undo`, g.ID)
+
+	undo := func(pID model.PlayerID, approve bool) *httptest.ResponseRecorder {
+		body := prepareBody(t, network.UndoRequest{PlayerID: pID, Approve: approve})
+		return performAuthedRequest(t, cs, router, pID, `POST`, undoURL, body)
+	}
+
+	// nobody can ask on behalf of the dealer
+	w, err := performRequest(router, `POST`, undoURL, prepareBody(t, network.UndoRequest{PlayerID: dealer}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	w = performAuthedRequest(t, cs, router, other, `POST`, undoURL, prepareBody(t, network.UndoRequest{PlayerID: dealer}))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `Cannot act for another player`, readError(t, w))
+
+	// the other player didn't deal, so they can't take it back
+	w = undo(other, false)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Error: only the player who took the last action can undo it`, readError(t, w))
+
+	// the dealer asks, and the other player denies
+	w = undo(dealer, false)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp network.GetGameResponse
+	readBody(t, w.Body, &resp)
+	assert.Equal(t, map[model.PlayerID]string{other: `Undo`}, resp.BlockingPlayers)
+
+	w = undo(other, false)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = network.GetGameResponse{}
+	readBody(t, w.Body, &resp)
+	assert.Equal(t, `BuildCrib`, resp.Phase)
+	g, err = getGame(ctx, db, g.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, g.NumActions())
+
+	// the dealer asks again, and the other player approves
+	w = undo(dealer, false)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = undo(other, true)
+	require.Equal(t, http.StatusOK, w.Code)
+	resp = network.GetGameResponse{}
+	readBody(t, w.Body, &resp)
+	assert.Equal(t, `Deal`, resp.Phase)
+	assert.Equal(t, map[model.PlayerID]string{dealer: `DealCards`}, resp.BlockingPlayers)
+	g, err = getGame(ctx, db, g.ID)
+	require.NoError(t, err)
+	assert.Zero(t, g.NumActions())
+
+	w = performAuthedRequest(t, cs, router, dealer, `POST`, `/game/123/undo`, prepareBody(t, network.UndoRequest{}))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `Game not found`, readError(t, w))
+}
+
+func TestGinMatch(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 3)
+
+	for _, tc := range []struct {
+		msg     string
+		req     network.CreateMatchRequest
+		expCode int
+		expErr  string
+	}{{
+		msg: `even match length`,
+		req: network.CreateMatchRequest{
+			PlayerIDs: pIDs,
+			BestOf:    4,
+		},
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid best_of: a match must be played over an odd number of games`,
+	}, {
+		msg: `too few players`,
+		req: network.CreateMatchRequest{
+			PlayerIDs: pIDs[:1],
+			BestOf:    3,
+		},
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid num players: 1`,
+	}} {
+		w := performAuthedRequest(t, cs, router, pIDs[0], `POST`, `/create/match`, prepareBody(t, tc.req))
+		require.Equal(t, tc.expCode, w.Code, tc.msg)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+	}
+
+	cmr := network.CreateMatchRequest{
+		PlayerIDs: pIDs[:2],
+		BestOf:    3,
+	}
+	w, err := performRequest(router, `POST`, `/create/match`, prepareBody(t, cmr))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = performAuthedRequest(t, cs, router, pIDs[2], `POST`, `/create/match`, prepareBody(t, cmr))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `Can only start games that you play in`, readError(t, w))
+
+	w = performAuthedRequest(t, cs, router, pIDs[1], `POST`, `/create/match`, prepareBody(t, cmr))
+	require.Equal(t, http.StatusOK, w.Code)
+	var created network.GetMatchResponse
+	readBody(t, w.Body, &created)
+	assert.Equal(t, pIDs[:2], created.PlayerIDs)
+	assert.Equal(t, 3, created.BestOf)
+	require.Len(t, created.GameIDs, 1)
+
+	w, err = performRequest(router, `GET`, fmt.Sprintf(`/match/%d`, created.ID), nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	var got network.GetMatchResponse
+	readBody(t, w.Body, &got)
+	assert.Equal(t, created.GameIDs, got.GameIDs)
+	assert.Equal(t, map[model.PlayerID]int{pIDs[0]: 0, pIDs[1]: 0}, got.Wins)
+	assert.Empty(t, got.Winners)
+
+	nextGame := fmt.Sprintf(`/match/%d/game`, created.ID)
+	w, err = performRequest(router, `POST`, nextGame, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = performAuthedRequest(t, cs, router, pIDs[2], `POST`, nextGame, nil)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, `Only players in the match can start its games`, readError(t, w))
+
+	// the first game hasn't been played yet
+	w = performAuthedRequest(t, cs, router, pIDs[0], `POST`, nextGame, nil)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Error: the current game in the match is not over`, readError(t, w))
+
+	w, err = performRequest(router, `GET`, `/match/123`, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `Match not found`, readError(t, w))
+}
+
 func TestGinGetPlayer(t *testing.T) {
 	testCases := []struct {
 		msg      string
@@ -436,16 +949,114 @@ func TestGinGetPlayer(t *testing.T) {
 	}
 }
 
+func TestGinGetScore(t *testing.T) {
+	testCases := []struct {
+		msg       string
+		url       string
+		expCode   int
+		expErr    string
+		expPoints int
+		expDesc   string
+	}{{
+		msg:       `perfect hand`,
+		url:       `/score?hand=5S,5C,5D,JH&cut=5H`,
+		expCode:   http.StatusOK,
+		expPoints: 29,
+	}, {
+		msg:       `fifteens and a pair`,
+		url:       `/score?hand=5S,5C,2D,JD&cut=KH`,
+		expCode:   http.StatusOK,
+		expPoints: 10,
+		expDesc:   `fifteen 2, fifteen 4, fifteen 6, fifteen 8, and a pair is 10`,
+	}, {
+		msg:       `four card flush doesn't count in the crib`,
+		url:       `/score?hand=2H,4H,6H,8H&cut=KS&crib=true`,
+		expCode:   http.StatusOK,
+		expPoints: 0,
+		expDesc:   `nineteen`,
+	}, {
+		msg:     `missing hand`,
+		url:     `/score?cut=5H`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: requires hand`,
+	}, {
+		msg:     `missing cut`,
+		url:     `/score?hand=5S,5C,5D,JH`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: requires cut`,
+	}, {
+		msg:     `bad card`,
+		url:     `/score?hand=5S,5C,5X,JH&cut=5H`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: invalid hand: bad input card: 5X`,
+	}, {
+		msg:     `duplicate card`,
+		url:     `/score?hand=5S,5C,5H,JH&cut=5H`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: duplicate card: 5H`,
+	}, {
+		msg:     `too many cards in the crib`,
+		url:     `/score?hand=5S,5C,5D,JH,2C&cut=5H&crib=true`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Cannot score 5 cards`,
+	}}
+
+	_, router := newServerAndRouter(t)
+	for _, tc := range testCases {
+		w, err := performRequest(router, `GET`, tc.url, nil)
+		require.NoError(t, err, tc.msg)
+		require.Equal(t, tc.expCode, w.Code, tc.msg)
+		if tc.expCode != http.StatusOK {
+			assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+			continue
+		}
+		var resp network.GetScoreResponse
+		readBody(t, w.Body, &resp)
+		assert.Equal(t, tc.expPoints, resp.Points, tc.msg)
+		if len(tc.expDesc) > 0 {
+			assert.Equal(t, tc.expDesc, resp.Description, tc.msg)
+		}
+	}
+}
+
 func TestGinPostAction(t *testing.T) {
 	type request struct {
-		action  model.PlayerAction
-		expCode int
-		expErr  string
+		action model.PlayerAction
+		// authAs is who signs the request. It defaults to the acting player
+		authAs    model.PlayerID
+		anonymous bool
+		expCode   int
+		expErr    string
 	}
 	testCases := []struct {
 		msg  string
 		reqs []request
 	}{{
+		msg: `acting for someone else`,
+		reqs: []request{{
+			action: model.PlayerAction{
+				ID:        `p1`,
+				Overcomes: model.DealCards,
+				Action: model.DealAction{
+					NumShuffles: 1,
+				},
+			},
+			anonymous: true,
+			expCode:   http.StatusUnauthorized,
+			expErr:    `Requires signing in`,
+		}, {
+			action: model.PlayerAction{
+				ID:        `p1`,
+				Overcomes: model.DealCards,
+				Action: model.DealAction{
+					NumShuffles: 1,
+				},
+			},
+			authAs:  `p2`,
+			expCode: http.StatusForbidden,
+			expErr:  `Cannot act for another player`,
+		}},
+	}, {
 		msg: `invalid action type`,
 		reqs: []request{{
 			action: model.PlayerAction{
@@ -526,7 +1137,7 @@ func TestGinPostAction(t *testing.T) {
 		require.NoError(t, err)
 		defer db.Close()
 
-		game, err := createGame(ctx, db, pIDs)
+		game, err := createGame(ctx, db, pIDs, model.GameOptions{})
 		require.NoError(t, err)
 
 		actionsCompleted := 0
@@ -534,10 +1145,18 @@ func TestGinPostAction(t *testing.T) {
 			r.action.GameID = game.ID
 			// make the request
 			body := prepareBody(t, r.action)
-			w, err := performRequest(router, `POST`, `/action`, body)
-			require.NoError(t, err)
+			var w *httptest.ResponseRecorder
+			switch {
+			case r.anonymous:
+				w, err = performRequest(router, `POST`, `/action`, body)
+				require.NoError(t, err)
+			case r.authAs != model.InvalidPlayerID:
+				w = performAuthedRequest(t, cs, router, r.authAs, `POST`, `/action`, body)
+			default:
+				w = performAuthedRequest(t, cs, router, r.action.ID, `POST`, `/action`, body)
+			}
 			// verify
-			require.Equal(t, r.expCode, w.Code)
+			require.Equal(t, r.expCode, w.Code, tc.msg)
 			if r.expCode != http.StatusOK {
 				errMsg := readError(t, w)
 				assert.Equal(t, r.expErr, errMsg)
@@ -555,3 +1174,114 @@ func TestGinPostAction(t *testing.T) {
 		}
 	}
 }
+
+func TestGinGetGamesForPlayer(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	active, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+
+	abandoned, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    abandoned.ID,
+		ID:        pIDs[1],
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}))
+
+	resigned, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    resigned.ID,
+		ID:        resigned.CurrentDealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 4},
+	}))
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    resigned.ID,
+		ID:        pIDs[1],
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}))
+
+	testCases := []struct {
+		msg      string
+		url      string
+		expCode  int
+		expErr   string
+		expGames []model.GameID
+	}{{
+		msg:      `active games`,
+		url:      `/games/active?playerID=p1`,
+		expCode:  http.StatusOK,
+		expGames: []model.GameID{active.ID},
+	}, {
+		msg:      `resigned games`,
+		url:      `/games/status/resigned?playerID=p1`,
+		expCode:  http.StatusOK,
+		expGames: []model.GameID{resigned.ID},
+	}, {
+		msg:      `abandoned games`,
+		url:      `/games/status/abandoned?playerID=p2`,
+		expCode:  http.StatusOK,
+		expGames: []model.GameID{abandoned.ID},
+	}, {
+		msg:      `no games with the status`,
+		url:      `/games/status/expired?playerID=p2`,
+		expCode:  http.StatusOK,
+		expGames: []model.GameID{},
+	}, {
+		msg:     `unknown status`,
+		url:     `/games/status/paused?playerID=p1`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Invalid status: unknown game status`,
+	}, {
+		msg:     `missing player`,
+		url:     `/games/status/active`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Requires playerID`,
+	}, {
+		msg:     `nonexistent player`,
+		url:     `/games/active?playerID=p3`,
+		expCode: http.StatusNotFound,
+		expErr:  `Player not found`,
+	}}
+
+	for _, tc := range testCases {
+		w, err := performRequest(router, `GET`, tc.url, nil)
+		require.NoError(t, err, tc.msg)
+		require.Equal(t, tc.expCode, w.Code, tc.msg)
+		if tc.expCode != http.StatusOK {
+			assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+			continue
+		}
+
+		var games []network.ActiveGame
+		if strings.Contains(tc.url, `/active`) {
+			var resp network.GetActiveGamesForPlayerResponse
+			readBody(t, w.Body, &resp)
+			games = resp.ActiveGames
+		} else {
+			var resp network.GetGamesForPlayerResponse
+			readBody(t, w.Body, &resp)
+			games = resp.Games
+		}
+		gIDs := make([]model.GameID, 0, len(games))
+		for _, ag := range games {
+			gIDs = append(gIDs, ag.GameID)
+		}
+		assert.Equal(t, tc.expGames, gIDs, tc.msg)
+	}
+
+	w, err := performRequest(router, `GET`, fmt.Sprintf(`/game/%d/result`, abandoned.ID), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Game was abandoned`, readError(t, w))
+}
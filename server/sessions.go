@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/auth"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var (
+	authSigningKey  = flag.String(`auth_signing_key`, ``, `The base64 key that sessions are signed with. default empty string makes a new one on startup`) //nolint:lll
+	sessionDuration = flag.Duration(`session_duration`, 7*24*time.Hour, `How long a player stays signed in`)
+	secureCookies   = flag.Bool(`secure_cookies`, true, `Only send the session cookie over https. Set to false when developing locally`) //nolint:lll
+)
+
+const (
+	sessionCookie = `session`
+	bearerPrefix  = `Bearer `
+
+	// authedPlayerKey is where the authenticated player's ID is kept in the gin.Context
+	authedPlayerKey = `authedPlayer`
+)
+
+// getSigner returns the signer for the configured key. Without one, it makes up a
+// key so that the server still works, but sessions won't survive a restart.
+func getSigner() (*auth.Signer, error) {
+	if *authSigningKey == `` {
+		log.Println("No auth_signing_key set. Sessions will end when the server restarts")
+		key, err := auth.NewKey()
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewSigner(key, *sessionDuration)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(*authSigningKey)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewSigner(key, *sessionDuration)
+}
+
+// authenticate finds out who signed the request, if anyone did. A request with a
+// token that isn't valid anymore is rejected, rather than treated as anonymous.
+func (cs *cribbageServer) authenticate(c *gin.Context) {
+	token := getSessionToken(c)
+	if token == `` {
+		c.Next()
+		return
+	}
+
+	now := time.Now()
+	claims, err := cs.signer.Verify(token, now)
+	if err != nil {
+		c.String(http.StatusUnauthorized, `Invalid session: %s`, err)
+		c.Abort()
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		c.Abort()
+		return
+	}
+	a, err := db.GetAccount(claims.PlayerID)
+	db.Close()
+	if err != nil {
+		if err == persistence.ErrAccountNotFound {
+			c.String(http.StatusUnauthorized, `Invalid session: account not found`)
+			c.Abort()
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		c.Abort()
+		return
+	}
+	if !claims.IssuedAt.After(a.SignedOutAt) {
+		c.String(http.StatusUnauthorized, `Invalid session: signed out`)
+		c.Abort()
+		return
+	}
+
+	c.Set(authedPlayerKey, claims.PlayerID)
+	c.Next()
+}
+
+func getSessionToken(c *gin.Context) string {
+	if h := c.GetHeader(`Authorization`); strings.HasPrefix(h, bearerPrefix) {
+		return strings.TrimPrefix(h, bearerPrefix)
+	}
+	token, err := c.Cookie(sessionCookie)
+	if err != nil {
+		return ``
+	}
+	return token
+}
+
+// requireAuth rejects requests from players who haven't signed in
+func requireAuth(c *gin.Context) {
+	if _, ok := authedPlayer(c); !ok {
+		c.String(http.StatusUnauthorized, `Requires signing in`)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// authedPlayer returns the player that signed the request
+func authedPlayer(c *gin.Context) (model.PlayerID, bool) {
+	v, ok := c.Get(authedPlayerKey)
+	if !ok {
+		return model.InvalidPlayerID, false
+	}
+	pID, ok := v.(model.PlayerID)
+	return pID, ok
+}
+
+// bindAuthedPlayer makes sure that the request is on behalf of the signed in player,
+// filling in the player if the request left it empty. It returns false if it isn't,
+// after it has written the error response.
+func bindAuthedPlayer(c *gin.Context, pID *model.PlayerID) bool {
+	authed, ok := authedPlayer(c)
+	if !ok {
+		c.String(http.StatusUnauthorized, `Requires signing in`)
+		return false
+	}
+	if *pID == model.InvalidPlayerID {
+		*pID = authed
+		return true
+	}
+	if *pID != authed {
+		c.String(http.StatusForbidden, `Cannot act for another player`)
+		return false
+	}
+	return true
+}
+
+// isAuthedPlayerSeated returns true if the signed in player is one of the players
+// that a game is being started for. It returns false after it has written the
+// error response.
+func isAuthedPlayerSeated(c *gin.Context, pIDs []model.PlayerID) bool {
+	authed, ok := authedPlayer(c)
+	if !ok {
+		c.String(http.StatusUnauthorized, `Requires signing in`)
+		return false
+	}
+	for _, pID := range pIDs {
+		if pID == authed {
+			return true
+		}
+	}
+	c.String(http.StatusForbidden, `Can only start games that you play in`)
+	return false
+}
+
+// canViewHand returns true if the request can see the player's hand. Nobody is
+// needed to see the public view of a game, but only the player can see their own
+// cards. It returns false after it has written the error response.
+func canViewHand(c *gin.Context, pID model.PlayerID) bool {
+	if pID == model.InvalidPlayerID {
+		return true
+	}
+	authed, ok := authedPlayer(c)
+	if !ok {
+		c.String(http.StatusUnauthorized, `Requires signing in`)
+		return false
+	}
+	if pID != authed {
+		c.String(http.StatusForbidden, `Cannot view another player's hand`)
+		return false
+	}
+	return true
+}
+
+// POST /auth/login
+func (cs *cribbageServer) ginPostLogin(c *gin.Context) {
+	var lr network.LoginRequest
+	err := c.ShouldBindJSON(&lr)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	p, a, err := getPlayerAccount(ctx, db, lr.Username)
+	if err != nil {
+		switch err {
+		case persistence.ErrPlayerNotFound, persistence.ErrAccountNotFound:
+			c.String(http.StatusUnauthorized, `Wrong username or password`)
+		default:
+			c.String(http.StatusInternalServerError, `Error: %s`, err)
+		}
+		return
+	}
+	err = auth.CheckPassword(a.PasswordHash, lr.Password)
+	if err != nil {
+		if err == auth.ErrWrongPassword {
+			c.String(http.StatusUnauthorized, `Wrong username or password`)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	s, ok := cs.startSession(c, p.ID)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, network.ConvertToLoginResponse(p, s.Token, s.Expires))
+}
+
+// POST /auth/logout
+func (cs *cribbageServer) ginPostLogout(c *gin.Context) {
+	pID, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	err = signOut(ctx, db, pID, time.Now())
+	if err != nil {
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	setSessionCookie(c, ``, -1)
+	c.String(http.StatusOK, `Signed out`)
+}
+
+// startSession signs the player in, and sets the session cookie for browsers. It
+// returns false if it couldn't, after it has written the error response.
+func (cs *cribbageServer) startSession(c *gin.Context, pID model.PlayerID) (network.Session, bool) {
+	token, claims, err := cs.signer.Sign(pID, time.Now())
+	if err != nil {
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return network.Session{}, false
+	}
+
+	maxAge := int(time.Until(claims.ExpiresAt).Seconds())
+	setSessionCookie(c, token, maxAge)
+
+	return network.Session{
+		Token:   token,
+		Expires: claims.ExpiresAt,
+	}, true
+}
+
+// setSessionCookie sets the session cookie so that other sites can't send it along
+// with the requests they make to us, which keeps them from acting for our players
+func setSessionCookie(c *gin.Context, token string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, token, maxAge, `/`, ``, *secureCookies, true)
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+func TestGinLoginAndLogout(t *testing.T) {
+	_, router := newServerAndRouter(t)
+
+	withToken := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, prepareBody(t, body))
+		require.NoError(t, err)
+		if token != `` {
+			req.Header.Set(`Authorization`, bearerPrefix+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	interactionReq := network.CreateInteractionRequest{
+		LocalhostPort: `1234`,
+	}
+
+	w := withToken(`POST`, `/create/player`, ``, network.CreatePlayerRequest{
+		Player: network.Player{
+			ID:   `alice`,
+			Name: `Alice`,
+		},
+		Password: `password`,
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var cpr network.CreatePlayerResponse
+	readBody(t, w.Body, &cpr)
+	require.NotNil(t, cpr.Session)
+	cookie := w.Header().Get(`Set-Cookie`)
+	assert.Contains(t, cookie, sessionCookie+`=`+cpr.Session.Token)
+	// other sites can't send the cookie with their requests
+	assert.Contains(t, cookie, `SameSite=Lax`)
+	assert.Contains(t, cookie, `Secure`)
+	assert.Contains(t, cookie, `HttpOnly`)
+
+	// the new player is signed in
+	w = withToken(`POST`, `/create/interaction`, cpr.Session.Token, interactionReq)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, lr := range []network.LoginRequest{{
+		Username: `alice`,
+		Password: `wrong password`,
+	}, {
+		Username: `bob`,
+		Password: `password`,
+	}} {
+		w = withToken(`POST`, `/auth/login`, ``, lr)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, `Wrong username or password`, readError(t, w))
+	}
+
+	w = withToken(`POST`, `/auth/login`, ``, network.LoginRequest{
+		Username: `alice`,
+		Password: `password`,
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	var lr network.LoginResponse
+	readBody(t, w.Body, &lr)
+	assert.Equal(t, network.Player{ID: `alice`, Name: `Alice`}, lr.Player)
+
+	w = withToken(`POST`, `/create/interaction`, lr.Session.Token, interactionReq)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// signing out ends every session
+	w = withToken(`POST`, `/auth/logout`, lr.Session.Token, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	cookie = w.Header().Get(`Set-Cookie`)
+	assert.Contains(t, cookie, `Max-Age=0`)
+	assert.Contains(t, cookie, `SameSite=Lax`)
+	assert.Contains(t, cookie, `Secure`)
+	for _, token := range []string{cpr.Session.Token, lr.Session.Token} {
+		w = withToken(`POST`, `/create/interaction`, token, interactionReq)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, `Invalid session: signed out`, readError(t, w))
+	}
+
+	w = withToken(`POST`, `/create/interaction`, `not.valid`, interactionReq)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Invalid session: invalid token`, readError(t, w))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/joshprzybyszewski/cribbage/logic/rating"
+	"github.com/joshprzybyszewski/cribbage/logic/strategy"
 	"github.com/joshprzybyszewski/cribbage/model"
 	"github.com/joshprzybyszewski/cribbage/server/interaction"
 	"github.com/joshprzybyszewski/cribbage/server/persistence"
@@ -28,6 +30,9 @@ var (
 	mysqlDBName = flag.String(`mysql_db`, `cribbage`, `The name of the Database to connect to in mysql`)
 
 	createTables = flag.Bool(`mysql_create_tables`, false, `Set to true when you want to create tables on startup.`)
+
+	monteCarloIterations = flag.Int(`monte_carlo_iterations`, strategy.DefaultSearchBudget.Iterations, `The most iterations the monte carlo NPC searches before pegging a card`) //nolint:lll
+	monteCarloDuration   = flag.Duration(`monte_carlo_duration`, strategy.DefaultSearchBudget.Duration, `The longest the monte carlo NPC searches before pegging a card`)        //nolint:lll
 )
 
 // Setup connects to a database and starts serving requests
@@ -42,11 +47,20 @@ func Setup() error {
 	if err != nil {
 		return err
 	}
-	cs := newCribbageServer(dbFactory)
+	signer, err := getSigner()
+	if err != nil {
+		return err
+	}
+	cs := newCribbageServer(dbFactory, signer)
+	interaction.SetMonteCarloBudget(strategy.SearchBudget{
+		Iterations: *monteCarloIterations,
+		Duration:   *monteCarloDuration,
+	})
 	err = seedNPCs(ctx, dbFactory)
 	if err != nil {
 		return err
 	}
+	go cs.runTurnTimers(ctx, *turnTimerInterval)
 	cs.Serve()
 
 	return nil
@@ -87,6 +101,11 @@ func getDBFactory(ctx context.Context, cfg factoryConfig) (persistence.DBFactory
 	return nil, fmt.Errorf(`db "%s" not supported. Currently supported: "mongo", "mysql", and "memory"`, *database)
 }
 
+var npcRatingPool = model.RatingPool{
+	NumPlayers: 2,
+	Variant:    model.StandardVariant,
+}
+
 func seedNPCs(ctx context.Context, dbFactory persistence.DBFactory) error {
 	db, err := dbFactory.New(ctx)
 	if err != nil {
@@ -100,7 +119,12 @@ func seedNPCs(ctx context.Context, dbFactory persistence.DBFactory) error {
 	}
 	defer commitOrRollback(db, &err)
 
-	npcIDs := []model.PlayerID{interaction.Dumb, interaction.Simple, interaction.Calc}
+	npcIDs := []model.PlayerID{
+		interaction.Dumb,
+		interaction.Simple,
+		interaction.Calc,
+		interaction.MonteCarlo,
+	}
 	for _, id := range npcIDs {
 		p := model.Player{
 			ID:   id,
@@ -129,6 +153,19 @@ func seedNPCs(ctx context.Context, dbFactory persistence.DBFactory) error {
 				return err
 			}
 		}
+		// the NPCs are on the heads up leaderboard from the start, so that
+		// players have something to measure themselves against
+		_, err = db.GetRating(id, npcRatingPool)
+		if err != nil {
+			if err == persistence.ErrRatingNotFound {
+				err = db.SaveRating(rating.New(id, npcRatingPool))
+				if err != nil {
+					return err
+				}
+			} else {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+// GET /game/:gameID/spectators
+func (cs *cribbageServer) ginGetSpectators(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	pIDs, err := getSpectators(ctx, db, gID)
+	if err != nil {
+		if err == persistence.ErrGameNotFound {
+			c.String(http.StatusNotFound, `Game not found`)
+			return
+		}
+		c.String(http.StatusInternalServerError, `Error: %s`, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetSpectatorsResponse(gID, pIDs))
+}
+
+// POST /game/:gameID/spectators
+func (cs *cribbageServer) ginPostSpectator(c *gin.Context) {
+	gID, err := getGameIDFromContext(c)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
+		return
+	}
+	var isr network.InviteSpectatorRequest
+	err = c.ShouldBindJSON(&isr)
+	if err != nil {
+		c.String(http.StatusBadRequest, `Error: %s`, err)
+		return
+	}
+	inviter, _ := authedPlayer(c)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `dbFactory.New() error: %s`, err)
+		return
+	}
+	defer db.Close()
+
+	pIDs, err := inviteSpectator(ctx, db, gID, inviter, isr.PlayerID)
+	if err != nil {
+		switch err {
+		case persistence.ErrGameNotFound:
+			c.String(http.StatusNotFound, `Game not found`)
+		case persistence.ErrPlayerNotFound:
+			c.String(http.StatusNotFound, `Player not found`)
+		case errInviterNotPlaying:
+			c.String(http.StatusForbidden, `Only players in the game can invite spectators`)
+		case errSpectatorIsPlaying, persistence.ErrAlreadySpectating, persistence.ErrInvalidPlayerID:
+			c.String(http.StatusBadRequest, `Error: %s`, err)
+		default:
+			c.String(http.StatusInternalServerError, `Error: %s`, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, network.ConvertToGetSpectatorsResponse(gID, pIDs))
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+)
+
+func TestGinPostSpectator(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 4)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	g, err := createGame(ctx, db, pIDs[:2], model.GameOptions{})
+	require.NoError(t, err)
+	url := fmt.Sprintf(`/game/%d/spectators`, g.ID)
+
+	w, err := performRequest(router, `POST`, url, prepareBody(t, network.InviteSpectatorRequest{PlayerID: `p3`}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	for _, tc := range []struct {
+		msg     string
+		url     string
+		authAs  model.PlayerID
+		invitee model.PlayerID
+		expCode int
+		expErr  string
+	}{{
+		msg:     `game doesn't exist`,
+		url:     `/game/123/spectators`,
+		authAs:  `p1`,
+		invitee: `p3`,
+		expCode: http.StatusNotFound,
+		expErr:  `Game not found`,
+	}, {
+		msg:     `inviter isn't playing`,
+		url:     url,
+		authAs:  `p4`,
+		invitee: `p3`,
+		expCode: http.StatusForbidden,
+		expErr:  `Only players in the game can invite spectators`,
+	}, {
+		msg:     `invitee doesn't exist`,
+		url:     url,
+		authAs:  `p1`,
+		invitee: `p9`,
+		expCode: http.StatusNotFound,
+		expErr:  `Player not found`,
+	}, {
+		msg:     `invitee is playing`,
+		url:     url,
+		authAs:  `p1`,
+		invitee: `p2`,
+		expCode: http.StatusBadRequest,
+		expErr:  `Error: players cannot spectate their own game`,
+	}} {
+		body := prepareBody(t, network.InviteSpectatorRequest{PlayerID: tc.invitee})
+		w := performAuthedRequest(t, cs, router, tc.authAs, `POST`, tc.url, body)
+		assert.Equal(t, tc.expCode, w.Code, tc.msg)
+		assert.Equal(t, tc.expErr, readError(t, w), tc.msg)
+	}
+
+	body := prepareBody(t, network.InviteSpectatorRequest{PlayerID: `p3`})
+	w = performAuthedRequest(t, cs, router, `p1`, `POST`, url, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp network.GetSpectatorsResponse
+	readBody(t, w.Body, &resp)
+	assert.Equal(t, network.GetSpectatorsResponse{
+		GameID:     g.ID,
+		Spectators: []model.PlayerID{`p3`},
+	}, resp)
+
+	body = prepareBody(t, network.InviteSpectatorRequest{PlayerID: `p3`})
+	w = performAuthedRequest(t, cs, router, `p2`, `POST`, url, body)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Error: player is already spectating`, readError(t, w))
+}
+
+func TestGinGetSpectators(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 3)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	g, err := createGame(ctx, db, pIDs[:2], model.GameOptions{})
+	require.NoError(t, err)
+	url := fmt.Sprintf(`/game/%d/spectators`, g.ID)
+
+	w, err := performRequest(router, `GET`, `/game/123/spectators`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w, err = performRequest(router, `GET`, url, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp network.GetSpectatorsResponse
+	readBody(t, w.Body, &resp)
+	assert.Empty(t, resp.Spectators)
+
+	_, err = inviteSpectator(ctx, db, g.ID, pIDs[0], pIDs[2])
+	require.NoError(t, err)
+
+	w, err = performRequest(router, `GET`, url, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code)
+	readBody(t, w.Body, &resp)
+	assert.Equal(t, []model.PlayerID{pIDs[2]}, resp.Spectators)
+}
+
+func TestSpectatorEvents(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 3)
+	spectator := pIDs[2]
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+	g, err := createGame(ctx, db, pIDs[:2], model.GameOptions{})
+	require.NoError(t, err)
+
+	// spectators can't listen in until they're invited
+	url := fmt.Sprintf(`/game/%d/events?player=%s`, g.ID, spectator)
+	w := performAuthedRequest(t, cs, router, spectator, `GET`, url, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `Player not in game`, readError(t, w))
+
+	_, err = inviteSpectator(ctx, db, g.ID, pIDs[0], spectator)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	req, err := http.NewRequest(`GET`, srv.URL+url, nil)
+	require.NoError(t, err)
+	token, _, err := cs.signer.Sign(spectator, time.Now())
+	require.NoError(t, err)
+	req.Header.Set(`Authorization`, bearerPrefix+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		gameEvents.lock.Lock()
+		defer gameEvents.lock.Unlock()
+		return len(gameEvents.subscribers[g.ID]) > 0
+	}, time.Second, time.Millisecond)
+
+	// dealing hands out cards that the spectator can't see, and then moves on to
+	// building the crib, which they can
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        g.CurrentDealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 3},
+	}))
+
+	r := bufio.NewReader(resp.Body)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event:event\n", line)
+	line, err = r.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, `data:{"type":"event"`), line)
+	assert.Contains(t, line, `"type":"PhaseChanged"`)
+	assert.NotContains(t, line, `CardDealt`)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/network"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+	"github.com/joshprzybyszewski/cribbage/server/play"
+)
+
+func resignGame(t *testing.T, db persistence.DB, pIDs []model.PlayerID, resigner model.PlayerID) model.GameID {
+	ctx := context.Background()
+	g, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        g.CurrentDealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 4},
+	}))
+	require.NoError(t, handleAction(ctx, db, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        resigner,
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}))
+	return g.ID
+}
+
+func TestGinGetPlayerStats(t *testing.T) {
+	cs, router := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	getStats := func(pID model.PlayerID) network.GetPlayerStatsResponse {
+		w, err := performRequest(router, http.MethodGet, `/player/`+string(pID)+`/stats`, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp network.GetPlayerStatsResponse
+		readBody(t, w.Body, &resp)
+		return resp
+	}
+
+	resp := getStats(pIDs[0])
+	assert.Equal(t, pIDs[0], resp.Player.ID)
+	assert.Zero(t, resp.Games)
+
+	resignGame(t, db, pIDs, pIDs[1])
+	resp = getStats(pIDs[0])
+	assert.Equal(t, 1, resp.Games)
+	assert.Equal(t, 1, resp.Wins)
+	assert.Equal(t, 0, resp.Losses)
+	resp = getStats(pIDs[1])
+	assert.Equal(t, 1, resp.Games)
+	assert.Equal(t, 1, resp.Losses)
+
+	// each game that finishes is added to the stats already kept
+	resignGame(t, db, pIDs, pIDs[0])
+	resp = getStats(pIDs[0])
+	assert.Equal(t, 2, resp.Games)
+	assert.Equal(t, 1, resp.Wins)
+	assert.Equal(t, 1, resp.Losses)
+
+	w, err := performRequest(router, http.MethodGet, `/player/p3/stats`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `Player not found`, readError(t, w))
+}
+
+func TestGetPlayerStatsTalliesFinishedGames(t *testing.T) {
+	cs, _ := newServerAndRouter(t)
+	pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+	ctx := context.Background()
+	db, err := cs.dbFactory.New(ctx)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// games finished before stats were kept don't have any stats saved
+	g, err := createGame(ctx, db, pIDs, model.GameOptions{})
+	require.NoError(t, err)
+	pAPIs, err := getPlayerAPIs(db, g.Players)
+	require.NoError(t, err)
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        g.CurrentDealer,
+		Overcomes: model.DealCards,
+		Action:    model.DealAction{NumShuffles: 4},
+	}, pAPIs))
+	require.NoError(t, db.SaveGame(g))
+	require.NoError(t, play.HandleAction(&g, model.PlayerAction{
+		GameID:    g.ID,
+		ID:        pIDs[0],
+		Overcomes: model.Resign,
+		Action:    model.ResignAction{},
+	}, pAPIs))
+	require.NoError(t, db.SaveGame(g))
+	_, err = db.GetPlayerStats(pIDs[1])
+	require.Equal(t, persistence.ErrStatsNotFound, err)
+
+	_, ps, err := getPlayerStats(ctx, db, pIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, 1, ps.Wins)
+	assert.Equal(t, 1, ps.Deals)
+
+	saved, err := db.GetPlayerStats(pIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, ps, saved)
+
+	// the stats of players who haven't been looked up are tallied when their next game ends
+	resignGame(t, db, pIDs, pIDs[1])
+	saved, err = db.GetPlayerStats(pIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved.Games())
+	assert.Equal(t, 1, saved.Wins)
+}
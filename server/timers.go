@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+	"github.com/joshprzybyszewski/cribbage/server/interaction"
+	"github.com/joshprzybyszewski/cribbage/server/persistence"
+)
+
+var (
+	turnTimerInterval = flag.Duration(`turn_timer_interval`, 5*time.Second, `How often to look for players out of time`)
+)
+
+// runTurnTimers checks for players who have run out of time to act until the context
+// is done. The deadlines are persisted with the games, so a restarted server picks up
+// the timers where the last one left off.
+func (cs *cribbageServer) runTurnTimers(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			err := cs.expireTurns(ctx, now)
+			if err != nil {
+				log.Printf("expireTurns errored: %+v\n", err)
+			}
+		}
+	}
+}
+
+// expireTurns applies the timeout policy of every game that's waiting on a player
+// whose deadline isn't after now
+func (cs *cribbageServer) expireTurns(ctx context.Context, now time.Time) error {
+	db, err := cs.dbFactory.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	gIDs, err := db.GetOverdueGames(now)
+	if err != nil {
+		return err
+	}
+
+	for _, gID := range gIDs {
+		err = expireGameTurns(ctx, db, gID, now)
+		if err != nil {
+			// one stuck game shouldn't hold up the timers on the rest
+			log.Printf("Could not expire turns in game %d: %+v\n", gID, err)
+		}
+	}
+
+	return nil
+}
+
+// expireGameTurns deals with each of the game's overdue players, one at a time,
+// since dealing with one can change what the game is waiting on
+func expireGameTurns(ctx context.Context, db persistence.DB, gID model.GameID, now time.Time) error {
+	for {
+		g, err := getGame(ctx, db, gID)
+		if err != nil {
+			return err
+		}
+
+		overdue := g.Overdue(now)
+		if len(overdue) == 0 {
+			return nil
+		}
+
+		err = expireTurn(ctx, db, g, overdue[0], now)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func expireTurn(
+	ctx context.Context,
+	db persistence.DB,
+	g model.Game,
+	pID model.PlayerID,
+	now time.Time,
+) error {
+
+	if g.BlockingPlayers[pID] == model.Undo {
+		// nobody gets to take back an action because someone else walked away,
+		// so an unanswered undo is denied no matter the policy
+		_, err := handleUndo(ctx, db, model.PlayerAction{
+			GameID:    g.ID,
+			ID:        pID,
+			Overcomes: model.Undo,
+			Action:    model.UndoAction{},
+		})
+		return err
+	}
+
+	switch g.Options.TimeoutPolicy {
+	case model.ForfeitGame:
+		return handleForfeit(ctx, db, model.PlayerAction{
+			GameID:    g.ID,
+			ID:        pID,
+			Overcomes: model.Forfeit,
+			Action:    model.ForfeitAction{TimedOut: true},
+		})
+	case model.Nudge:
+		return nudgePlayer(ctx, db, g.ID, pID, now)
+	}
+
+	action, err := interaction.AutoPlayAction(pID, g)
+	if err != nil {
+		return err
+	}
+	return handleAction(ctx, db, action)
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/joshprzybyszewski/cribbage/model"
+)
+
+func TestExpireTurns(t *testing.T) {
+	testCases := []struct {
+		msg    string
+		policy model.TimeoutPolicy
+		check  func(t *testing.T, before, after model.Game)
+	}{{
+		msg:    `autoplay`,
+		policy: model.AutoPlay,
+		check: func(t *testing.T, before, after model.Game) {
+			require.NotEmpty(t, after.Actions)
+			assert.Equal(t, model.DealCards, after.Actions[0].Overcomes)
+			assert.Equal(t, model.CribCard, after.BlockingPlayers[before.Players[0].ID])
+			assert.Nil(t, after.Result)
+		},
+	}, {
+		msg:    `forfeit`,
+		policy: model.ForfeitGame,
+		check: func(t *testing.T, before, after model.Game) {
+			require.NotNil(t, after.Result)
+			assert.True(t, after.Result.Forfeit)
+			assert.Equal(t, after.PlayerColors[before.Players[1].ID], after.Result.Winner)
+			assert.Empty(t, after.Deadlines)
+		},
+	}, {
+		msg:    `nudge`,
+		policy: model.Nudge,
+		check: func(t *testing.T, before, after model.Game) {
+			dealer := before.Players[0].ID
+			assert.Equal(t, before.NumActions(), after.NumActions())
+			assert.Equal(t, model.DealCards, after.BlockingPlayers[dealer])
+			assert.True(t, after.Deadlines[dealer].After(before.Deadlines[dealer]))
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			cs, _ := newServerAndRouter(t)
+			pIDs := seedPlayers(t, cs.dbFactory, 2)
+
+			ctx := context.Background()
+			db, err := cs.dbFactory.New(ctx)
+			require.NoError(t, err)
+			defer db.Close()
+
+			g, err := createGame(ctx, db, pIDs, model.GameOptions{
+				TurnTimeLimit: time.Minute,
+				TimeoutPolicy: tc.policy,
+			})
+			require.NoError(t, err)
+			require.Equal(t, model.DealCards, g.BlockingPlayers[g.Players[0].ID])
+
+			require.NoError(t, cs.expireTurns(ctx, time.Now()))
+			notYet, err := getGame(ctx, db, g.ID)
+			require.NoError(t, err)
+			assert.Equal(t, g.NumActions(), notYet.NumActions())
+			assert.Equal(t, g.Deadlines, notYet.Deadlines)
+
+			require.NoError(t, cs.expireTurns(ctx, time.Now().Add(time.Minute)))
+			after, err := getGame(ctx, db, g.ID)
+			require.NoError(t, err)
+			tc.check(t, g, after)
+		})
+	}
+}
@@ -115,6 +115,9 @@ func (cs *cribbageServer) handleWasmGetUsernameGame(c *gin.Context) { //nolint:g
 		c.String(http.StatusBadRequest, `Invalid GameID: %v`, err)
 		return
 	}
+	if !canViewHand(c, pID) {
+		return
+	}
 
 	ctx := context.Background()
 	db, err := cs.dbFactory.New(ctx)
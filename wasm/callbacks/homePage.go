@@ -27,6 +27,7 @@ func addHomePageCallbacks() []Releaser {
 	var r []Releaser
 
 	r = append(r, getListenersForCreateUser()...)
+	r = append(r, getListenersForSignIn()...)
 
 	return r
 }
@@ -39,10 +40,12 @@ func getListenersForCreateUser() []Releaser {
 	submitButton := doc.GetElementByID(consts.CreateUserButtonID).(*dom.HTMLButtonElement)
 	usernameInput := doc.GetElementByID(consts.CreateUsernameInputID).(*dom.HTMLInputElement)
 	displayNameInput := doc.GetElementByID(consts.CreateDisplaynameInputID).(*dom.HTMLInputElement)
+	passwordInput := doc.GetElementByID(consts.CreatePasswordInputID).(*dom.HTMLInputElement)
 
 	recalcEnabled := func() {
 		oldDisabled := submitButton.Disabled()
-		newDisabled := len(usernameInput.Value()) == 0 || len(displayNameInput.Value()) == 0
+		newDisabled := len(usernameInput.Value()) == 0 || len(displayNameInput.Value()) == 0 ||
+			len(passwordInput.Value()) == 0
 		if newDisabled != oldDisabled {
 			submitButton.SetDisabled(newDisabled)
 		}
@@ -55,6 +58,8 @@ func getListenersForCreateUser() []Releaser {
 	r = append(r, getInputHandlerForID(consts.CreateUsernameInputID, cb))
 	r = append(r, getChangeHandlerForID(consts.CreateDisplaynameInputID, cb))
 	r = append(r, getInputHandlerForID(consts.CreateDisplaynameInputID, cb))
+	r = append(r, getChangeHandlerForID(consts.CreatePasswordInputID, cb))
+	r = append(r, getInputHandlerForID(consts.CreatePasswordInputID, cb))
 
 	listener := getClickHandlerForID(consts.CreateUserButtonID, func(e dom.Event) {
 		e.PreventDefault()
@@ -65,6 +70,7 @@ func getListenersForCreateUser() []Releaser {
 				ID:   model.PlayerID(username),
 				Name: displayname,
 			},
+			Password: passwordInput.Value(),
 		}
 
 		go func() {
@@ -88,3 +94,44 @@ func getListenersForCreateUser() []Releaser {
 	r = append(r, listener)
 	return r
 }
+
+func getListenersForSignIn() []Releaser {
+	var r []Releaser
+
+	doc := dom.GetWindow().Document()
+
+	usernameInput := doc.GetElementByID(consts.SignInUsernameInputID).(*dom.HTMLInputElement)
+	passwordInput := doc.GetElementByID(consts.SignInPasswordInputID).(*dom.HTMLInputElement)
+
+	listener := getClickHandlerForID(consts.SignInButtonID, func(e dom.Event) {
+		e.PreventDefault()
+		lr := network.LoginRequest{
+			Username: model.PlayerID(usernameInput.Value()),
+			Password: passwordInput.Value(),
+		}
+
+		go func() {
+			inputBytes, err := json.Marshal(lr)
+			if err != nil {
+				println("Got error on json.Marshal: " + err.Error())
+				return
+			}
+			// the server sets the session cookie that the rest of the pages use
+			respBytes, err := actions.MakeRequest(`POST`, `/auth/login`, bytes.NewBuffer(inputBytes))
+			if err != nil {
+				println("Got error on MakeRequest: " + err.Error())
+				return
+			}
+			me := network.LoginResponse{}
+			err = json.Unmarshal(respBytes, &me)
+			if err != nil {
+				println("Got error on json.Unmarshal LoginResponse: " + err.Error())
+				return
+			}
+			goToPath(`/user/` + string(me.Player.ID))
+		}()
+	})
+
+	r = append(r, listener)
+	return r
+}
@@ -5,6 +5,11 @@ const (
 	CreateUserButtonID       string = `createUserButton`
 	CreateUsernameInputID    string = `createUN`
 	CreateDisplaynameInputID string = `createUN`
+	CreatePasswordInputID    string = `createPW`
+
+	SignInButtonID        string = `signInButton`
+	SignInUsernameInputID string = `signInUN`
+	SignInPasswordInputID string = `signInPW`
 )
 
 // user page